@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/eirini-controller/config"
+	"code.cloudfoundry.org/eirini-controller/doctor"
+	eirinischeme "code.cloudfoundry.org/eirini-controller/pkg/generated/clientset/versioned/scheme"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const doctorTimeout = time.Minute
+
+type doctorOptions struct{}
+
+// runDoctor checks the prerequisites of the controller in the cluster of the
+// configured kubeconfig, and exits with 1 when any check fails.
+func runDoctor(opts options) {
+	cfg, err := config.Load(opts.ConfigFile)
+	exitfIfError(err, "Failed to read config file")
+
+	kubeConfig, err := clientcmd.BuildConfigFromFlags("", cfg.ConfigPath)
+	exitfIfError(err, "Failed to build kubeconfig")
+
+	err = apiextensionsv1.AddToScheme(eirinischeme.Scheme)
+	exitfIfError(err, "Failed to add the apiextensions scheme")
+
+	controllerClient, err := client.New(kubeConfig, client.Options{Scheme: eirinischeme.Scheme})
+	exitfIfError(err, "Failed to create k8s runtime client")
+
+	ctx, cancel := context.WithTimeout(context.Background(), doctorTimeout)
+	defer cancel()
+
+	results := doctor.NewDoctor(controllerClient, cfg).Run(ctx)
+	exitfIfError(doctor.WriteReport(os.Stdout, results), "Failed to write report")
+
+	if doctor.Failed(results) {
+		cancel()
+		os.Exit(1)
+	}
+}
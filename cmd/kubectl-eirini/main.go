@@ -0,0 +1,107 @@
+// kubectl-eirini is a kubectl plugin showing the LRPs and tasks of a cluster,
+// with the state of their instances and crashes. Installed in the PATH, it
+// runs as `kubectl eirini`.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"code.cloudfoundry.org/eirini-controller/pkg/generated/clientset/versioned"
+	"code.cloudfoundry.org/eirini-controller/plugin"
+	"github.com/jessevdk/go-flags"
+	"k8s.io/client-go/kubernetes"
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/utils/clock"
+)
+
+type lrpArgs struct {
+	LRP string `positional-arg-name:"lrp" description:"Name of the LRP"`
+}
+
+type options struct {
+	Namespace     string `short:"n" long:"namespace" description:"Namespace of the workloads, defaults to the one of the kubeconfig context"`
+	AllNamespaces bool   `short:"A" long:"all-namespaces" description:"List the workloads of all namespaces, not supported by instances and crashes"`
+	Kubeconfig    string `long:"kubeconfig" description:"Path to the kubeconfig file, defaults to $KUBECONFIG or ~/.kube/config"`
+	Context       string `long:"context" description:"Name of the kubeconfig context to use"`
+
+	Apps      struct{} `command:"apps" description:"List the LRPs with their desired and ready instances"`
+	Instances struct {
+		Args lrpArgs `positional-args:"yes" required:"yes"`
+	} `command:"instances" description:"List the instances of an LRP with their state, node, restarts and last crash"`
+	Crashes struct {
+		Args lrpArgs `positional-args:"yes" required:"yes"`
+	} `command:"crashes" description:"List the crash events of the instances of an LRP"`
+	Tasks struct{} `command:"tasks" description:"List the tasks with their state, duration and failure reason"`
+}
+
+func main() {
+	var opts options
+	parser := flags.NewParser(&opts, flags.Default)
+	parser.Name = "kubectl-eirini"
+
+	if _, err := parser.Parse(); err != nil {
+		if flags.WroteHelp(err) {
+			os.Exit(0)
+		}
+
+		os.Exit(1)
+	}
+
+	if opts.AllNamespaces && (parser.Active.Name == "instances" || parser.Active.Name == "crashes") {
+		fmt.Fprintf(os.Stderr, "The %s command does not support --all-namespaces, LRP names are only unique within a namespace\n", parser.Active.Name)
+		os.Exit(1)
+	}
+
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = opts.Kubeconfig
+
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		loadingRules,
+		&clientcmd.ConfigOverrides{CurrentContext: opts.Context},
+	)
+
+	kubeConfig, err := clientConfig.ClientConfig()
+	exitfIfError(err, "Failed to load kubeconfig")
+
+	namespace := opts.Namespace
+	if namespace == "" {
+		namespace, _, err = clientConfig.Namespace()
+		exitfIfError(err, "Failed to get the namespace of the kubeconfig context")
+	}
+
+	if opts.AllNamespaces {
+		namespace = ""
+	}
+
+	eiriniClient, err := versioned.NewForConfig(kubeConfig)
+	exitfIfError(err, "Failed to create eirini client")
+
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	exitfIfError(err, "Failed to create k8s client")
+
+	inspector := plugin.NewInspector(eiriniClient, kubeClient, clock.RealClock{})
+	ctx := context.Background()
+
+	switch parser.Active.Name {
+	case "apps":
+		err = inspector.Apps(ctx, os.Stdout, namespace)
+	case "instances":
+		err = inspector.Instances(ctx, os.Stdout, namespace, opts.Instances.Args.LRP)
+	case "crashes":
+		err = inspector.Crashes(ctx, os.Stdout, namespace, opts.Crashes.Args.LRP)
+	case "tasks":
+		err = inspector.Tasks(ctx, os.Stdout, namespace)
+	}
+
+	exitfIfError(err, "Failed to inspect workloads")
+}
+
+func exitfIfError(err error, message string) {
+	if err != nil {
+		fmt.Fprintln(os.Stderr, fmt.Errorf("%s: %w", message, err))
+		os.Exit(1)
+	}
+}
@@ -1,39 +1,61 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"io/ioutil"
 	"os"
-	"path/filepath"
+	"os/signal"
+	"syscall"
 
 	eirinictrl "code.cloudfoundry.org/eirini-controller"
 	"code.cloudfoundry.org/eirini-controller/cmd/wiring"
+	"code.cloudfoundry.org/eirini-controller/config"
+	"code.cloudfoundry.org/eirini-controller/k8s/shard"
 	eirinischeme "code.cloudfoundry.org/eirini-controller/pkg/generated/clientset/versioned/scheme"
+	"code.cloudfoundry.org/eirini-controller/tracing"
 	"code.cloudfoundry.org/eirini-controller/util"
 	"code.cloudfoundry.org/lager"
 	"github.com/jessevdk/go-flags"
-	"github.com/pkg/errors"
-	"gopkg.in/yaml.v2"
 	kscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	"k8s.io/client-go/tools/clientcmd"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
 type options struct {
 	ConfigFile string `short:"c" long:"config" description:"Config for running eirini-controller"`
+
+	Render renderOptions `command:"render" description:"Print the objects created for an LRP or a Task, without accessing the cluster"`
+	Doctor doctorOptions `command:"doctor" description:"Check the CRDs, webhooks, namespaces, secrets and service accounts the controller needs"`
 }
 
 type wiringFunc func(loger lager.Logger, manager manager.Manager, config eirinictrl.ControllerConfig) error
 
-func getWirings() []wiringFunc {
+type shardedWiringFunc func(loger lager.Logger, manager manager.Manager, config eirinictrl.ControllerConfig, claimer *shard.Claimer) error
+
+func getWirings(claimer *shard.Claimer) []wiringFunc {
 	return []wiringFunc{
-		wiring.LRPReconciler,
-		wiring.PodCrashReconciler,
-		wiring.TaskReconciler,
+		sharded(wiring.LRPReconciler, claimer),
+		sharded(wiring.PodCrashReconciler, claimer),
+		sharded(wiring.TaskReconciler, claimer),
+		sharded(wiring.InstancesCollector, claimer),
+		wiring.LeaderLeaseCollector,
 		wiring.ResourceValidator,
+		wiring.TaskResourceValidator,
+		wiring.ResourceDefaulter,
+		wiring.ConversionWebhook,
 		wiring.InstanceIndexEnvInjector,
+		wiring.HealthProbes,
+	}
+}
+
+// sharded passes the shard claimer shared by the reconcilers and the
+// instances collector to the wiring.
+func sharded(wire shardedWiringFunc, claimer *shard.Claimer) wiringFunc {
+	return func(logger lager.Logger, manager manager.Manager, config eirinictrl.ControllerConfig) error {
+		return wire(logger, manager, config, claimer)
 	}
 }
 
@@ -43,11 +65,23 @@ func main() {
 	}
 
 	var opts options
-	_, err := flags.ParseArgs(&opts, os.Args)
+	parser := flags.NewParser(&opts, flags.Default)
+	parser.SubcommandsOptional = true
+	_, err := parser.ParseArgs(os.Args[1:])
 	exitfIfError(err, "Failed to parse args")
 
-	var cfg eirinictrl.ControllerConfig
-	err = readConfigFile(opts.ConfigFile, &cfg)
+	if parser.Active != nil {
+		switch parser.Active.Name {
+		case "render":
+			renderWorkload(opts)
+		case "doctor":
+			runDoctor(opts)
+		}
+
+		return
+	}
+
+	cfg, err := config.Load(opts.ConfigFile)
 	exitfIfError(err, "Failed to read config file")
 
 	kubeConfig, err := clientcmd.BuildConfigFromFlags("", cfg.ConfigPath)
@@ -55,8 +89,21 @@ func main() {
 
 	exitfIfError(err, "Failed to create k8s runtime client")
 
-	logger := lager.NewLogger("eirini-controller")
-	logger.RegisterSink(lager.NewPrettySink(os.Stdout, lager.DEBUG))
+	logger, levelSink, err := util.NewLogger("eirini-controller", cfg.Logging, os.Stdout)
+	exitfIfError(err, "Failed to create logger")
+
+	configWatcher := config.NewWatcher(logger, opts.ConfigFile, cfg)
+	configWatcher.OnReload(func(newCfg eirinictrl.ControllerConfig) {
+		if err := levelSink.SetLevels(newCfg.Logging.Level, newCfg.Logging.Components); err != nil {
+			logger.Error("failed-to-set-log-levels", err)
+		}
+	})
+	cfg.Reloader = configWatcher
+
+	reloadConfigOnSIGHUP(configWatcher)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	exitfIfError(err, "Failed to set up tracing")
 
 	certDir := getEnvOrDefault(
 		eirinictrl.EnvEiriniCertsDir,
@@ -66,13 +113,29 @@ func main() {
 	managerOptions := manager.Options{
 		MetricsBindAddress: "0",
 		Scheme:             eirinischeme.Scheme,
-		Namespace:          cfg.WorkloadsNamespace,
-		Logger:             util.NewLagerLogr(logger),
-		LeaderElection:     true,
-		LeaderElectionID:   "eirini-controller-leader",
-		CertDir:            certDir,
-		Host:               "0.0.0.0",
-		Port:               int(cfg.WebhookPort),
+		Logger:             util.NewLagerLogr(logger.Session("controller-runtime"), levelSink),
+		// Sharded replicas are all active, each holding the leases of its
+		// own shards instead of a single leader lease.
+		LeaderElection:   !cfg.Sharding.Enabled,
+		LeaderElectionID: eirinictrl.DefaultLeaderElectionID,
+		CertDir:          certDir,
+		Host:             "0.0.0.0",
+		Port:             int(cfg.WebhookPort),
+	}
+
+	// A single namespace keeps the plain namespaced cache, several get one
+	// informer per namespace. A namespace selector alone needs to see them
+	// all, and leaves the filtering to the reconcilers.
+	switch namespaces := cfg.Namespaces(); len(namespaces) {
+	case 0:
+	case 1:
+		managerOptions.Namespace = namespaces[0]
+	default:
+		managerOptions.NewCache = cache.MultiNamespacedCacheBuilder(namespaces)
+	}
+
+	if cfg.HealthProbePort > 0 {
+		managerOptions.HealthProbeBindAddress = fmt.Sprintf(":%d", cfg.HealthProbePort)
 	}
 
 	if cfg.PrometheusPort > 0 {
@@ -87,25 +150,47 @@ func main() {
 	mgr, err := manager.New(kubeConfig, managerOptions)
 	exitfIfError(err, "Failed to create k8s controller runtime manager")
 
-	for _, wire := range getWirings() {
+	exitfIfError(mgr.Add(configWatcher), "Failed to add the config watcher")
+
+	// The metrics port is reachable by anyone reaching the pod, so the
+	// levels are only changed on a local port.
+	if cfg.PrometheusPort > 0 {
+		err = mgr.AddMetricsExtraHandler("/log-level", util.NewReadOnlyLogLevelHandler(logger, levelSink))
+		exitfIfError(err, "Failed to add the log level handler")
+	}
+
+	if cfg.Logging.Port > 0 {
+		logLevelServer := util.NewLogLevelServer(fmt.Sprintf("127.0.0.1:%d", cfg.Logging.Port), util.NewLogLevelHandler(logger, levelSink))
+		exitfIfError(mgr.Add(logLevelServer), "Failed to add the log level server")
+	}
+
+	claimer, err := wiring.ShardClaimer(logger, mgr, cfg)
+	exitfIfError(err, "Failed to create the shard claimer")
+
+	for _, wire := range getWirings(claimer) {
 		exitfIfError(wire(logger, mgr, cfg), "wiring failure")
 	}
 
 	err = mgr.Start(ctrl.SetupSignalHandler())
-	exitfIfError(err, "Failed to start manager")
-}
 
-func readConfigFile(path string, conf interface{}) error {
-	if path == "" {
-		return nil
+	if shutdownErr := shutdownTracing(context.Background()); shutdownErr != nil {
+		logger.Error("failed-to-flush-traces", shutdownErr)
 	}
 
-	fileBytes, err := ioutil.ReadFile(filepath.Clean(path))
-	if err != nil {
-		return errors.Wrap(err, "failed to read file")
-	}
+	exitfIfError(err, "Failed to start manager")
+}
 
-	return errors.Wrap(yaml.Unmarshal(fileBytes, conf), "failed to unmarshal yaml")
+// reloadConfigOnSIGHUP reloads the config when the process receives a
+// SIGHUP, as when its file changes.
+func reloadConfigOnSIGHUP(configWatcher *config.Watcher) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	go func() {
+		for range signals {
+			configWatcher.Reload()
+		}
+	}()
 }
 
 func exitIfError(err error) {
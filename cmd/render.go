@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/cmd/wiring"
+	"code.cloudfoundry.org/eirini-controller/config"
+	eirinischeme "code.cloudfoundry.org/eirini-controller/pkg/generated/clientset/versioned/scheme"
+	"code.cloudfoundry.org/eirini-controller/render"
+	"code.cloudfoundry.org/lager"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type renderOptions struct {
+	File string `short:"f" long:"file" required:"true" description:"YAML file with the LRP or Task to render, and optionally the WorkloadDefaults of its namespace"`
+	Diff string `long:"diff" description:"YAML file with the live objects to compare the rendered objects with, exiting with 1 when they differ"`
+}
+
+// renderWorkload prints the objects the controller would create for the LRP
+// or Task of the file, without accessing any cluster.
+func renderWorkload(opts options) {
+	cfg, err := config.Load(opts.ConfigFile)
+	exitfIfError(err, "Failed to read config file")
+
+	objects, err := readObjects(opts.Render.File)
+	exitfIfError(err, "Failed to read workload file")
+
+	renderer := render.NewRenderer(eirinischeme.Scheme, newLRPDesirer(cfg), newTaskDesirer(cfg))
+
+	rendered, err := renderer.Render(context.Background(), objects)
+	exitfIfError(err, "Failed to render workload")
+
+	if opts.Render.Diff == "" {
+		exitfIfError(render.WriteObjects(os.Stdout, rendered), "Failed to write objects")
+
+		return
+	}
+
+	live, err := readObjects(opts.Render.Diff)
+	exitfIfError(err, "Failed to read live objects file")
+
+	differs, err := render.Diff(os.Stdout, rendered, live)
+	exitfIfError(err, "Failed to diff objects")
+
+	if differs {
+		os.Exit(1)
+	}
+}
+
+func newLRPDesirer(cfg eirinictrl.ControllerConfig) render.LRPDesirerFactory {
+	return func(controllerClient client.Client) (render.LRPDesirer, error) {
+		return wiring.NewLRPDesirer(lager.NewLogger("eirini-controller"), controllerClient, cfg, eirinischeme.Scheme)
+	}
+}
+
+func newTaskDesirer(cfg eirinictrl.ControllerConfig) render.TaskDesirerFactory {
+	return func(controllerClient client.Client) (render.TaskDesirer, error) {
+		return wiring.NewTaskDesirer(lager.NewLogger("eirini-controller"), controllerClient, cfg, eirinischeme.Scheme)
+	}
+}
+
+func readObjects(path string) ([]client.Object, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return render.ReadObjects(file, eirinischeme.Scheme)
+}
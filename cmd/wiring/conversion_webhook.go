@@ -0,0 +1,24 @@
+package wiring
+
+import (
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/tracing"
+	"code.cloudfoundry.org/lager"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+)
+
+// ConversionWebhook serves the conversions between the eirini API versions.
+// The webhook is given the manager scheme, which knows every version, as the
+// tracing handler hides it from the injection of the webhook server.
+func ConversionWebhook(logger lager.Logger, manager manager.Manager, config eirinictrl.ControllerConfig) error {
+	webhook := &conversion.Webhook{}
+	if err := webhook.InjectScheme(manager.GetScheme()); err != nil {
+		return errors.Wrap(err, "Failed to create the conversion webhook")
+	}
+
+	manager.GetWebhookServer().Register("/convert", tracing.NewConversionHandler("webhook.Conversion", webhook))
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package wiring
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/health"
+	"code.cloudfoundry.org/lager"
+	"github.com/pkg/errors"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	cacheSyncCheckTimeout = time.Second
+	defaultCertName       = "tls.crt"
+)
+
+// HealthProbes adds the readiness checks of the informer caches, the webhook
+// server and its certificate. The liveness checks are the heartbeats of the
+// reconcilers.
+func HealthProbes(logger lager.Logger, manager manager.Manager, config eirinictrl.ControllerConfig) error {
+	if err := manager.AddHealthzCheck("ping", healthz.Ping); err != nil {
+		return errors.Wrap(err, "Failed to add ping check")
+	}
+
+	if err := manager.AddReadyzCheck("informers", health.NewCacheSyncChecker(manager.GetCache(), cacheSyncCheckTimeout)); err != nil {
+		return errors.Wrap(err, "Failed to add informers check")
+	}
+
+	webhookServer := manager.GetWebhookServer()
+
+	if err := manager.AddReadyzCheck("webhook", webhookServer.StartedChecker()); err != nil {
+		return errors.Wrap(err, "Failed to add webhook check")
+	}
+
+	certName := webhookServer.CertName
+	if certName == "" {
+		certName = defaultCertName
+	}
+
+	certChecker := health.NewCertificateChecker(filepath.Join(webhookServer.CertDir, certName), clock.RealClock{})
+
+	return errors.Wrap(manager.AddReadyzCheck("webhook-certificate", certChecker), "Failed to add webhook certificate check")
+}
+
+// addHeartbeat adds a heartbeat for the named reconciler to the manager,
+// checked by its liveness probe.
+func addHeartbeat(manager manager.Manager, name string) (*health.Heartbeat, error) {
+	heartbeat := health.NewHeartbeat(name, clock.RealClock{}, health.DefaultHeartbeatInterval, health.DefaultHeartbeatTimeout)
+
+	if err := manager.Add(heartbeat); err != nil {
+		return nil, errors.Wrapf(err, "Failed to add %s heartbeat", name)
+	}
+
+	checkName := fmt.Sprintf("%s-heartbeat", name)
+
+	return heartbeat, errors.Wrapf(manager.AddHealthzCheck(checkName, heartbeat.Check), "Failed to add %s check", checkName)
+}
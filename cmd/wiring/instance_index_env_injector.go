@@ -3,6 +3,7 @@ package wiring
 import (
 	eirinictrl "code.cloudfoundry.org/eirini-controller"
 	"code.cloudfoundry.org/eirini-controller/k8s/webhook"
+	"code.cloudfoundry.org/eirini-controller/tracing"
 	"code.cloudfoundry.org/lager"
 	"github.com/pkg/errors"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -19,7 +20,7 @@ func InstanceIndexEnvInjector(logger lager.Logger, manager manager.Manager, conf
 	}
 
 	manager.GetWebhookServer().Register("/", &admission.Webhook{
-		Handler: webhook.NewInstanceIndexEnvInjector(logger, decoder),
+		Handler: tracing.NewAdmissionHandler("webhook.InstanceIndexEnvInjector", webhook.NewInstanceIndexEnvInjector(logger, decoder)),
 	})
 
 	return nil
@@ -0,0 +1,46 @@
+package wiring
+
+import (
+	"context"
+
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/k8s/shard"
+	"code.cloudfoundry.org/eirini-controller/prometheus"
+	"code.cloudfoundry.org/lager"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// InstancesCollector exports the instances of the LRPs reconciled by this
+// replica: those of the served namespaces of its shards, or of all the
+// served namespaces while it is the leader.
+func InstancesCollector(logger lager.Logger, manager manager.Manager, config eirinictrl.ControllerConfig, claimer *shard.Claimer) error {
+	var matcher prometheus.NamespaceMatcher = leaderMatcher{elected: manager.Elected()}
+	if claimer != nil {
+		matcher = claimer
+	}
+
+	namespaceFilter, err := createNamespaceFilter(manager, config)
+	if err != nil {
+		return err
+	}
+
+	collector := prometheus.NewInstancesCollector(logger, manager.GetClient(), config.PrometheusInstancesBySpace, namespaceFilter, matcher)
+
+	return errors.Wrap(metrics.Registry.Register(collector), "Failed to register the instances collector")
+}
+
+// leaderMatcher matches every namespace once the replica is elected leader.
+type leaderMatcher struct {
+	elected <-chan struct{}
+}
+
+func (m leaderMatcher) Includes(context.Context, string) (bool, error) {
+	select {
+	case <-m.elected:
+		return true, nil
+	default:
+		return false, nil
+	}
+}
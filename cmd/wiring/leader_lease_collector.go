@@ -0,0 +1,44 @@
+package wiring
+
+import (
+	"os"
+	"strings"
+
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/prometheus"
+	"code.cloudfoundry.org/lager"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const inClusterNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// LeaderLeaseCollector exports the state of the lease of the elected leader.
+// Sharded replicas hold no leader lease.
+func LeaderLeaseCollector(logger lager.Logger, manager manager.Manager, config eirinictrl.ControllerConfig) error {
+	if config.Sharding.Enabled {
+		return nil
+	}
+
+	lease := client.ObjectKey{Name: eirinictrl.DefaultLeaderElectionID}
+
+	if config.LeaderElectionID != "" {
+		lease = client.ObjectKey{Namespace: config.LeaderElectionNamespace, Name: config.LeaderElectionID}
+	}
+
+	// Like the manager, fall back to the namespace of the controller pod
+	if lease.Namespace == "" {
+		namespace, err := os.ReadFile(inClusterNamespaceFile)
+		if err != nil {
+			return errors.Wrap(err, "Failed to read the leader election namespace")
+		}
+
+		lease.Namespace = strings.TrimSpace(string(namespace))
+	}
+
+	collector := prometheus.NewLeaderLeaseCollector(logger, manager.GetAPIReader(), lease)
+
+	return errors.Wrap(metrics.Registry.Register(collector), "Failed to register the leader lease collector")
+}
@@ -5,33 +5,83 @@ import (
 	"code.cloudfoundry.org/eirini-controller/k8s"
 	"code.cloudfoundry.org/eirini-controller/k8s/pdb"
 	"code.cloudfoundry.org/eirini-controller/k8s/reconciler"
+	"code.cloudfoundry.org/eirini-controller/k8s/shard"
 	"code.cloudfoundry.org/eirini-controller/k8s/stset"
 	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
 	"code.cloudfoundry.org/eirini-controller/prometheus"
+	"code.cloudfoundry.org/eirini-controller/tracing"
 	"code.cloudfoundry.org/lager"
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/clock"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
-func LRPReconciler(logger lager.Logger, manager manager.Manager, config eirinictrl.ControllerConfig) error {
+func LRPReconciler(logger lager.Logger, manager manager.Manager, config eirinictrl.ControllerConfig, claimer *shard.Claimer) error {
 	logger = logger.Session("lrp-reconciler")
 
-	lrpReconciler, err := createLRPReconciler(logger, manager.GetClient(), config, manager.GetScheme())
+	lrpReconciler, err := createLRPReconciler(logger, tracing.NewClient(manager.GetClient()), config, manager.GetScheme())
 	if err != nil {
 		return errors.Wrap(err, "Failed to create LRP reconciler")
 	}
 
-	err = builder.
+	namespaceFilter, err := createNamespaceFilter(manager, config)
+	if err != nil {
+		return err
+	}
+
+	options, err := controllerOptions(config.Reconcilers.LRP)
+	if err != nil {
+		return err
+	}
+
+	heartbeat, err := addHeartbeat(manager, "lrp")
+	if err != nil {
+		return err
+	}
+
+	decoratedReconciler, err := decorateReconciler(logger, lrpReconciler, "lrp", config.Reconcilers.LRP)
+	if err != nil {
+		return err
+	}
+
+	inNamespace := builder.WithPredicates(workloadPredicates(logger, namespaceFilter, claimer)...)
+	mapper := reconciler.NewLRPsMapper(logger, manager.GetClient(), namespaceFilter)
+
+	// The instance statuses follow the app pods, which are not owned by the
+	// LRP directly but by its StatefulSet.
+	instanceStateChanged := builder.WithPredicates(append(
+		[]predicate.Predicate{reconciler.NewInstanceStatePredicate()},
+		workloadPredicates(logger, namespaceFilter, claimer)...,
+	)...)
+
+	controllerBuilder := builder.
 		ControllerManagedBy(manager).
-		For(&eiriniv1.LRP{}).
-		Owns(&appsv1.StatefulSet{}).
-		Complete(lrpReconciler)
+		WithOptions(options).
+		For(&eiriniv1.LRP{}, inNamespace).
+		Watches(heartbeat.Source(), &handler.EnqueueRequestForObject{}).
+		Owns(&appsv1.StatefulSet{}, inNamespace).
+		Watches(
+			&source.Kind{Type: &corev1.Pod{}},
+			handler.EnqueueRequestsFromMapFunc(mapper.MapAppPod),
+			instanceStateChanged,
+		).
+		Watches(
+			&source.Kind{Type: &eiriniv1.WorkloadDefaults{}},
+			handler.EnqueueRequestsFromMapFunc(mapper.MapWorkloadDefaults),
+			inNamespace,
+		)
+
+	controllerBuilder = watchSelectedNamespaces(controllerBuilder, namespaceFilter, mapper)
+	err = watchClaimedShards(controllerBuilder, claimer, mapper).Complete(heartbeat.Decorate(decoratedReconciler))
 
 	return errors.Wrapf(err, "Failed to build LRP reconciler")
 }
@@ -43,16 +93,12 @@ func createLRPReconciler(
 	scheme *runtime.Scheme,
 ) (*reconciler.LRP, error) {
 	logger = logger.Session("lrp-reconciler")
-	lrpToStatefulSetConverter := stset.NewLRPToStatefulSetConverter(
-		cfg.ApplicationServiceAccount,
-		cfg.RegistrySecretName,
-		cfg.UnsafeAllowAutomountServiceAccountToken,
-		k8s.CreateLivenessProbe,
-		k8s.CreateReadinessProbe,
-	)
 
-	pdbUpdater := pdb.NewUpdater(controllerClient)
-	desirer := stset.NewDesirer(logger, lrpToStatefulSetConverter, pdbUpdater, controllerClient, scheme)
+	desirer, pdbUpdater, err := createLRPDesirer(logger, controllerClient, cfg, scheme)
+	if err != nil {
+		return nil, err
+	}
+
 	updater := stset.NewUpdater(logger, controllerClient, pdbUpdater)
 
 	decoratedDesirer, err := prometheus.NewLRPDesirerDecorator(desirer, metrics.Registry, clock.RealClock{})
@@ -60,10 +106,73 @@ func createLRPReconciler(
 		return nil, err
 	}
 
+	decoratedUpdater, err := prometheus.NewLRPUpdaterDecorator(updater, metrics.Registry)
+	if err != nil {
+		return nil, err
+	}
+
 	return reconciler.NewLRP(
 		logger,
 		controllerClient,
 		decoratedDesirer,
-		updater,
+		decoratedUpdater,
 	), nil
 }
+
+// NewLRPDesirer creates the desirer the LRP reconciler uses to create the
+// StatefulSet, PDB, registry secret and service account of new LRPs.
+func NewLRPDesirer(
+	logger lager.Logger,
+	controllerClient client.Client,
+	cfg eirinictrl.ControllerConfig,
+	scheme *runtime.Scheme,
+) (*stset.Desirer, error) {
+	desirer, _, err := createLRPDesirer(logger, controllerClient, cfg, scheme)
+
+	return desirer, err
+}
+
+func createLRPDesirer(
+	logger lager.Logger,
+	controllerClient client.Client,
+	cfg eirinictrl.ControllerConfig,
+	scheme *runtime.Scheme,
+) (*stset.Desirer, *pdb.Updater, error) {
+	switch cfg.TopologySpreadPolicy {
+	case "", eiriniv1.TopologySpreadPolicySoft, eiriniv1.TopologySpreadPolicyHard:
+	default:
+		return nil, nil, errors.Errorf("invalid topology spread policy %q", cfg.TopologySpreadPolicy)
+	}
+
+	securityPolicy, err := k8s.NewSecurityPolicy(cfg.SecurityProfile, cfg.NamespaceSecurityProfiles)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid security configuration")
+	}
+
+	workloadIdentity, err := k8s.NewWorkloadIdentity(cfg.ApplicationServiceAccount, cfg.WorkloadIdentity)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid workload identity configuration")
+	}
+
+	lrpToStatefulSetConverter := stset.NewLRPToStatefulSetConverter(
+		workloadIdentity,
+		cfg.RegistrySecretName,
+		cfg.UnsafeAllowAutomountServiceAccountToken,
+		cfg.TopologySpreadPolicy,
+		cfg.PlacementTags,
+		securityPolicy,
+		k8s.CreateLivenessProbe,
+		k8s.CreateReadinessProbe,
+		k8s.CreateStartupProbe,
+	)
+
+	pdbUpdater := pdb.NewUpdater(controllerClient, cfg.DefaultMinAvailableInstances)
+	desirer := stset.NewDesirer(logger, lrpToStatefulSetConverter, pdbUpdater, workloadIdentity, controllerClient, scheme)
+
+	onReload(cfg, func(newCfg eirinictrl.ControllerConfig) {
+		lrpToStatefulSetConverter.SetTopologySpreadPolicy(newCfg.TopologySpreadPolicy)
+		pdbUpdater.SetDefaultMinAvailable(newCfg.DefaultMinAvailableInstances)
+	})
+
+	return desirer, pdbUpdater, nil
+}
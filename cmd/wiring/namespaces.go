@@ -0,0 +1,52 @@
+package wiring
+
+import (
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/k8s"
+	"code.cloudfoundry.org/eirini-controller/k8s/reconciler"
+	"code.cloudfoundry.org/eirini-controller/k8s/shard"
+	"code.cloudfoundry.org/lager"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+func createNamespaceFilter(manager manager.Manager, config eirinictrl.ControllerConfig) (*k8s.NamespaceFilter, error) {
+	namespaceFilter, err := k8s.NewNamespaceFilter(manager.GetClient(), config.Namespaces(), config.WorkloadsNamespaceSelector)
+
+	return namespaceFilter, errors.Wrap(err, "invalid workloads namespaces")
+}
+
+// watchSelectedNamespaces requeues the workloads of the namespaces that
+// start matching the workloads namespace selector.
+func watchSelectedNamespaces(
+	controllerBuilder *builder.Builder,
+	namespaceFilter *k8s.NamespaceFilter,
+	mapper *reconciler.WorkloadsMapper,
+) *builder.Builder {
+	if !namespaceFilter.Selective() {
+		return controllerBuilder
+	}
+
+	return controllerBuilder.Watches(
+		&source.Kind{Type: &corev1.Namespace{}},
+		handler.EnqueueRequestsFromMapFunc(mapper.MapNamespace),
+		builder.WithPredicates(predicate.LabelChangedPredicate{}),
+	)
+}
+
+// workloadPredicates keeps the events of the workloads in the namespaces
+// served by this replica.
+func workloadPredicates(logger lager.Logger, namespaceFilter *k8s.NamespaceFilter, claimer *shard.Claimer) []predicate.Predicate {
+	predicates := []predicate.Predicate{reconciler.NewNamespacePredicate(logger, namespaceFilter)}
+
+	if claimer != nil {
+		predicates = append(predicates, reconciler.NewNamespacePredicate(logger, claimer))
+	}
+
+	return predicates
+}
@@ -6,31 +6,64 @@ import (
 	eirinictrl "code.cloudfoundry.org/eirini-controller"
 	eirinievent "code.cloudfoundry.org/eirini-controller/k8s/event"
 	"code.cloudfoundry.org/eirini-controller/k8s/reconciler"
+	"code.cloudfoundry.org/eirini-controller/k8s/shard"
 	"code.cloudfoundry.org/eirini-controller/k8s/stset"
+	"code.cloudfoundry.org/eirini-controller/prometheus"
+	"code.cloudfoundry.org/eirini-controller/tracing"
 	"code.cloudfoundry.org/lager"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
 
-func PodCrashReconciler(logger lager.Logger, manager manager.Manager, config eirinictrl.ControllerConfig) error {
+func PodCrashReconciler(logger lager.Logger, manager manager.Manager, config eirinictrl.ControllerConfig, claimer *shard.Claimer) error {
 	logger = logger.Session("pod-crash-reconciler")
 
-	podCrashReconciler := createPodCrashReconciler(logger, config.WorkloadsNamespace, manager.GetClient())
+	podCrashReconciler, err := createPodCrashReconciler(logger, config.WorkloadsNamespace, tracing.NewClient(manager.GetClient()))
+	if err != nil {
+		return errors.Wrap(err, "Failed to create Pod Crash reconciler")
+	}
+
+	err = addEventIndexes(manager)
+	if err != nil {
+		return err
+	}
 
-	err := addEventIndexes(manager)
+	namespaceFilter, err := createNamespaceFilter(manager, config)
 	if err != nil {
 		return err
 	}
 
-	predicates := []predicate.Predicate{reconciler.NewSourceTypeUpdatePredicate(stset.AppSourceType)}
+	options, err := controllerOptions(config.Reconcilers.PodCrash)
+	if err != nil {
+		return err
+	}
+
+	heartbeat, err := addHeartbeat(manager, "pod_crash")
+	if err != nil {
+		return err
+	}
+
+	decoratedReconciler, err := decorateReconciler(logger, podCrashReconciler, "pod_crash", config.Reconcilers.PodCrash)
+	if err != nil {
+		return err
+	}
+
+	predicates := append(
+		[]predicate.Predicate{reconciler.NewSourceTypeUpdatePredicate(stset.AppSourceType)},
+		workloadPredicates(logger, namespaceFilter, claimer)...,
+	)
 	err = builder.
 		ControllerManagedBy(manager).
+		WithOptions(options).
 		For(&corev1.Pod{}, builder.WithPredicates(predicates...)).
-		Complete(podCrashReconciler)
+		Watches(heartbeat.Source(), &handler.EnqueueRequestForObject{}).
+		Complete(heartbeat.Decorate(decoratedReconciler))
 
 	return errors.Wrapf(err, "Failed to build Pod Crash reconciler")
 }
@@ -77,8 +110,13 @@ func createEventOwnerIndexerFunc(getEventAttribute func(*corev1.Event) string) c
 	}
 }
 
-func createPodCrashReconciler(logger lager.Logger, workloadsNamespace string, controllerClient client.Client) *reconciler.PodCrash {
+func createPodCrashReconciler(logger lager.Logger, workloadsNamespace string, controllerClient client.Client) (*reconciler.PodCrash, error) {
 	crashEventGenerator := eirinievent.NewDefaultCrashEventGenerator(controllerClient)
 
-	return reconciler.NewPodCrash(logger, controllerClient, crashEventGenerator)
+	decoratedCrashEventGenerator, err := prometheus.NewCrashEventGeneratorDecorator(crashEventGenerator, metrics.Registry)
+	if err != nil {
+		return nil, err
+	}
+
+	return reconciler.NewPodCrash(logger, controllerClient, decoratedCrashEventGenerator), nil
 }
@@ -0,0 +1,86 @@
+package wiring
+
+import (
+	"time"
+
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/k8s/reconciler"
+	"code.cloudfoundry.org/eirini-controller/prometheus"
+	"code.cloudfoundry.org/lager"
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	defaultRateLimiterBaseDelay = 5 * time.Millisecond
+	defaultRateLimiterMaxDelay  = 1000 * time.Second
+)
+
+// controllerOptions sizes the workers of a controller and how its failed
+// reconciles back off. The overall retry rate is kept as in the
+// controller-runtime default rate limiter.
+func controllerOptions(config eirinictrl.ReconcilerConfig) (controller.Options, error) {
+	if config.MaxConcurrentReconciles < 0 ||
+		config.RateLimiterBaseDelayMilliseconds < 0 ||
+		config.RateLimiterMaxDelaySeconds < 0 ||
+		config.ConflictRequeueDelayMilliseconds < 0 ||
+		config.NamespaceReconcilesPerSecond < 0 ||
+		config.NamespaceBurst < 0 {
+		return controller.Options{}, errors.New("invalid reconciler configuration: values cannot be negative")
+	}
+
+	baseDelay := defaultRateLimiterBaseDelay
+	if config.RateLimiterBaseDelayMilliseconds > 0 {
+		baseDelay = time.Duration(config.RateLimiterBaseDelayMilliseconds) * time.Millisecond
+	}
+
+	maxDelay := defaultRateLimiterMaxDelay
+	if config.RateLimiterMaxDelaySeconds > 0 {
+		maxDelay = time.Duration(config.RateLimiterMaxDelaySeconds) * time.Second
+	}
+
+	if maxDelay < baseDelay {
+		return controller.Options{}, errors.Errorf("invalid reconciler configuration: max delay %s is shorter than base delay %s", maxDelay, baseDelay)
+	}
+
+	return controller.Options{
+		MaxConcurrentReconciles: config.MaxConcurrentReconciles,
+		RateLimiter: workqueue.NewMaxOfRateLimiter(
+			workqueue.NewItemExponentialFailureRateLimiter(baseDelay, maxDelay),
+			&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
+		),
+	}, nil
+}
+
+// decorateReconciler counts the failed reconciles of the named controller,
+// and requeues conflicts and spreads the reconciles across namespaces when
+// configured to.
+func decorateReconciler(logger lager.Logger, r reconcile.Reconciler, name string, config eirinictrl.ReconcilerConfig) (reconcile.Reconciler, error) {
+	r, err := prometheus.NewReconcilerDecorator(r, name, metrics.Registry)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.ConflictRequeueDelayMilliseconds > 0 {
+		r = reconciler.NewConflictRequeuer(r, time.Duration(config.ConflictRequeueDelayMilliseconds)*time.Millisecond)
+	}
+
+	if config.NamespaceReconcilesPerSecond > 0 {
+		r = reconciler.NewNamespaceFairness(logger, r, config.NamespaceReconcilesPerSecond, config.NamespaceBurst, clock.RealClock{})
+	}
+
+	return r, nil
+}
+
+// onReload applies the reloadable settings of the new config when the config
+// file changes.
+func onReload(config eirinictrl.ControllerConfig, apply func(eirinictrl.ControllerConfig)) {
+	if config.Reloader != nil {
+		config.Reloader.OnReload(apply)
+	}
+}
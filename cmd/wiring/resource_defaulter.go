@@ -0,0 +1,31 @@
+package wiring
+
+import (
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/k8s/webhook"
+	"code.cloudfoundry.org/eirini-controller/tracing"
+	"code.cloudfoundry.org/lager"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func ResourceDefaulter(logger lager.Logger, manager manager.Manager, config eirinictrl.ControllerConfig) error {
+	logger = logger.Session("resource-defaulter")
+
+	decoder, err := admission.NewDecoder(scheme.Scheme)
+	if err != nil {
+		return errors.Wrap(err, "Failed to create admission decoder")
+	}
+
+	manager.GetWebhookServer().Register("/lrps/defaults", &admission.Webhook{
+		Handler: tracing.NewAdmissionHandler("webhook.LRPDefaulter", webhook.NewLRPDefaulter(logger, decoder, manager.GetClient())),
+	})
+
+	manager.GetWebhookServer().Register("/tasks/defaults", &admission.Webhook{
+		Handler: tracing.NewAdmissionHandler("webhook.TaskDefaulter", webhook.NewTaskDefaulter(logger, decoder, manager.GetClient())),
+	})
+
+	return nil
+}
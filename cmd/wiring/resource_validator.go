@@ -3,6 +3,7 @@ package wiring
 import (
 	eirinictrl "code.cloudfoundry.org/eirini-controller"
 	"code.cloudfoundry.org/eirini-controller/k8s/webhook"
+	"code.cloudfoundry.org/eirini-controller/tracing"
 	"code.cloudfoundry.org/lager"
 	"github.com/pkg/errors"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -19,7 +20,7 @@ func ResourceValidator(logger lager.Logger, manager manager.Manager, config eiri
 	}
 
 	manager.GetWebhookServer().Register("/lrps", &admission.Webhook{
-		Handler: webhook.NewLRPResourceValidator(logger, decoder),
+		Handler: tracing.NewAdmissionHandler("webhook.LRPResourceValidator", webhook.NewLRPResourceValidator(logger, decoder, config.PlacementTags, config.WorkloadIdentity)),
 	})
 
 	return nil
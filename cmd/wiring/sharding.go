@@ -0,0 +1,65 @@
+package wiring
+
+import (
+	"fmt"
+	"os"
+
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/k8s/reconciler"
+	"code.cloudfoundry.org/eirini-controller/k8s/shard"
+	"code.cloudfoundry.org/lager"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// ShardClaimer adds the claimer of the shards to the manager. Its leases,
+// eirini-controller-shard-<index>, are shared by all the reconcilers, so
+// that a replica reconciles every workload of its shards. There is none when
+// sharding is disabled.
+func ShardClaimer(logger lager.Logger, manager manager.Manager, config eirinictrl.ControllerConfig) (*shard.Claimer, error) {
+	if !config.Sharding.Enabled {
+		return nil, nil // nolint: nilnil
+	}
+
+	clientset, err := kubernetes.NewForConfig(manager.GetConfig())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the shard leases client")
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the replica hostname")
+	}
+
+	claimer, err := shard.NewClaimer(
+		logger,
+		clientset.CoordinationV1(),
+		manager.GetClient(),
+		"eirini-controller",
+		fmt.Sprintf("%s_%s", hostname, uuid.NewUUID()),
+		config.Sharding,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid sharding configuration")
+	}
+
+	return claimer, errors.Wrap(manager.Add(claimer), "failed to add the shard claimer")
+}
+
+// watchClaimedShards requeues the workloads of the shards acquired by this
+// replica.
+func watchClaimedShards(
+	controllerBuilder *builder.Builder,
+	claimer *shard.Claimer,
+	mapper *reconciler.WorkloadsMapper,
+) *builder.Builder {
+	if claimer == nil {
+		return controllerBuilder
+	}
+
+	return controllerBuilder.Watches(claimer.Source(), handler.EnqueueRequestsFromMapFunc(mapper.MapNamespace))
+}
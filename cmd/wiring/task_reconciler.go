@@ -2,28 +2,70 @@ package wiring
 
 import (
 	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/k8s"
 	"code.cloudfoundry.org/eirini-controller/k8s/jobs"
 	"code.cloudfoundry.org/eirini-controller/k8s/reconciler"
+	"code.cloudfoundry.org/eirini-controller/k8s/shard"
 	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"code.cloudfoundry.org/eirini-controller/prometheus"
+	"code.cloudfoundry.org/eirini-controller/tracing"
 	"code.cloudfoundry.org/lager"
 	"github.com/pkg/errors"
 	batchv1 "k8s.io/api/batch/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
-func TaskReconciler(logger lager.Logger, manager manager.Manager, config eirinictrl.ControllerConfig) error {
+func TaskReconciler(logger lager.Logger, manager manager.Manager, config eirinictrl.ControllerConfig, claimer *shard.Claimer) error {
 	logger = logger.Session("task-reconciler")
 
-	taskReconciler := createTaskReconciler(logger, manager.GetClient(), config, manager.GetScheme())
+	taskReconciler, err := createTaskReconciler(logger, tracing.NewClient(manager.GetClient()), config, manager.GetScheme())
+	if err != nil {
+		return errors.Wrap(err, "Failed to create Task reconciler")
+	}
 
-	err := builder.
+	namespaceFilter, err := createNamespaceFilter(manager, config)
+	if err != nil {
+		return err
+	}
+
+	options, err := controllerOptions(config.Reconcilers.Task)
+	if err != nil {
+		return err
+	}
+
+	heartbeat, err := addHeartbeat(manager, "task")
+	if err != nil {
+		return err
+	}
+
+	decoratedReconciler, err := decorateReconciler(logger, taskReconciler, "task", config.Reconcilers.Task)
+	if err != nil {
+		return err
+	}
+
+	inNamespace := builder.WithPredicates(workloadPredicates(logger, namespaceFilter, claimer)...)
+	mapper := reconciler.NewTasksMapper(logger, manager.GetClient(), namespaceFilter)
+
+	controllerBuilder := builder.
 		ControllerManagedBy(manager).
-		For(&eiriniv1.Task{}).
-		Owns(&batchv1.Job{}).
-		Complete(taskReconciler)
+		WithOptions(options).
+		For(&eiriniv1.Task{}, inNamespace).
+		Watches(heartbeat.Source(), &handler.EnqueueRequestForObject{}).
+		Owns(&batchv1.Job{}, inNamespace).
+		Watches(
+			&source.Kind{Type: &eiriniv1.WorkloadDefaults{}},
+			handler.EnqueueRequestsFromMapFunc(mapper.MapWorkloadDefaults),
+			inNamespace,
+		)
+
+	controllerBuilder = watchSelectedNamespaces(controllerBuilder, namespaceFilter, mapper)
+	err = watchClaimedShards(controllerBuilder, claimer, mapper).Complete(heartbeat.Decorate(decoratedReconciler))
 
 	return errors.Wrapf(err, "Failed to build Task reconciler")
 }
@@ -33,15 +75,58 @@ func createTaskReconciler(
 	controllerClient client.Client,
 	cfg eirinictrl.ControllerConfig,
 	scheme *runtime.Scheme,
-) *reconciler.Task {
+) (*reconciler.Task, error) {
+	desirer, err := NewTaskDesirer(logger, controllerClient, cfg, scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	statusGetter := jobs.NewStatusGetter(logger, controllerClient)
+
+	decoratedDesirer, err := prometheus.NewTaskDesirerDecorator(desirer, metrics.Registry)
+	if err != nil {
+		return nil, err
+	}
+
+	decoratedStatusGetter, err := prometheus.NewTaskStatusGetterDecorator(statusGetter, metrics.Registry)
+	if err != nil {
+		return nil, err
+	}
+
+	taskReconciler := reconciler.NewTask(logger, controllerClient, decoratedDesirer, decoratedStatusGetter, cfg.TaskTTLSeconds)
+
+	onReload(cfg, func(newCfg eirinictrl.ControllerConfig) {
+		taskReconciler.SetTTLSeconds(newCfg.TaskTTLSeconds)
+	})
+
+	return taskReconciler, nil
+}
+
+// NewTaskDesirer creates the desirer the task reconciler uses to create the
+// Job and service account of new tasks.
+func NewTaskDesirer(
+	logger lager.Logger,
+	controllerClient client.Client,
+	cfg eirinictrl.ControllerConfig,
+	scheme *runtime.Scheme,
+) (*jobs.Desirer, error) {
+	securityPolicy, err := k8s.NewSecurityPolicy(cfg.SecurityProfile, cfg.NamespaceSecurityProfiles)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid security configuration")
+	}
+
+	workloadIdentity, err := k8s.NewWorkloadIdentity(cfg.ApplicationServiceAccount, cfg.WorkloadIdentity)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid workload identity configuration")
+	}
+
 	taskToJobConverter := jobs.NewTaskToJobConverter(
-		cfg.ApplicationServiceAccount,
+		workloadIdentity,
 		cfg.RegistrySecretName,
 		cfg.UnsafeAllowAutomountServiceAccountToken,
+		cfg.PlacementTags,
+		securityPolicy,
 	)
 
-	desirer := jobs.NewDesirer(logger, taskToJobConverter, controllerClient, scheme)
-	statusGetter := jobs.NewStatusGetter(logger, controllerClient)
-
-	return reconciler.NewTask(logger, controllerClient, desirer, statusGetter, cfg.TaskTTLSeconds)
+	return jobs.NewDesirer(logger, taskToJobConverter, workloadIdentity, controllerClient, scheme), nil
 }
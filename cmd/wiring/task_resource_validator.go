@@ -0,0 +1,27 @@
+package wiring
+
+import (
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/k8s/webhook"
+	"code.cloudfoundry.org/eirini-controller/tracing"
+	"code.cloudfoundry.org/lager"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TaskResourceValidator(logger lager.Logger, manager manager.Manager, config eirinictrl.ControllerConfig) error {
+	logger = logger.Session("task-resource-validator")
+
+	decoder, err := admission.NewDecoder(scheme.Scheme)
+	if err != nil {
+		return errors.Wrap(err, "Failed to create admission decoder")
+	}
+
+	manager.GetWebhookServer().Register("/tasks", &admission.Webhook{
+		Handler: tracing.NewAdmissionHandler("webhook.TaskValidator", webhook.NewTaskValidator(logger, decoder, config.PlacementTags, config.WorkloadIdentity)),
+	})
+
+	return nil
+}
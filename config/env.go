@@ -0,0 +1,88 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+const EnvPrefix = "EIRINI_CONTROLLER_"
+
+// ApplyEnv overrides the config fields with the environment variables named
+// after their YAML keys, e.g. EIRINI_CONTROLLER_TASK_TTL_SECONDS or
+// EIRINI_CONTROLLER_LOGGING_LEVEL. Strings are taken as they are, other
+// values are parsed as YAML, so that lists and maps are written as
+// EIRINI_CONTROLLER_WORKLOADS_NAMESPACES='[ns-1, ns-2]'.
+func ApplyEnv(config *eirinictrl.ControllerConfig, lookupEnv func(string) (string, bool)) error {
+	return applyEnv(reflect.ValueOf(config).Elem(), EnvPrefix, lookupEnv)
+}
+
+func applyEnv(value reflect.Value, prefix string, lookupEnv func(string) (string, bool)) error {
+	for i := 0; i < value.NumField(); i++ {
+		key, inline := yamlKey(value.Type().Field(i))
+		field := value.Field(i)
+
+		switch {
+		case key == "-":
+			continue
+		case inline:
+			if err := applyEnv(field, prefix, lookupEnv); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		name := prefix + strings.ToUpper(key)
+
+		if field.Kind() == reflect.Struct {
+			if err := applyEnv(field, name+"_", lookupEnv); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		env, ok := lookupEnv(name)
+		if !ok {
+			continue
+		}
+
+		if err := setField(field, env); err != nil {
+			return errors.Wrapf(err, "invalid value of %s", name)
+		}
+	}
+
+	return nil
+}
+
+// yamlKey returns the key of a struct field in the YAML config, as yaml.v2
+// names it, and whether the field is inlined.
+func yamlKey(field reflect.StructField) (string, bool) {
+	name, options, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+
+	if options == "inline" {
+		return "", true
+	}
+
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+
+	return name, false
+}
+
+func setField(field reflect.Value, env string) error {
+	if field.Kind() == reflect.String {
+		field.SetString(env)
+
+		return nil
+	}
+
+	field.Set(reflect.Zero(field.Type()))
+
+	return errors.Wrap(yaml.UnmarshalStrict([]byte(env), field.Addr().Interface()), "failed to unmarshal yaml")
+}
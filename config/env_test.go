@@ -0,0 +1,83 @@
+package config_test
+
+import (
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("ApplyEnv", func() {
+	var (
+		env map[string]string
+		cfg eirinictrl.ControllerConfig
+		err error
+	)
+
+	BeforeEach(func() {
+		env = map[string]string{}
+		cfg = eirinictrl.ControllerConfig{
+			RegistrySecretName:  "registry-secret",
+			WorkloadsNamespaces: []string{"ns-0"},
+			PlacementTags: map[string]eirinictrl.PlacementTag{
+				"old-tag": {NodeSelector: map[string]string{"old": "node"}},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		err = config.ApplyEnv(&cfg, func(name string) (string, bool) {
+			value, ok := env[name]
+
+			return value, ok
+		})
+	})
+
+	It("keeps the fields without variables", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.RegistrySecretName).To(Equal("registry-secret"))
+		Expect(cfg.WorkloadsNamespaces).To(ConsistOf("ns-0"))
+	})
+
+	When("variables are set", func() {
+		BeforeEach(func() {
+			env["EIRINI_CONTROLLER_REGISTRY_SECRET_NAME"] = "true"
+			env["EIRINI_CONTROLLER_KUBE_CONFIG_PATH"] = "/kube/config"
+			env["EIRINI_CONTROLLER_WEBHOOK_PORT"] = "9443"
+			env["EIRINI_CONTROLLER_UNSAFE_ALLOW_AUTOMOUNT_SERVICE_ACCOUNT_TOKEN"] = "true"
+			env["EIRINI_CONTROLLER_WORKLOADS_NAMESPACES"] = "[ns-1, ns-2]"
+			env["EIRINI_CONTROLLER_PLACEMENT_TAGS"] = "{new-tag: {node_selector: {new: node}}}"
+			env["EIRINI_CONTROLLER_TRACING_SAMPLE_RATIO"] = "0.5"
+			env["EIRINI_CONTROLLER_RECONCILERS_POD_CRASH_MAX_CONCURRENT_RECONCILES"] = "4"
+			env["EIRINI_CONTROLLER_SECURITY_PROFILE_RUN_AS_USER"] = "1000"
+			env["EIRINI_CONTROLLER_LEADERELECTIONID"] = "leader"
+		})
+
+		It("overrides the fields", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.RegistrySecretName).To(Equal("true"))
+			Expect(cfg.ConfigPath).To(Equal("/kube/config"))
+			Expect(cfg.WebhookPort).To(BeEquivalentTo(9443))
+			Expect(cfg.UnsafeAllowAutomountServiceAccountToken).To(BeTrue())
+			Expect(cfg.WorkloadsNamespaces).To(ConsistOf("ns-1", "ns-2"))
+			Expect(cfg.PlacementTags).To(Equal(map[string]eirinictrl.PlacementTag{
+				"new-tag": {NodeSelector: map[string]string{"new": "node"}},
+			}))
+			Expect(cfg.Tracing.SampleRatio).To(PointTo(Equal(0.5)))
+			Expect(cfg.Reconcilers.PodCrash.MaxConcurrentReconciles).To(Equal(4))
+			Expect(cfg.SecurityProfile.RunAsUser).To(PointTo(BeEquivalentTo(1000)))
+			Expect(cfg.LeaderElectionID).To(Equal("leader"))
+		})
+	})
+
+	When("a value cannot be parsed", func() {
+		BeforeEach(func() {
+			env["EIRINI_CONTROLLER_TASK_TTL_SECONDS"] = "a minute"
+		})
+
+		It("returns an error naming the variable", func() {
+			Expect(err).To(MatchError(ContainSubstring("invalid value of EIRINI_CONTROLLER_TASK_TTL_SECONDS")))
+		})
+	})
+})
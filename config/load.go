@@ -0,0 +1,34 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Load reads the config file, rejecting unknown fields, applies the
+// environment overrides and validates the result. An empty path stands for
+// an empty file.
+func Load(path string) (eirinictrl.ControllerConfig, error) {
+	var config eirinictrl.ControllerConfig
+
+	if path != "" {
+		fileBytes, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return config, errors.Wrap(err, "failed to read file")
+		}
+
+		if err := yaml.UnmarshalStrict(fileBytes, &config); err != nil {
+			return config, errors.Wrap(err, "failed to unmarshal yaml")
+		}
+	}
+
+	if err := ApplyEnv(&config, os.LookupEnv); err != nil {
+		return config, err
+	}
+
+	return config, errors.Wrap(Validate(config), "invalid config")
+}
@@ -0,0 +1,101 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Load", func() {
+	var (
+		configPath string
+		configYAML string
+		cfg        eirinictrl.ControllerConfig
+		err        error
+	)
+
+	BeforeEach(func() {
+		configPath = filepath.Join(GinkgoT().TempDir(), "controller.yml")
+		configYAML = `
+application_service_account: eirini
+task_ttl_seconds: 30
+sharding:
+  enabled: true
+  shards: 4
+logging:
+  level: debug
+`
+	})
+
+	JustBeforeEach(func() {
+		Expect(os.WriteFile(configPath, []byte(configYAML), 0o600)).To(Succeed())
+		cfg, err = config.Load(configPath)
+	})
+
+	It("reads the config file", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.ApplicationServiceAccount).To(Equal("eirini"))
+		Expect(cfg.TaskTTLSeconds).To(Equal(30))
+		Expect(cfg.Sharding).To(Equal(eirinictrl.Sharding{Enabled: true, Shards: 4}))
+		Expect(cfg.Logging.Level).To(Equal("debug"))
+	})
+
+	When("the file has an unknown field", func() {
+		BeforeEach(func() {
+			configYAML += "task_ttl_second: 30\n"
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError(ContainSubstring("field task_ttl_second not found")))
+		})
+	})
+
+	When("the file is invalid", func() {
+		BeforeEach(func() {
+			configYAML += "default_min_available_instances: 150%\nprometheus_port: 70000\n"
+		})
+
+		It("returns the validation errors", func() {
+			Expect(err).To(MatchError(ContainSubstring(`default_min_available_instances: Invalid value: "150%": must be less than 100%`)))
+			Expect(err).To(MatchError(ContainSubstring("prometheus_port: Invalid value: 70000: must be between 0 and 65535")))
+		})
+	})
+
+	When("the file does not exist", func() {
+		JustBeforeEach(func() {
+			cfg, err = config.Load(filepath.Join(GinkgoT().TempDir(), "missing.yml"))
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError(ContainSubstring("failed to read file")))
+		})
+	})
+
+	When("an environment variable overrides a field", func() {
+		BeforeEach(func() {
+			GinkgoT().Setenv("EIRINI_CONTROLLER_TASK_TTL_SECONDS", "60")
+			GinkgoT().Setenv("EIRINI_CONTROLLER_LOGGING_LEVEL", "error")
+		})
+
+		It("takes the environment value", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.TaskTTLSeconds).To(Equal(60))
+			Expect(cfg.Logging.Level).To(Equal("error"))
+		})
+	})
+
+	When("no path is given", func() {
+		JustBeforeEach(func() {
+			cfg, err = config.Load("")
+		})
+
+		It("returns the default config", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg).To(Equal(eirinictrl.ControllerConfig{}))
+		})
+	})
+})
@@ -0,0 +1,4 @@
+// Package config loads the controller configuration from its YAML file and
+// the EIRINI_CONTROLLER_* environment variables, validates it, and reloads
+// the settings that are safe to change while the controller runs.
+package config
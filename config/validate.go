@@ -0,0 +1,302 @@
+package config
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/k8s"
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"code.cloudfoundry.org/eirini-controller/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const maxPort = 65535
+
+// Validate checks the config, reporting each invalid field by its YAML key.
+func Validate(config eirinictrl.ControllerConfig) error {
+	errs := field.ErrorList{}
+
+	errs = append(errs, validatePort(field.NewPath("prometheus_port"), config.PrometheusPort)...)
+	errs = append(errs, validatePort(field.NewPath("health_probe_port"), config.HealthProbePort)...)
+	errs = append(errs, validatePort(field.NewPath("webhook_port"), int(config.WebhookPort))...)
+	errs = append(errs, validateNonNegative(field.NewPath("task_ttl_seconds"), config.TaskTTLSeconds)...)
+	errs = append(errs, validateMinAvailable(field.NewPath("default_min_available_instances"), config.DefaultMinAvailableInstances)...)
+
+	switch config.TopologySpreadPolicy {
+	case "", eiriniv1.TopologySpreadPolicySoft, eiriniv1.TopologySpreadPolicyHard:
+	default:
+		errs = append(errs, field.NotSupported(field.NewPath("topology_spread_policy"), config.TopologySpreadPolicy,
+			[]string{eiriniv1.TopologySpreadPolicySoft, eiriniv1.TopologySpreadPolicyHard}))
+	}
+
+	if _, err := labels.Parse(config.WorkloadsNamespaceSelector); err != nil {
+		errs = append(errs, field.Invalid(field.NewPath("workloads_namespace_selector"), config.WorkloadsNamespaceSelector, err.Error()))
+	}
+
+	errs = append(errs, validatePlacementTags(field.NewPath("placement_tags"), config.PlacementTags)...)
+	errs = append(errs, validateSecurityProfile(field.NewPath("security_profile"), config.SecurityProfile)...)
+	errs = append(errs, validateNamespaceSecurityProfiles(field.NewPath("namespace_security_profiles"), config.NamespaceSecurityProfiles)...)
+	errs = append(errs, validateWorkloadIdentity(field.NewPath("workload_identity"), config.WorkloadIdentity)...)
+	errs = append(errs, validateSharding(field.NewPath("sharding"), config.Sharding)...)
+
+	reconcilersPath := field.NewPath("reconcilers")
+	errs = append(errs, validateReconciler(reconcilersPath.Child("lrp"), config.Reconcilers.LRP)...)
+	errs = append(errs, validateReconciler(reconcilersPath.Child("task"), config.Reconcilers.Task)...)
+	errs = append(errs, validateReconciler(reconcilersPath.Child("pod_crash"), config.Reconcilers.PodCrash)...)
+
+	if ratio := config.Tracing.SampleRatio; ratio != nil && (*ratio < 0 || *ratio > 1) {
+		errs = append(errs, field.Invalid(field.NewPath("tracing", "sample_ratio"), *ratio, "must be between 0 and 1"))
+	}
+
+	errs = append(errs, validateLogging(field.NewPath("logging"), config.Logging)...)
+
+	return errs.ToAggregate()
+}
+
+func validatePort(path *field.Path, port int) field.ErrorList {
+	if port < 0 || port > maxPort {
+		return field.ErrorList{field.Invalid(path, port, "must be between 0 and 65535")}
+	}
+
+	return nil
+}
+
+func validateNonNegative(path *field.Path, value int) field.ErrorList {
+	if value < 0 {
+		return field.ErrorList{field.Invalid(path, value, "must not be negative")}
+	}
+
+	return nil
+}
+
+func validateMinAvailable(path *field.Path, value string) field.ErrorList {
+	if value == "" {
+		return nil
+	}
+
+	minAvailable := intstr.Parse(value)
+	if minAvailable.Type == intstr.Int {
+		return validateNonNegative(path, minAvailable.IntValue())
+	}
+
+	errs := field.ErrorList{}
+	for _, msg := range validation.IsValidPercent(value) {
+		errs = append(errs, field.Invalid(path, value, msg))
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	// 100% would block every eviction of every LRP and hang node drains
+	if percent, _ := strconv.Atoi(strings.TrimSuffix(value, "%")); percent >= 100 {
+		return field.ErrorList{field.Invalid(path, value, "must be less than 100%")}
+	}
+
+	return nil
+}
+
+// validatePlacementTags checks the node selectors, tolerations and node
+// affinity of the placement tags, so that pods carrying the tags are not
+// rejected by Kubernetes.
+func validatePlacementTags(path *field.Path, placementTags map[string]eirinictrl.PlacementTag) field.ErrorList {
+	errs := field.ErrorList{}
+
+	names := make([]string, 0, len(placementTags))
+	for name := range placementTags {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		tagPath := path.Key(name)
+		placement := placementTags[name]
+
+		errs = append(errs, metav1validation.ValidateLabels(placement.NodeSelector, tagPath.Child("node_selector"))...)
+
+		for i, toleration := range placement.Tolerations {
+			errs = append(errs, validateToleration(tagPath.Child("tolerations").Index(i), toleration)...)
+		}
+
+		for i, requirement := range placement.NodeAffinity {
+			errs = append(errs, validateNodeSelectorRequirement(tagPath.Child("node_affinity").Index(i), requirement)...)
+		}
+	}
+
+	return errs
+}
+
+func validateToleration(path *field.Path, toleration eirinictrl.Toleration) field.ErrorList {
+	errs := field.ErrorList{}
+
+	switch corev1.TolerationOperator(toleration.Operator) {
+	case "", corev1.TolerationOpEqual:
+	case corev1.TolerationOpExists:
+		if toleration.Value != "" {
+			errs = append(errs, field.Invalid(path.Child("value"), toleration.Value, "must be empty when operator is Exists"))
+		}
+	default:
+		errs = append(errs, field.NotSupported(path.Child("operator"), toleration.Operator,
+			[]string{string(corev1.TolerationOpEqual), string(corev1.TolerationOpExists)}))
+	}
+
+	switch corev1.TaintEffect(toleration.Effect) {
+	case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+	default:
+		errs = append(errs, field.NotSupported(path.Child("effect"), toleration.Effect,
+			[]string{string(corev1.TaintEffectNoSchedule), string(corev1.TaintEffectPreferNoSchedule), string(corev1.TaintEffectNoExecute)}))
+	}
+
+	return errs
+}
+
+func validateNodeSelectorRequirement(path *field.Path, requirement eirinictrl.NodeSelectorRequirement) field.ErrorList {
+	errs := field.ErrorList{}
+
+	for _, msg := range validation.IsQualifiedName(requirement.Key) {
+		errs = append(errs, field.Invalid(path.Child("key"), requirement.Key, msg))
+	}
+
+	switch corev1.NodeSelectorOperator(requirement.Operator) {
+	case corev1.NodeSelectorOpIn, corev1.NodeSelectorOpNotIn:
+		if len(requirement.Values) == 0 {
+			errs = append(errs, field.Required(path.Child("values"), "must be set when operator is In or NotIn"))
+		}
+	case corev1.NodeSelectorOpExists, corev1.NodeSelectorOpDoesNotExist:
+		if len(requirement.Values) > 0 {
+			errs = append(errs, field.Forbidden(path.Child("values"), "must be empty when operator is Exists or DoesNotExist"))
+		}
+	case corev1.NodeSelectorOpGt, corev1.NodeSelectorOpLt:
+		if len(requirement.Values) != 1 {
+			errs = append(errs, field.Required(path.Child("values"), "must have a single value when operator is Gt or Lt"))
+		}
+	default:
+		errs = append(errs, field.NotSupported(path.Child("operator"), requirement.Operator, []string{
+			string(corev1.NodeSelectorOpIn), string(corev1.NodeSelectorOpNotIn),
+			string(corev1.NodeSelectorOpExists), string(corev1.NodeSelectorOpDoesNotExist),
+			string(corev1.NodeSelectorOpGt), string(corev1.NodeSelectorOpLt),
+		}))
+	}
+
+	return errs
+}
+
+func validateSecurityProfile(path *field.Path, profile eirinictrl.SecurityProfile) field.ErrorList {
+	if err := k8s.ValidateSecurityProfile(profile); err != nil {
+		return field.ErrorList{field.Forbidden(path, err.Error())}
+	}
+
+	return nil
+}
+
+func validateNamespaceSecurityProfiles(path *field.Path, profiles map[string]eirinictrl.SecurityProfile) field.ErrorList {
+	errs := field.ErrorList{}
+
+	namespaces := make([]string, 0, len(profiles))
+	for namespace := range profiles {
+		namespaces = append(namespaces, namespace)
+	}
+
+	sort.Strings(namespaces)
+
+	for _, namespace := range namespaces {
+		errs = append(errs, validateSecurityProfile(path.Key(namespace), profiles[namespace])...)
+	}
+
+	return errs
+}
+
+// validateWorkloadIdentity checks the service account annotations, so that
+// the service accounts of apps are not rejected by Kubernetes and their
+// templates render for every app.
+func validateWorkloadIdentity(path *field.Path, identity eirinictrl.WorkloadIdentity) field.ErrorList {
+	errs := field.ErrorList{}
+
+	keys := make([]string, 0, len(identity.ServiceAccountAnnotations))
+	for key := range identity.ServiceAccountAnnotations {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	annotationsPath := path.Child("service_account_annotations")
+	for _, key := range keys {
+		value := identity.ServiceAccountAnnotations[key]
+
+		for _, msg := range validation.IsQualifiedName(key) {
+			errs = append(errs, field.Invalid(annotationsPath, key, msg))
+		}
+
+		if _, err := k8s.ParseServiceAccountAnnotation(key, value); err != nil {
+			errs = append(errs, field.Invalid(annotationsPath.Key(key), value, err.Error()))
+		}
+	}
+
+	return errs
+}
+
+func validateSharding(path *field.Path, sharding eirinictrl.Sharding) field.ErrorList {
+	if !sharding.Enabled {
+		return nil
+	}
+
+	errs := field.ErrorList{}
+
+	if sharding.Shards < 1 {
+		errs = append(errs, field.Invalid(path.Child("shards"), sharding.Shards, "must be at least 1"))
+	}
+
+	errs = append(errs, validateNonNegative(path.Child("replicas"), sharding.Replicas)...)
+	errs = append(errs, validateNonNegative(path.Child("max_shards_per_replica"), sharding.MaxShardsPerReplica)...)
+	errs = append(errs, validateNonNegative(path.Child("lease_duration_seconds"), sharding.LeaseDurationSeconds)...)
+
+	return errs
+}
+
+func validateReconciler(path *field.Path, config eirinictrl.ReconcilerConfig) field.ErrorList {
+	errs := field.ErrorList{}
+
+	errs = append(errs, validateNonNegative(path.Child("max_concurrent_reconciles"), config.MaxConcurrentReconciles)...)
+	errs = append(errs, validateNonNegative(path.Child("rate_limiter_base_delay_milliseconds"), config.RateLimiterBaseDelayMilliseconds)...)
+	errs = append(errs, validateNonNegative(path.Child("rate_limiter_max_delay_seconds"), config.RateLimiterMaxDelaySeconds)...)
+	errs = append(errs, validateNonNegative(path.Child("conflict_requeue_delay_milliseconds"), config.ConflictRequeueDelayMilliseconds)...)
+	errs = append(errs, validateNonNegative(path.Child("namespace_burst"), config.NamespaceBurst)...)
+
+	if config.NamespaceReconcilesPerSecond < 0 {
+		errs = append(errs, field.Invalid(path.Child("namespace_reconciles_per_second"), config.NamespaceReconcilesPerSecond, "must not be negative"))
+	}
+
+	return errs
+}
+
+func validateLogging(path *field.Path, logging eirinictrl.Logging) field.ErrorList {
+	errs := field.ErrorList{}
+
+	switch logging.Format {
+	case "", util.LogFormatPretty, util.LogFormatJSON:
+	default:
+		errs = append(errs, field.NotSupported(path.Child("format"), logging.Format, []string{util.LogFormatPretty, util.LogFormatJSON}))
+	}
+
+	if _, err := util.ParseLogLevel(logging.Level); err != nil {
+		errs = append(errs, field.Invalid(path.Child("level"), logging.Level, "must be debug, info, error or fatal"))
+	}
+
+	errs = append(errs, validatePort(path.Child("port"), logging.Port)...)
+
+	for component, level := range logging.Components {
+		if _, err := util.ParseLogLevel(level); err != nil {
+			errs = append(errs, field.Invalid(path.Child("components").Key(component), level, "must be debug, info, error or fatal"))
+		}
+	}
+
+	return errs
+}
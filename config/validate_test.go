@@ -0,0 +1,116 @@
+package config_test
+
+import (
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("Validate", func() {
+	It("accepts the default config", func() {
+		Expect(config.Validate(eirinictrl.ControllerConfig{})).To(Succeed())
+	})
+
+	DescribeTable("valid settings",
+		func(cfg eirinictrl.ControllerConfig) {
+			Expect(config.Validate(cfg)).To(Succeed())
+		},
+		Entry("ports", eirinictrl.ControllerConfig{PrometheusPort: 8080, HealthProbePort: 8081, WebhookPort: 65535}),
+		Entry("a minimum of available instances", eirinictrl.ControllerConfig{DefaultMinAvailableInstances: "2"}),
+		Entry("a percentage of available instances", eirinictrl.ControllerConfig{DefaultMinAvailableInstances: "75%"}),
+		Entry("a topology spread policy", eirinictrl.ControllerConfig{TopologySpreadPolicy: "hard"}),
+		Entry("a namespace selector", eirinictrl.ControllerConfig{WorkloadsNamespaceSelector: "eirini in (yes)"}),
+		Entry("sharding", eirinictrl.ControllerConfig{Sharding: eirinictrl.Sharding{Enabled: true, Shards: 1}}),
+		Entry("placement tags", eirinictrl.ControllerConfig{PlacementTags: map[string]eirinictrl.PlacementTag{
+			"isolated": {
+				NodeSelector: map[string]string{"segment": "isolated"},
+				Tolerations:  []eirinictrl.Toleration{{Key: "segment", Operator: "Exists", Effect: "NoSchedule"}},
+				NodeAffinity: []eirinictrl.NodeSelectorRequirement{{Key: "pool", Operator: "In", Values: []string{"a"}}},
+			},
+		}}),
+		Entry("security profiles", eirinictrl.ControllerConfig{
+			SecurityProfile:           eirinictrl.SecurityProfile{RunAsUser: pointer.Int64(1000), SeccompProfile: "runtime/default"},
+			NamespaceSecurityProfiles: map[string]eirinictrl.SecurityProfile{"isolated": {AppArmorProfile: "localhost/isolated"}},
+		}),
+		Entry("service account annotations", eirinictrl.ControllerConfig{WorkloadIdentity: eirinictrl.WorkloadIdentity{
+			ServiceAccountAnnotations: map[string]string{"iam.gke.io/gcp-service-account": "{{ .AppGUID }}@project.iam.gserviceaccount.com"},
+		}}),
+		Entry("logging", eirinictrl.ControllerConfig{Logging: eirinictrl.Logging{
+			Format:     "json",
+			Level:      "ERROR",
+			Components: map[string]string{"lrp-reconciler": "debug"},
+		}}),
+	)
+
+	DescribeTable("invalid settings",
+		func(cfg eirinictrl.ControllerConfig, message string) {
+			Expect(config.Validate(cfg)).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("a negative port", eirinictrl.ControllerConfig{HealthProbePort: -1},
+			"health_probe_port: Invalid value: -1: must be between 0 and 65535"),
+		Entry("a port out of range", eirinictrl.ControllerConfig{WebhookPort: 65536},
+			"webhook_port: Invalid value: 65536: must be between 0 and 65535"),
+		Entry("a negative TTL", eirinictrl.ControllerConfig{TaskTTLSeconds: -1},
+			"task_ttl_seconds: Invalid value: -1: must not be negative"),
+		Entry("a negative minimum of available instances", eirinictrl.ControllerConfig{DefaultMinAvailableInstances: "-1"},
+			"default_min_available_instances: Invalid value: -1: must not be negative"),
+		Entry("a malformed percentage", eirinictrl.ControllerConfig{DefaultMinAvailableInstances: "half"},
+			`default_min_available_instances: Invalid value: "half"`),
+		Entry("a percentage over 100", eirinictrl.ControllerConfig{DefaultMinAvailableInstances: "101%"},
+			`default_min_available_instances: Invalid value: "101%": must be less than 100%`),
+		Entry("a percentage blocking every eviction", eirinictrl.ControllerConfig{DefaultMinAvailableInstances: "100%"},
+			`default_min_available_instances: Invalid value: "100%": must be less than 100%`),
+		Entry("an unknown topology spread policy", eirinictrl.ControllerConfig{TopologySpreadPolicy: "strict"},
+			`topology_spread_policy: Unsupported value: "strict"`),
+		Entry("an invalid namespace selector", eirinictrl.ControllerConfig{WorkloadsNamespaceSelector: "eirini in yes"},
+			"workloads_namespace_selector: Invalid value"),
+		Entry("no shards", eirinictrl.ControllerConfig{Sharding: eirinictrl.Sharding{Enabled: true}},
+			"sharding.shards: Invalid value: 0: must be at least 1"),
+		Entry("an unknown toleration operator", eirinictrl.ControllerConfig{PlacementTags: map[string]eirinictrl.PlacementTag{
+			"isolated": {Tolerations: []eirinictrl.Toleration{{Key: "segment", Operator: "Matches"}}},
+		}}, `placement_tags[isolated].tolerations[0].operator: Unsupported value: "Matches"`),
+		Entry("an unknown toleration effect", eirinictrl.ControllerConfig{PlacementTags: map[string]eirinictrl.PlacementTag{
+			"isolated": {Tolerations: []eirinictrl.Toleration{{Key: "segment", Operator: "Exists", Effect: "NoScheduling"}}},
+		}}, `placement_tags[isolated].tolerations[0].effect: Unsupported value: "NoScheduling"`),
+		Entry("an unknown node affinity operator", eirinictrl.ControllerConfig{PlacementTags: map[string]eirinictrl.PlacementTag{
+			"isolated": {NodeAffinity: []eirinictrl.NodeSelectorRequirement{{Key: "pool", Operator: "Equals"}}},
+		}}, `placement_tags[isolated].node_affinity[0].operator: Unsupported value: "Equals"`),
+		Entry("an invalid node selector", eirinictrl.ControllerConfig{PlacementTags: map[string]eirinictrl.PlacementTag{
+			"isolated": {NodeSelector: map[string]string{"segment": "not valid"}},
+		}}, `placement_tags[isolated].node_selector: Invalid value: "not valid"`),
+		Entry("a root security profile", eirinictrl.ControllerConfig{SecurityProfile: eirinictrl.SecurityProfile{RunAsUser: pointer.Int64(0)}},
+			"security_profile: Forbidden: run_as_user must not be root"),
+		Entry("an unconfined namespace security profile", eirinictrl.ControllerConfig{
+			NamespaceSecurityProfiles: map[string]eirinictrl.SecurityProfile{"isolated": {SeccompProfile: "unconfined"}},
+		}, `namespace_security_profiles[isolated]: Forbidden: unsupported seccomp profile "unconfined"`),
+		Entry("a malformed annotation template", eirinictrl.ControllerConfig{WorkloadIdentity: eirinictrl.WorkloadIdentity{
+			ServiceAccountAnnotations: map[string]string{"iam.gke.io/gcp-service-account": "{{ .AppGUID"},
+		}}, `workload_identity.service_account_annotations[iam.gke.io/gcp-service-account]: Invalid value: "{{ .AppGUID"`),
+		Entry("an annotation template referring to an unknown field", eirinictrl.ControllerConfig{WorkloadIdentity: eirinictrl.WorkloadIdentity{
+			ServiceAccountAnnotations: map[string]string{"iam.gke.io/gcp-service-account": "{{ .AppID }}"},
+		}}, `workload_identity.service_account_annotations[iam.gke.io/gcp-service-account]: Invalid value: "{{ .AppID }}"`),
+		Entry("an invalid annotation key", eirinictrl.ControllerConfig{WorkloadIdentity: eirinictrl.WorkloadIdentity{
+			ServiceAccountAnnotations: map[string]string{"not valid": "value"},
+		}}, `workload_identity.service_account_annotations: Invalid value: "not valid"`),
+		Entry("a negative reconciler setting", eirinictrl.ControllerConfig{Reconcilers: eirinictrl.Reconcilers{
+			Task: eirinictrl.ReconcilerConfig{MaxConcurrentReconciles: -1},
+		}}, "reconcilers.task.max_concurrent_reconciles: Invalid value: -1: must not be negative"),
+		Entry("a sample ratio over 1", eirinictrl.ControllerConfig{Tracing: eirinictrl.Tracing{SampleRatio: pointer.Float64(1.5)}},
+			"tracing.sample_ratio: Invalid value: 1.5: must be between 0 and 1"),
+		Entry("an unknown log format", eirinictrl.ControllerConfig{Logging: eirinictrl.Logging{Format: "xml"}},
+			`logging.format: Unsupported value: "xml"`),
+		Entry("an unknown log level", eirinictrl.ControllerConfig{Logging: eirinictrl.Logging{Level: "verbose"}},
+			`logging.level: Invalid value: "verbose": must be debug, info, error or fatal`),
+		Entry("an unknown component log level", eirinictrl.ControllerConfig{Logging: eirinictrl.Logging{
+			Components: map[string]string{"lrp-reconciler": "verbose"},
+		}}, `logging.components[lrp-reconciler]: Invalid value: "verbose"`),
+	)
+
+	It("reports all the invalid fields", func() {
+		err := config.Validate(eirinictrl.ControllerConfig{PrometheusPort: -1, TaskTTLSeconds: -1})
+		Expect(err).To(MatchError(ContainSubstring("prometheus_port")))
+		Expect(err).To(MatchError(ContainSubstring("task_ttl_seconds")))
+	})
+})
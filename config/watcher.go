@@ -0,0 +1,166 @@
+package config
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/lager"
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+)
+
+// reloadDelay lets the writes to the config file settle before reloading
+// it, so that partially written files are not loaded.
+const reloadDelay = 200 * time.Millisecond
+
+// Watcher reloads the config file when it changes, and passes the new
+// config to its callbacks. Invalid configs are ignored, and changes to
+// settings that are not reloadable are only reported.
+type Watcher struct {
+	logger    lager.Logger
+	path      string
+	mutex     sync.Mutex
+	current   eirinictrl.ControllerConfig
+	callbacks []func(eirinictrl.ControllerConfig)
+}
+
+func NewWatcher(logger lager.Logger, path string, current eirinictrl.ControllerConfig) *Watcher {
+	return &Watcher{
+		logger:  logger.Session("config-watcher", lager.Data{"path": path}),
+		path:    path,
+		current: current,
+	}
+}
+
+func (w *Watcher) OnReload(callback func(eirinictrl.ControllerConfig)) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.callbacks = append(w.callbacks, callback)
+}
+
+// Reload loads the config and applies it when it is valid and its
+// reloadable settings changed.
+func (w *Watcher) Reload() {
+	config, err := Load(w.path)
+	if err != nil {
+		w.logger.Error("failed-to-reload-config", err)
+
+		return
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if changed := changedFields(withoutReloadable(w.current), withoutReloadable(config)); len(changed) > 0 {
+		w.logger.Info("config-changes-need-restart", lager.Data{"fields": changed})
+	}
+
+	changed := changedFields(reloadable(w.current), reloadable(config))
+	if len(changed) == 0 {
+		return
+	}
+
+	w.current = withReloadable(w.current, config)
+
+	for _, callback := range w.callbacks {
+		callback(w.current)
+	}
+
+	w.logger.Info("config-reloaded", lager.Data{"fields": changed})
+}
+
+// Start reloads the config whenever its file changes, until the context is
+// done. The directory is watched rather than the file, as mounted config
+// maps are updated by swapping a symlink.
+func (w *Watcher) Start(ctx context.Context) error {
+	if w.path == "" {
+		<-ctx.Done()
+
+		return nil
+	}
+
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Wrap(err, "failed to create file watcher")
+	}
+	defer fsWatcher.Close()
+
+	if err := fsWatcher.Add(filepath.Dir(w.path)); err != nil {
+		return errors.Wrap(err, "failed to watch config directory")
+	}
+
+	reloadTimer := time.NewTimer(reloadDelay)
+	reloadTimer.Stop()
+
+	defer reloadTimer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-fsWatcher.Events:
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+
+			reloadTimer.Reset(reloadDelay)
+		case <-reloadTimer.C:
+			w.Reload()
+		case err := <-fsWatcher.Errors:
+			w.logger.Error("config-watch-error", err)
+		}
+	}
+}
+
+// NeedLeaderElection lets every replica reload its config.
+func (w *Watcher) NeedLeaderElection() bool {
+	return false
+}
+
+// withReloadable returns the config with the reloadable settings of
+// newConfig.
+func withReloadable(config, newConfig eirinictrl.ControllerConfig) eirinictrl.ControllerConfig {
+	config.TaskTTLSeconds = newConfig.TaskTTLSeconds
+	config.DefaultMinAvailableInstances = newConfig.DefaultMinAvailableInstances
+	config.TopologySpreadPolicy = newConfig.TopologySpreadPolicy
+	config.Logging.Level = newConfig.Logging.Level
+	config.Logging.Components = newConfig.Logging.Components
+
+	return config
+}
+
+func reloadable(config eirinictrl.ControllerConfig) eirinictrl.ControllerConfig {
+	return withReloadable(eirinictrl.ControllerConfig{}, config)
+}
+
+func withoutReloadable(config eirinictrl.ControllerConfig) eirinictrl.ControllerConfig {
+	return withReloadable(config, eirinictrl.ControllerConfig{})
+}
+
+// changedFields returns the YAML keys of the top level fields that differ.
+func changedFields(oldConfig, newConfig eirinictrl.ControllerConfig) []string {
+	return changedValueFields(reflect.ValueOf(oldConfig), reflect.ValueOf(newConfig))
+}
+
+func changedValueFields(oldValue, newValue reflect.Value) []string {
+	changed := []string{}
+
+	for i := 0; i < oldValue.NumField(); i++ {
+		key, inline := yamlKey(oldValue.Type().Field(i))
+
+		switch {
+		case key == "-":
+		case inline:
+			changed = append(changed, changedValueFields(oldValue.Field(i), newValue.Field(i))...)
+		case !reflect.DeepEqual(oldValue.Field(i).Interface(), newValue.Field(i).Interface()):
+			changed = append(changed, key)
+		}
+	}
+
+	return changed
+}
@@ -0,0 +1,132 @@
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/config"
+	"code.cloudfoundry.org/eirini-controller/tests"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Watcher", func() {
+	var (
+		logger     *tests.TestLogger
+		configPath string
+		initial    eirinictrl.ControllerConfig
+		watcher    *config.Watcher
+
+		reloadsMutex sync.Mutex
+		reloads      []eirinictrl.ControllerConfig
+	)
+
+	writeConfig := func(configYAML string) {
+		Expect(os.WriteFile(configPath, []byte(configYAML), 0o600)).To(Succeed())
+	}
+
+	getReloads := func() []eirinictrl.ControllerConfig {
+		reloadsMutex.Lock()
+		defer reloadsMutex.Unlock()
+
+		return append([]eirinictrl.ControllerConfig{}, reloads...)
+	}
+
+	BeforeEach(func() {
+		logger = tests.NewTestLogger("config-watcher-test")
+		configPath = filepath.Join(GinkgoT().TempDir(), "controller.yml")
+		writeConfig("task_ttl_seconds: 30\nprometheus_port: 8080\n")
+
+		var err error
+		initial, err = config.Load(configPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		reloads = nil
+		watcher = config.NewWatcher(logger, configPath, initial)
+		watcher.OnReload(func(cfg eirinictrl.ControllerConfig) {
+			reloadsMutex.Lock()
+			defer reloadsMutex.Unlock()
+
+			reloads = append(reloads, cfg)
+		})
+	})
+
+	Describe("Reload", func() {
+		It("does nothing when the config did not change", func() {
+			watcher.Reload()
+
+			Expect(getReloads()).To(BeEmpty())
+		})
+
+		It("applies the changed reloadable settings", func() {
+			writeConfig("task_ttl_seconds: 60\nprometheus_port: 8080\nlogging:\n  level: debug\n")
+			watcher.Reload()
+
+			Expect(getReloads()).To(HaveLen(1))
+			Expect(getReloads()[0].TaskTTLSeconds).To(Equal(60))
+			Expect(getReloads()[0].Logging.Level).To(Equal("debug"))
+			Expect(logger.LogMessages()).To(ContainElement("config-watcher-test.config-watcher.config-reloaded"))
+		})
+
+		It("keeps the settings that need a restart", func() {
+			writeConfig("task_ttl_seconds: 60\nprometheus_port: 9090\n")
+			watcher.Reload()
+
+			Expect(getReloads()).To(HaveLen(1))
+			Expect(getReloads()[0].TaskTTLSeconds).To(Equal(60))
+			Expect(getReloads()[0].PrometheusPort).To(Equal(8080))
+			Expect(logger.LogMessages()).To(ContainElement("config-watcher-test.config-watcher.config-changes-need-restart"))
+		})
+
+		It("ignores invalid configs", func() {
+			writeConfig("task_ttl_seconds: -1\n")
+			watcher.Reload()
+
+			Expect(getReloads()).To(BeEmpty())
+			Expect(logger.LogMessages()).To(ContainElement("config-watcher-test.config-watcher.failed-to-reload-config"))
+		})
+	})
+
+	Describe("Start", func() {
+		var (
+			cancel context.CancelFunc
+			done   chan struct{}
+		)
+
+		BeforeEach(func() {
+			var ctx context.Context
+			ctx, cancel = context.WithCancel(context.Background())
+			done = make(chan struct{})
+
+			go func() {
+				defer GinkgoRecover()
+				defer close(done)
+
+				Expect(watcher.Start(ctx)).To(Succeed())
+			}()
+		})
+
+		AfterEach(func() {
+			cancel()
+			Eventually(done).Should(BeClosed())
+		})
+
+		It("reloads the config when the file changes", func() {
+			Eventually(func() []eirinictrl.ControllerConfig {
+				writeConfig("task_ttl_seconds: 90\nprometheus_port: 8080\n")
+
+				return getReloads()
+			}).WithPolling(500 * time.Millisecond).WithTimeout(5 * time.Second).ShouldNot(BeEmpty())
+
+			Expect(getReloads()).To(HaveEach(HaveField("TaskTTLSeconds", 90)))
+		})
+
+		It("runs on every replica", func() {
+			Expect(watcher.NeedLeaderElection()).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,115 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const upgradeCRDsHint = "apply the CRDs of the Helm chart matching this controller version"
+
+type expectedCRD struct {
+	name     string
+	versions []string
+	status   bool
+}
+
+// expectedCRDs are the CRDs served for the controller. The v1 versions are
+// stored, and the others converted by the conversion webhook.
+var expectedCRDs = []expectedCRD{
+	{name: "lrps.eirini.cloudfoundry.org", versions: []string{"v1", "v2"}, status: true},
+	{name: "tasks.eirini.cloudfoundry.org", versions: []string{"v1", "v2"}, status: true},
+	{name: "workloaddefaults.eirini.cloudfoundry.org", versions: []string{"v1"}},
+}
+
+const storageVersion = "v1"
+
+func (d *Doctor) checkCRDs(ctx context.Context) []Result {
+	results := []Result{}
+
+	for _, expected := range expectedCRDs {
+		results = append(results, d.checkCRD(ctx, expected)...)
+	}
+
+	return results
+}
+
+func (d *Doctor) checkCRD(ctx context.Context, expected expectedCRD) []Result {
+	check := "CRD " + expected.name
+
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := d.client.Get(ctx, client.ObjectKey{Name: expected.name}, crd); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return []Result{failed(check, "not found", upgradeCRDsHint)}
+		}
+
+		return []Result{failed(check, err.Error(), "check that the kubeconfig can get CRDs")}
+	}
+
+	results := []Result{}
+
+	if !isEstablished(crd) {
+		results = append(results, failed(check, "not established", "check the conditions of the CRD with kubectl describe crd "+expected.name))
+	}
+
+	for _, name := range expected.versions {
+		version := findVersion(crd, name)
+
+		switch {
+		case version == nil || !version.Served:
+			results = append(results, failed(check, fmt.Sprintf("version %s is not served", name), upgradeCRDsHint))
+		case name == storageVersion && !version.Storage:
+			results = append(results, failed(check, fmt.Sprintf("version %s is not the storage version", name), upgradeCRDsHint))
+		case expected.status && (version.Subresources == nil || version.Subresources.Status == nil):
+			results = append(results, failed(check, fmt.Sprintf("version %s has no status subresource, so the controller cannot update statuses", name), upgradeCRDsHint))
+		}
+	}
+
+	if len(expected.versions) > 1 {
+		results = append(results, d.checkConversion(ctx, check, crd.Spec.Conversion)...)
+	}
+
+	if len(results) == 0 {
+		return []Result{passed(check)}
+	}
+
+	return results
+}
+
+func (d *Doctor) checkConversion(ctx context.Context, check string, conversion *apiextensionsv1.CustomResourceConversion) []Result {
+	if conversion == nil || conversion.Strategy != apiextensionsv1.WebhookConverter || conversion.Webhook == nil || conversion.Webhook.ClientConfig == nil {
+		return []Result{failed(check, "versions are not converted by the conversion webhook", upgradeCRDsHint)}
+	}
+
+	clientConfig := conversion.Webhook.ClientConfig
+
+	var namespace, name string
+	if clientConfig.Service != nil {
+		namespace, name = clientConfig.Service.Namespace, clientConfig.Service.Name
+	}
+
+	return d.checkClientConfig(ctx, check, "conversion webhook", clientConfig.CABundle, namespace, name)
+}
+
+func isEstablished(crd *apiextensionsv1.CustomResourceDefinition) bool {
+	for _, condition := range crd.Status.Conditions {
+		if condition.Type == apiextensionsv1.Established {
+			return condition.Status == apiextensionsv1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+func findVersion(crd *apiextensionsv1.CustomResourceDefinition, name string) *apiextensionsv1.CustomResourceDefinitionVersion {
+	for i := range crd.Spec.Versions {
+		if crd.Spec.Versions[i].Name == name {
+			return &crd.Spec.Versions[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type Status string
+
+const (
+	StatusOK      Status = "OK"
+	StatusWarning Status = "WARN"
+	StatusFailed  Status = "FAIL"
+)
+
+// Result is the outcome of a check, with a hint on how to fix it when it did
+// not pass.
+type Result struct {
+	Check   string
+	Status  Status
+	Message string
+	Hint    string
+}
+
+type Doctor struct {
+	client client.Client
+	config eirinictrl.ControllerConfig
+}
+
+func NewDoctor(client client.Client, config eirinictrl.ControllerConfig) *Doctor {
+	return &Doctor{
+		client: client,
+		config: config,
+	}
+}
+
+// Run runs all the checks, carrying on after the failed ones.
+func (d *Doctor) Run(ctx context.Context) []Result {
+	results := []Result{}
+
+	for _, check := range []func(context.Context) []Result{
+		d.checkCRDs,
+		d.checkWebhooks,
+		d.checkNamespaces,
+		d.checkTopology,
+	} {
+		results = append(results, check(ctx)...)
+	}
+
+	return results
+}
+
+// Failed returns whether any of the checks failed. Warnings do not fail.
+func Failed(results []Result) bool {
+	for _, result := range results {
+		if result.Status == StatusFailed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WriteReport writes a line per check, followed by the hints of the checks
+// that did not pass and a summary.
+func WriteReport(writer io.Writer, results []Result) error {
+	var (
+		report   strings.Builder
+		failed   int
+		warnings int
+	)
+
+	for _, result := range results {
+		fmt.Fprintf(&report, "%-6s %s", "["+string(result.Status)+"]", result.Check)
+
+		if result.Message != "" {
+			fmt.Fprintf(&report, ": %s", result.Message)
+		}
+
+		report.WriteString("\n")
+
+		if result.Status != StatusOK && result.Hint != "" {
+			fmt.Fprintf(&report, "       fix: %s\n", result.Hint)
+		}
+
+		switch result.Status {
+		case StatusFailed:
+			failed++
+		case StatusWarning:
+			warnings++
+		case StatusOK:
+		}
+	}
+
+	fmt.Fprintf(&report, "\n%d checks, %d failed, %d warned\n", len(results), failed, warnings)
+
+	_, err := io.WriteString(writer, report.String())
+
+	return errors.Wrap(err, "failed to write report")
+}
+
+func passed(check string) Result {
+	return Result{Check: check, Status: StatusOK}
+}
+
+func failed(check, message, hint string) Result {
+	return Result{Check: check, Status: StatusFailed, Message: message, Hint: hint}
+}
+
+func warning(check, message, hint string) Result {
+	return Result{Check: check, Status: StatusWarning, Message: message, Hint: hint}
+}
@@ -0,0 +1,481 @@
+package doctor_test
+
+import (
+	"bytes"
+	"context"
+
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/doctor"
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	eirinischeme "code.cloudfoundry.org/eirini-controller/pkg/generated/clientset/versioned/scheme"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var caBundle = []byte("ca-bundle")
+
+func newCRD(plural string, versions ...string) *apiextensionsv1.CustomResourceDefinition {
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: plural + ".eirini.cloudfoundry.org"},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{
+			Conditions: []apiextensionsv1.CustomResourceDefinitionCondition{
+				{Type: apiextensionsv1.Established, Status: apiextensionsv1.ConditionTrue},
+			},
+		},
+	}
+
+	for _, version := range versions {
+		crd.Spec.Versions = append(crd.Spec.Versions, apiextensionsv1.CustomResourceDefinitionVersion{
+			Name:         version,
+			Served:       true,
+			Storage:      version == "v1",
+			Subresources: &apiextensionsv1.CustomResourceSubresources{Status: &apiextensionsv1.CustomResourceSubresourceStatus{}},
+		})
+	}
+
+	if len(versions) > 1 {
+		crd.Spec.Conversion = &apiextensionsv1.CustomResourceConversion{
+			Strategy: apiextensionsv1.WebhookConverter,
+			Webhook: &apiextensionsv1.WebhookConversion{
+				ClientConfig: &apiextensionsv1.WebhookClientConfig{
+					Service:  &apiextensionsv1.ServiceReference{Namespace: "eirini-controller", Name: "eirini-webhooks"},
+					CABundle: caBundle,
+				},
+			},
+		}
+	}
+
+	return crd
+}
+
+func webhookClientConfig() admissionregistrationv1.WebhookClientConfig {
+	return admissionregistrationv1.WebhookClientConfig{
+		Service:  &admissionregistrationv1.ServiceReference{Namespace: "eirini-controller", Name: "eirini-webhooks"},
+		CABundle: caBundle,
+	}
+}
+
+func resultOf(results []doctor.Result, check string) doctor.Result {
+	for _, result := range results {
+		if result.Check == check {
+			return result
+		}
+	}
+
+	Fail("no result for check " + check)
+
+	return doctor.Result{}
+}
+
+var _ = Describe("Doctor", func() {
+	var (
+		cfg          eirinictrl.ControllerConfig
+		lrpCRD       *apiextensionsv1.CustomResourceDefinition
+		taskCRD      *apiextensionsv1.CustomResourceDefinition
+		defaultsCRD  *apiextensionsv1.CustomResourceDefinition
+		mutating     *admissionregistrationv1.MutatingWebhookConfiguration
+		validating   *admissionregistrationv1.ValidatingWebhookConfiguration
+		objects      []client.Object
+		results      []doctor.Result
+		workloadsNss []string
+	)
+
+	BeforeEach(func() {
+		cfg = eirinictrl.ControllerConfig{
+			ApplicationServiceAccount: "eirini",
+			RegistrySecretName:        "registry-secret",
+			WorkloadsNamespaces:       []string{"apps"},
+		}
+
+		lrpCRD = newCRD("lrps", "v1", "v2")
+		taskCRD = newCRD("tasks", "v1", "v2")
+		defaultsCRD = newCRD("workloaddefaults", "v1")
+
+		mutating = &admissionregistrationv1.MutatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "eirini-resource-defaulter-hook"},
+		}
+		for _, name := range []string{"lrp-defaulter", "task-defaulter", "instance-index-env-injector"} {
+			mutating.Webhooks = append(mutating.Webhooks, admissionregistrationv1.MutatingWebhook{
+				Name:         name + ".eirini.cloudfoundry.org",
+				ClientConfig: webhookClientConfig(),
+			})
+		}
+
+		validating = &admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "eirini-resource-validator-hook"},
+		}
+		for _, name := range []string{"resource-validator", "task-validator"} {
+			validating.Webhooks = append(validating.Webhooks, admissionregistrationv1.ValidatingWebhook{
+				Name:         name + ".eirini.cloudfoundry.org",
+				ClientConfig: webhookClientConfig(),
+			})
+		}
+
+		workloadsNss = []string{"apps"}
+		objects = []client.Object{
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "eirini-controller"}},
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "eirini-controller", Name: "eirini-webhooks"}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(kscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(apiextensionsv1.AddToScheme(scheme)).To(Succeed())
+		Expect(eirinischeme.AddToScheme(scheme)).To(Succeed())
+
+		for _, namespace := range workloadsNss {
+			objects = append(objects,
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "registry-secret"},
+					Type:       corev1.SecretTypeDockerConfigJson,
+				},
+				&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "eirini"}},
+			)
+		}
+
+		objects = append(objects, lrpCRD, taskCRD, defaultsCRD, mutating, validating)
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+		results = doctor.NewDoctor(fakeClient, cfg).Run(context.Background())
+	})
+
+	It("passes on a healthy cluster", func() {
+		Expect(results).To(HaveEach(HaveField("Status", doctor.StatusOK)))
+		Expect(doctor.Failed(results)).To(BeFalse())
+
+		Expect(resultOf(results, "CRD lrps.eirini.cloudfoundry.org").Status).To(Equal(doctor.StatusOK))
+		Expect(resultOf(results, "webhook task-defaulter.eirini.cloudfoundry.org").Status).To(Equal(doctor.StatusOK))
+		Expect(resultOf(results, "workloads namespace apps").Status).To(Equal(doctor.StatusOK))
+		Expect(resultOf(results, "registry secret apps/registry-secret").Status).To(Equal(doctor.StatusOK))
+		Expect(resultOf(results, "application service account apps/eirini").Status).To(Equal(doctor.StatusOK))
+	})
+
+	Describe("CRDs", func() {
+		When("a CRD is missing", func() {
+			BeforeEach(func() {
+				defaultsCRD.Name = "other.eirini.cloudfoundry.org"
+			})
+
+			It("fails", func() {
+				result := resultOf(results, "CRD workloaddefaults.eirini.cloudfoundry.org")
+				Expect(result.Status).To(Equal(doctor.StatusFailed))
+				Expect(result.Message).To(Equal("not found"))
+				Expect(result.Hint).To(ContainSubstring("CRDs of the Helm chart"))
+				Expect(doctor.Failed(results)).To(BeTrue())
+			})
+		})
+
+		When("a CRD lacks the status subresource", func() {
+			BeforeEach(func() {
+				lrpCRD.Spec.Versions[0].Subresources = nil
+			})
+
+			It("fails", func() {
+				result := resultOf(results, "CRD lrps.eirini.cloudfoundry.org")
+				Expect(result.Status).To(Equal(doctor.StatusFailed))
+				Expect(result.Message).To(ContainSubstring("version v1 has no status subresource"))
+			})
+		})
+
+		When("a CRD version is not served", func() {
+			BeforeEach(func() {
+				taskCRD.Spec.Versions = taskCRD.Spec.Versions[:1]
+			})
+
+			It("fails", func() {
+				Expect(resultOf(results, "CRD tasks.eirini.cloudfoundry.org").Message).To(Equal("version v2 is not served"))
+			})
+		})
+
+		When("v1 is not the storage version", func() {
+			BeforeEach(func() {
+				lrpCRD.Spec.Versions[0].Storage = false
+				lrpCRD.Spec.Versions[1].Storage = true
+			})
+
+			It("fails", func() {
+				Expect(resultOf(results, "CRD lrps.eirini.cloudfoundry.org").Message).To(Equal("version v1 is not the storage version"))
+			})
+		})
+
+		When("a CRD is not established", func() {
+			BeforeEach(func() {
+				defaultsCRD.Status.Conditions = nil
+			})
+
+			It("fails", func() {
+				Expect(resultOf(results, "CRD workloaddefaults.eirini.cloudfoundry.org").Message).To(Equal("not established"))
+			})
+		})
+
+		When("the conversion webhook has no caBundle", func() {
+			BeforeEach(func() {
+				lrpCRD.Spec.Conversion.Webhook.ClientConfig.CABundle = nil
+			})
+
+			It("fails", func() {
+				result := resultOf(results, "CRD lrps.eirini.cloudfoundry.org")
+				Expect(result.Status).To(Equal(doctor.StatusFailed))
+				Expect(result.Message).To(ContainSubstring("the conversion webhook has no caBundle"))
+				Expect(result.Hint).To(ContainSubstring("webhooks.ca_bundle"))
+			})
+		})
+
+		When("the CRD versions are not converted", func() {
+			BeforeEach(func() {
+				taskCRD.Spec.Conversion = nil
+			})
+
+			It("fails", func() {
+				Expect(resultOf(results, "CRD tasks.eirini.cloudfoundry.org").Message).To(Equal("versions are not converted by the conversion webhook"))
+			})
+		})
+	})
+
+	Describe("webhooks", func() {
+		When("a webhook is missing", func() {
+			BeforeEach(func() {
+				validating.Webhooks = validating.Webhooks[:1]
+			})
+
+			It("fails", func() {
+				result := resultOf(results, "webhook task-validator.eirini.cloudfoundry.org")
+				Expect(result.Status).To(Equal(doctor.StatusFailed))
+				Expect(result.Message).To(Equal("not found"))
+			})
+		})
+
+		When("a webhook has no caBundle", func() {
+			BeforeEach(func() {
+				mutating.Webhooks[2].ClientConfig.CABundle = nil
+			})
+
+			It("fails", func() {
+				result := resultOf(results, "webhook instance-index-env-injector.eirini.cloudfoundry.org")
+				Expect(result.Status).To(Equal(doctor.StatusFailed))
+				Expect(result.Message).To(ContainSubstring("has no caBundle"))
+			})
+		})
+
+		When("the webhook service is missing", func() {
+			BeforeEach(func() {
+				objects = objects[:1]
+			})
+
+			It("fails", func() {
+				result := resultOf(results, "webhook lrp-defaulter.eirini.cloudfoundry.org")
+				Expect(result.Status).To(Equal(doctor.StatusFailed))
+				Expect(result.Message).To(Equal("service eirini-controller/eirini-webhooks of the webhook not found"))
+			})
+		})
+	})
+
+	Describe("namespaces", func() {
+		When("a workloads namespace is missing", func() {
+			BeforeEach(func() {
+				cfg.WorkloadsNamespaces = []string{"apps", "missing"}
+			})
+
+			It("fails and checks the other namespaces", func() {
+				Expect(resultOf(results, "workloads namespace missing").Status).To(Equal(doctor.StatusFailed))
+				Expect(resultOf(results, "registry secret apps/registry-secret").Status).To(Equal(doctor.StatusOK))
+			})
+		})
+
+		When("the registry secret is missing", func() {
+			BeforeEach(func() {
+				cfg.RegistrySecretName = "other-secret"
+			})
+
+			It("fails with the command creating it", func() {
+				result := resultOf(results, "registry secret apps/other-secret")
+				Expect(result.Status).To(Equal(doctor.StatusFailed))
+				Expect(result.Hint).To(HavePrefix("kubectl create secret docker-registry other-secret --namespace apps"))
+			})
+		})
+
+		When("the namespace defaults replace the registry secret and service account", func() {
+			BeforeEach(func() {
+				objects = append(objects, &eiriniv1.WorkloadDefaults{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: eiriniv1.WorkloadDefaultsName},
+					Spec: eiriniv1.WorkloadDefaultsSpec{
+						RegistrySecretName:        "apps-secret",
+						ApplicationServiceAccount: "apps-account",
+					},
+				})
+			})
+
+			It("checks the replacements", func() {
+				Expect(resultOf(results, "registry secret apps/apps-secret").Status).To(Equal(doctor.StatusFailed))
+				Expect(resultOf(results, "application service account apps/apps-account").Status).To(Equal(doctor.StatusFailed))
+			})
+
+			It("fails on a service account that is not allowed", func() {
+				result := resultOf(results, "application service account apps/apps-account")
+				Expect(result.Message).To(HavePrefix("not in workload_identity.allowed_service_accounts"))
+			})
+		})
+
+		When("the registry secret is not a docker config", func() {
+			BeforeEach(func() {
+				objects = append(objects, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "opaque-secret"},
+					Type:       corev1.SecretTypeOpaque,
+				})
+				cfg.RegistrySecretName = "opaque-secret"
+			})
+
+			It("warns", func() {
+				result := resultOf(results, "registry secret apps/opaque-secret")
+				Expect(result.Status).To(Equal(doctor.StatusWarning))
+				Expect(doctor.Failed(results)).To(BeFalse())
+			})
+		})
+
+		When("the application service account is missing", func() {
+			BeforeEach(func() {
+				cfg.ApplicationServiceAccount = "other-account"
+			})
+
+			It("fails", func() {
+				result := resultOf(results, "application service account apps/other-account")
+				Expect(result.Status).To(Equal(doctor.StatusFailed))
+				Expect(result.Hint).To(HavePrefix("kubectl create serviceaccount other-account --namespace apps"))
+			})
+		})
+
+		When("apps get their own service accounts", func() {
+			BeforeEach(func() {
+				cfg.ApplicationServiceAccount = "other-account"
+				cfg.WorkloadIdentity.PerAppServiceAccounts = true
+			})
+
+			It("does not check the application service account", func() {
+				Expect(doctor.Failed(results)).To(BeFalse())
+			})
+		})
+
+		When("the controller serves all namespaces", func() {
+			BeforeEach(func() {
+				cfg.WorkloadsNamespaces = nil
+				workloadsNss = []string{"apps", "idle"}
+				objects = append(objects, &eiriniv1.LRP{ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "lrp"}})
+				cfg.RegistrySecretName = "other-secret"
+			})
+
+			It("checks the namespaces with workloads", func() {
+				Expect(resultOf(results, "workloads namespaces").Message).To(Equal("all namespaces are served, checking the 1 with LRPs or tasks"))
+				Expect(resultOf(results, "registry secret apps/other-secret").Status).To(Equal(doctor.StatusFailed))
+				Expect(results).NotTo(ContainElement(HaveField("Check", "registry secret idle/other-secret")))
+			})
+		})
+
+		When("the controller serves the namespaces matching a selector", func() {
+			BeforeEach(func() {
+				cfg.WorkloadsNamespaces = nil
+				cfg.WorkloadsNamespaceSelector = "eirini=yes"
+				objects = append(objects, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "selected", Labels: map[string]string{"eirini": "yes"}}})
+			})
+
+			It("checks the matching namespaces", func() {
+				Expect(resultOf(results, "workloads namespaces").Message).To(Equal("1 namespaces match the selector"))
+				Expect(resultOf(results, "registry secret selected/registry-secret").Status).To(Equal(doctor.StatusFailed))
+			})
+		})
+
+		When("no namespace matches the selector", func() {
+			BeforeEach(func() {
+				cfg.WorkloadsNamespaces = nil
+				cfg.WorkloadsNamespaceSelector = "eirini=yes"
+			})
+
+			It("warns", func() {
+				Expect(resultOf(results, "workloads namespaces").Status).To(Equal(doctor.StatusWarning))
+			})
+		})
+
+		When("the lease namespaces are missing", func() {
+			BeforeEach(func() {
+				cfg.LeaderElectionNamespace = "leader"
+				cfg.Sharding = eirinictrl.Sharding{Enabled: true, Shards: 2, LeaseNamespace: "leases"}
+			})
+
+			It("fails", func() {
+				Expect(resultOf(results, "leader election namespace leader").Status).To(Equal(doctor.StatusFailed))
+				Expect(resultOf(results, "shard lease namespace leases").Status).To(Equal(doctor.StatusFailed))
+			})
+		})
+
+		When("a security profile namespace is missing", func() {
+			BeforeEach(func() {
+				cfg.NamespaceSecurityProfiles = map[string]eirinictrl.SecurityProfile{"gone": {}}
+			})
+
+			It("warns", func() {
+				Expect(resultOf(results, "security profile namespace gone").Status).To(Equal(doctor.StatusWarning))
+			})
+		})
+	})
+
+	Describe("topology", func() {
+		BeforeEach(func() {
+			cfg.TopologySpreadPolicy = eiriniv1.TopologySpreadPolicyHard
+			objects = append(objects, &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name:   "node-1",
+				Labels: map[string]string{corev1.LabelHostname: "node-1"},
+			}})
+		})
+
+		When("no node has a zone label", func() {
+			It("fails with the hard topology spread policy", func() {
+				result := resultOf(results, "node zone labels")
+				Expect(result.Status).To(Equal(doctor.StatusFailed))
+				Expect(result.Message).To(ContainSubstring("leaves every LRP instance pending"))
+				Expect(result.Hint).To(ContainSubstring("set topology_spread_policy to soft"))
+			})
+		})
+
+		When("the nodes have zone labels", func() {
+			BeforeEach(func() {
+				objects = append(objects, &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+					Name:   "node-2",
+					Labels: map[string]string{corev1.LabelTopologyZone: "zone-a"},
+				}})
+			})
+
+			It("passes", func() {
+				Expect(resultOf(results, "node zone labels").Status).To(Equal(doctor.StatusOK))
+			})
+		})
+	})
+})
+
+var _ = Describe("WriteReport", func() {
+	It("writes the results with the hints of the failed checks", func() {
+		var buffer bytes.Buffer
+
+		Expect(doctor.WriteReport(&buffer, []doctor.Result{
+			{Check: "CRD lrps.eirini.cloudfoundry.org", Status: doctor.StatusOK, Hint: "unused"},
+			{Check: "registry secret apps/registry", Status: doctor.StatusFailed, Message: "not found", Hint: "create it"},
+			{Check: "security profile namespace gone", Status: doctor.StatusWarning, Message: "not found"},
+		})).To(Succeed())
+
+		Expect(buffer.String()).To(Equal(`[OK]   CRD lrps.eirini.cloudfoundry.org
+[FAIL] registry secret apps/registry: not found
+       fix: create it
+[WARN] security profile namespace gone: not found
+
+3 checks, 1 failed, 1 warned
+`))
+	})
+})
@@ -0,0 +1,233 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"code.cloudfoundry.org/eirini-controller/k8s"
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func (d *Doctor) checkNamespaces(ctx context.Context) []Result {
+	results := d.checkWorkloadsNamespaces(ctx)
+
+	if d.config.LeaderElectionNamespace != "" {
+		results = append(results, d.checkNamespaceExists(ctx, "leader election namespace", d.config.LeaderElectionNamespace, StatusFailed))
+	}
+
+	if d.config.Sharding.Enabled && d.config.Sharding.LeaseNamespace != "" {
+		results = append(results, d.checkNamespaceExists(ctx, "shard lease namespace", d.config.Sharding.LeaseNamespace, StatusFailed))
+	}
+
+	namespaces := []string{}
+	for namespace := range d.config.NamespaceSecurityProfiles {
+		namespaces = append(namespaces, namespace)
+	}
+
+	sort.Strings(namespaces)
+
+	for _, namespace := range namespaces {
+		results = append(results, d.checkNamespaceExists(ctx, "security profile namespace", namespace, StatusWarning))
+	}
+
+	return results
+}
+
+// checkWorkloadsNamespaces checks the registry secret and application service
+// account of the namespaces the controller serves. When it serves all of
+// them, the namespaces with LRPs or tasks are checked.
+func (d *Doctor) checkWorkloadsNamespaces(ctx context.Context) []Result {
+	results := []Result{}
+
+	namespaces := []string{}
+
+	for _, namespace := range d.config.Namespaces() {
+		result := d.checkNamespaceExists(ctx, "workloads namespace", namespace, StatusFailed)
+		results = append(results, result)
+
+		if result.Status == StatusOK {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+
+	if len(d.config.Namespaces()) == 0 {
+		var (
+			result Result
+			err    error
+		)
+
+		namespaces, result, err = d.discoverWorkloadsNamespaces(ctx)
+		if err != nil {
+			return []Result{failed("workloads namespaces", err.Error(), "check that the kubeconfig can list namespaces, LRPs and tasks")}
+		}
+
+		results = append(results, result)
+	}
+
+	for _, namespace := range namespaces {
+		defaults, err := k8s.GetWorkloadDefaults(ctx, d.client, namespace)
+		if err != nil {
+			results = append(results, failed("workload defaults "+namespace, err.Error(), "check that the kubeconfig can get WorkloadDefaults"))
+
+			continue
+		}
+
+		if secretName := k8s.RegistrySecretName(d.config.RegistrySecretName, defaults); secretName != "" {
+			results = append(results, d.checkRegistrySecret(ctx, namespace, secretName))
+		}
+
+		if serviceAccountName := d.applicationServiceAccount(defaults); serviceAccountName != "" {
+			results = append(results, d.checkServiceAccount(ctx, namespace, serviceAccountName, defaults))
+		}
+	}
+
+	return results
+}
+
+func (d *Doctor) discoverWorkloadsNamespaces(ctx context.Context) ([]string, Result, error) {
+	check := "workloads namespaces"
+
+	if d.config.WorkloadsNamespaceSelector != "" {
+		selector, err := labels.Parse(d.config.WorkloadsNamespaceSelector)
+		if err != nil {
+			return nil, Result{}, errors.Wrap(err, "invalid workloads_namespace_selector")
+		}
+
+		namespaceList := &corev1.NamespaceList{}
+		if err := d.client.List(ctx, namespaceList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, Result{}, errors.Wrap(err, "failed to list namespaces")
+		}
+
+		namespaces := []string{}
+		for _, namespace := range namespaceList.Items {
+			namespaces = append(namespaces, namespace.Name)
+		}
+
+		if len(namespaces) == 0 {
+			return nil, warning(check, "no namespace matches the workloads_namespace_selector", "label the workloads namespaces, or fix workloads_namespace_selector"), nil
+		}
+
+		return namespaces, Result{Check: check, Status: StatusOK, Message: fmt.Sprintf("%d namespaces match the selector", len(namespaces))}, nil
+	}
+
+	lrps := &eiriniv1.LRPList{}
+	if err := d.client.List(ctx, lrps); err != nil {
+		return nil, Result{}, errors.Wrap(err, "failed to list LRPs")
+	}
+
+	tasks := &eiriniv1.TaskList{}
+	if err := d.client.List(ctx, tasks); err != nil {
+		return nil, Result{}, errors.Wrap(err, "failed to list tasks")
+	}
+
+	seen := map[string]bool{}
+	namespaces := []string{}
+
+	for _, namespace := range append(lrpNamespaces(lrps), taskNamespaces(tasks)...) {
+		if !seen[namespace] {
+			seen[namespace] = true
+			namespaces = append(namespaces, namespace)
+		}
+	}
+
+	sort.Strings(namespaces)
+
+	return namespaces, Result{
+		Check:   check,
+		Status:  StatusOK,
+		Message: fmt.Sprintf("all namespaces are served, checking the %d with LRPs or tasks", len(namespaces)),
+	}, nil
+}
+
+func (d *Doctor) applicationServiceAccount(defaults *eiriniv1.WorkloadDefaultsSpec) string {
+	if d.config.WorkloadIdentity.PerAppServiceAccounts {
+		return ""
+	}
+
+	if defaults.ApplicationServiceAccount != "" {
+		return defaults.ApplicationServiceAccount
+	}
+
+	return d.config.ApplicationServiceAccount
+}
+
+func (d *Doctor) checkRegistrySecret(ctx context.Context, namespace, name string) Result {
+	check := fmt.Sprintf("registry secret %s/%s", namespace, name)
+	hint := fmt.Sprintf("kubectl create secret docker-registry %s --namespace %s --docker-server=... --docker-username=... --docker-password=..., or fix registry_secret_name", name, namespace)
+
+	secret := &corev1.Secret{}
+	if err := d.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return failed(check, "not found, so images from private registries cannot be pulled", hint)
+		}
+
+		return failed(check, err.Error(), "check that the kubeconfig can get secrets")
+	}
+
+	if secret.Type != corev1.SecretTypeDockerConfigJson && secret.Type != corev1.SecretTypeDockercfg {
+		return warning(check, fmt.Sprintf("has type %s, which is ignored when pulling images", secret.Type), hint)
+	}
+
+	return passed(check)
+}
+
+// checkServiceAccount also checks that the service account set by the
+// namespace workload defaults is allowed, as workloads fail to start
+// otherwise.
+func (d *Doctor) checkServiceAccount(ctx context.Context, namespace, name string, defaults *eiriniv1.WorkloadDefaultsSpec) Result {
+	check := fmt.Sprintf("application service account %s/%s", namespace, name)
+
+	if name == defaults.ApplicationServiceAccount && !k8s.IsServiceAccountAllowed(d.config.WorkloadIdentity, name, "") {
+		return failed(check, "not in workload_identity.allowed_service_accounts, so app pods cannot be created",
+			fmt.Sprintf("add %s to workload_identity.allowed_service_accounts, or fix the WorkloadDefaults of %s", name, namespace))
+	}
+
+	if err := d.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &corev1.ServiceAccount{}); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return failed(check, "not found, so app pods cannot be created",
+				fmt.Sprintf("kubectl create serviceaccount %s --namespace %s, or fix application_service_account", name, namespace))
+		}
+
+		return failed(check, err.Error(), "check that the kubeconfig can get service accounts")
+	}
+
+	return passed(check)
+}
+
+func (d *Doctor) checkNamespaceExists(ctx context.Context, kind, namespace string, missingStatus Status) Result {
+	check := kind + " " + namespace
+
+	if err := d.client.Get(ctx, client.ObjectKey{Name: namespace}, &corev1.Namespace{}); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return Result{Check: check, Status: missingStatus, Message: "not found", Hint: "create the namespace, or remove it from the controller config"}
+		}
+
+		return failed(check, err.Error(), "check that the kubeconfig can get namespaces")
+	}
+
+	return passed(check)
+}
+
+func lrpNamespaces(lrps *eiriniv1.LRPList) []string {
+	namespaces := []string{}
+	for _, lrp := range lrps.Items {
+		namespaces = append(namespaces, lrp.Namespace)
+	}
+
+	return namespaces
+}
+
+func taskNamespaces(tasks *eiriniv1.TaskList) []string {
+	namespaces := []string{}
+	for _, task := range tasks.Items {
+		namespaces = append(namespaces, task.Namespace)
+	}
+
+	return namespaces
+}
@@ -0,0 +1,4 @@
+// Package doctor checks the prerequisites of the controller in a cluster:
+// the CRDs, the webhook configurations, and the secrets, service accounts and
+// namespaces the controller configuration refers to.
+package doctor
@@ -0,0 +1,33 @@
+package doctor
+
+import (
+	"context"
+
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const zoneLabelsCheck = "node zone labels"
+
+// checkTopology checks that the nodes carry zone labels when the hard
+// topology spread policy is configured, as it never schedules instances on
+// nodes without them.
+func (d *Doctor) checkTopology(ctx context.Context) []Result {
+	if d.config.TopologySpreadPolicy != eiriniv1.TopologySpreadPolicyHard {
+		return nil
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := d.client.List(ctx, nodes, client.HasLabels{corev1.LabelTopologyZone}, client.Limit(1)); err != nil {
+		return []Result{failed(zoneLabelsCheck, err.Error(), "check that the kubeconfig can list nodes")}
+	}
+
+	if len(nodes.Items) == 0 {
+		return []Result{failed(zoneLabelsCheck,
+			"no node has the "+corev1.LabelTopologyZone+" label, so the hard topology_spread_policy leaves every LRP instance pending",
+			"label the nodes with their zone, or set topology_spread_policy to soft")}
+	}
+
+	return []Result{passed(zoneLabelsCheck)}
+}
@@ -0,0 +1,108 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	installWebhooksHint = "apply the webhook configurations of the Helm chart"
+	caBundleHint        = "set webhooks.ca_bundle in the Helm values, or check that cert-manager injects the CA of the webhook-cert certificate"
+)
+
+// expectedMutatingWebhooks and expectedValidatingWebhooks are the webhooks
+// served by the controller, by their name in the webhook configurations.
+var (
+	expectedMutatingWebhooks = []string{
+		"lrp-defaulter.eirini.cloudfoundry.org",
+		"task-defaulter.eirini.cloudfoundry.org",
+		"instance-index-env-injector.eirini.cloudfoundry.org",
+	}
+	expectedValidatingWebhooks = []string{
+		"resource-validator.eirini.cloudfoundry.org",
+		"task-validator.eirini.cloudfoundry.org",
+	}
+)
+
+func (d *Doctor) checkWebhooks(ctx context.Context) []Result {
+	clientConfigs := map[string]admissionregistrationv1.WebhookClientConfig{}
+
+	mutatingConfigurations := &admissionregistrationv1.MutatingWebhookConfigurationList{}
+	if err := d.client.List(ctx, mutatingConfigurations); err != nil {
+		return []Result{failed("webhook configurations", err.Error(), "check that the kubeconfig can list mutating webhook configurations")}
+	}
+
+	for _, configuration := range mutatingConfigurations.Items {
+		for _, webhook := range configuration.Webhooks {
+			clientConfigs[webhook.Name] = webhook.ClientConfig
+		}
+	}
+
+	validatingConfigurations := &admissionregistrationv1.ValidatingWebhookConfigurationList{}
+	if err := d.client.List(ctx, validatingConfigurations); err != nil {
+		return []Result{failed("webhook configurations", err.Error(), "check that the kubeconfig can list validating webhook configurations")}
+	}
+
+	for _, configuration := range validatingConfigurations.Items {
+		for _, webhook := range configuration.Webhooks {
+			clientConfigs[webhook.Name] = webhook.ClientConfig
+		}
+	}
+
+	results := []Result{}
+
+	for _, name := range append(append([]string{}, expectedMutatingWebhooks...), expectedValidatingWebhooks...) {
+		check := "webhook " + name
+
+		clientConfig, ok := clientConfigs[name]
+		if !ok {
+			results = append(results, failed(check, "not found", installWebhooksHint))
+
+			continue
+		}
+
+		var namespace, serviceName string
+		if clientConfig.Service != nil {
+			namespace, serviceName = clientConfig.Service.Namespace, clientConfig.Service.Name
+		}
+
+		webhookResults := d.checkClientConfig(ctx, check, "webhook", clientConfig.CABundle, namespace, serviceName)
+		if len(webhookResults) == 0 {
+			webhookResults = []Result{passed(check)}
+		}
+
+		results = append(results, webhookResults...)
+	}
+
+	return results
+}
+
+// checkClientConfig checks that a webhook trusts a CA and that its service,
+// if any, exists.
+func (d *Doctor) checkClientConfig(ctx context.Context, check, webhook string, caBundle []byte, namespace, serviceName string) []Result {
+	results := []Result{}
+
+	if len(caBundle) == 0 {
+		results = append(results, failed(check, fmt.Sprintf("the %s has no caBundle, so the API server cannot call it", webhook), caBundleHint))
+	}
+
+	if serviceName == "" {
+		return results
+	}
+
+	err := d.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: serviceName}, &corev1.Service{})
+
+	switch {
+	case k8serrors.IsNotFound(err):
+		results = append(results, failed(check, fmt.Sprintf("service %s/%s of the %s not found", namespace, serviceName, webhook), "apply the eirini-webhooks service of the Helm chart"))
+	case err != nil:
+		results = append(results, failed(check, err.Error(), "check that the kubeconfig can get services"))
+	}
+
+	return results
+}
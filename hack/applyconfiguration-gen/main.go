@@ -0,0 +1,55 @@
+//go:build tools
+// +build tools
+
+// applyconfiguration-gen runs the applyconfiguration-gen of k8s.io/code-generator
+// with the OwnerReference and Condition apply configurations of client-go
+// added to its external types. Without them the generated ObjectMeta setters
+// do not build, and the --external-applyconfigurations flag of this version
+// cannot parse package paths with dots.
+package main
+
+import (
+	"flag"
+
+	"github.com/spf13/pflag"
+	"k8s.io/code-generator/cmd/applyconfiguration-gen/args"
+	"k8s.io/code-generator/cmd/applyconfiguration-gen/generators"
+	"k8s.io/code-generator/pkg/util"
+	"k8s.io/gengo/types"
+	"k8s.io/klog/v2"
+)
+
+const (
+	metaPackage             = "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metaApplyConfigsPackage = "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+func main() {
+	klog.InitFlags(nil)
+	genericArgs, customArgs := args.NewDefaults()
+	genericArgs.GoHeaderFilePath = util.BoilerplatePath()
+	genericArgs.AddFlags(pflag.CommandLine)
+	customArgs.AddFlags(pflag.CommandLine, "")
+
+	if err := flag.Set("logtostderr", "true"); err != nil {
+		klog.Fatalf("Error: %v", err)
+	}
+
+	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
+	pflag.Parse()
+
+	customArgs.ExternalApplyConfigurations[types.Name{Package: metaPackage, Name: "OwnerReference"}] = metaApplyConfigsPackage
+	customArgs.ExternalApplyConfigurations[types.Name{Package: metaPackage, Name: "Condition"}] = metaApplyConfigsPackage
+
+	if err := args.Validate(genericArgs); err != nil {
+		klog.Fatalf("Error: %v", err)
+	}
+
+	if err := genericArgs.Execute(
+		generators.NameSystems(),
+		generators.DefaultNameSystem(),
+		generators.Packages,
+	); err != nil {
+		klog.Fatalf("Error: %v", err)
+	}
+}
@@ -15,16 +15,41 @@ rm -rf "$EIRINI_CONTROLLER_ROOT/pkg/generated"
 go run sigs.k8s.io/controller-tools/cmd/controller-gen@v0.9.2 \
   object \
   object:headerFile="$EIRINI_CONTROLLER_ROOT/hack/boilerplate.go.txt" \
-  paths="$EIRINI_CONTROLLER_ROOT/pkg/apis/eirini/v1"
+  paths="$EIRINI_CONTROLLER_ROOT/pkg/apis/eirini/..."
+
+EIRINI_INPUT_PACKAGES="code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1,code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v2"
+EIRINI_GENERATED_PACKAGE="code.cloudfoundry.org/eirini-controller/pkg/generated"
+
+go run -tags tools "$EIRINI_CONTROLLER_ROOT/hack/applyconfiguration-gen" \
+  --input-dirs "$EIRINI_INPUT_PACKAGES" \
+  --go-header-file "${EIRINI_CONTROLLER_ROOT}/hack/boilerplate.go.txt" \
+  --output-base "$(dirname "${BASH_SOURCE[0]}")/.." \
+  --output-package "$EIRINI_GENERATED_PACKAGE/applyconfiguration"
 
 go run k8s.io/code-generator/cmd/client-gen \
   --clientset-name versioned \
   --input-base "" \
-  --input "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1" \
+  --input "$EIRINI_INPUT_PACKAGES" \
+  --apply-configuration-package "$EIRINI_GENERATED_PACKAGE/applyconfiguration" \
   --go-header-file "${EIRINI_CONTROLLER_ROOT}/hack/boilerplate.go.txt" \
   --output-base "$(dirname "${BASH_SOURCE[0]}")/.." \
-  --output-package "code.cloudfoundry.org/eirini-controller/pkg/generated/clientset" \
-  "$EIRINI_CONTROLLER_ROOT/pkg/apis/eirini/v1"
+  --output-package "$EIRINI_GENERATED_PACKAGE/clientset" \
+  "$EIRINI_CONTROLLER_ROOT/pkg/apis/eirini/v1" \
+  "$EIRINI_CONTROLLER_ROOT/pkg/apis/eirini/v2"
+
+go run k8s.io/code-generator/cmd/lister-gen \
+  --input-dirs "$EIRINI_INPUT_PACKAGES" \
+  --go-header-file "${EIRINI_CONTROLLER_ROOT}/hack/boilerplate.go.txt" \
+  --output-base "$(dirname "${BASH_SOURCE[0]}")/.." \
+  --output-package "$EIRINI_GENERATED_PACKAGE/listers"
+
+go run k8s.io/code-generator/cmd/informer-gen \
+  --input-dirs "$EIRINI_INPUT_PACKAGES" \
+  --versioned-clientset-package "$EIRINI_GENERATED_PACKAGE/clientset/versioned" \
+  --listers-package "$EIRINI_GENERATED_PACKAGE/listers" \
+  --go-header-file "${EIRINI_CONTROLLER_ROOT}/hack/boilerplate.go.txt" \
+  --output-base "$(dirname "${BASH_SOURCE[0]}")/.." \
+  --output-package "$EIRINI_GENERATED_PACKAGE/informers"
 
 cp -R "$EIRINI_CONTROLLER_ROOT"/code.cloudfoundry.org/eirini-controller/pkg/* "$EIRINI_CONTROLLER_ROOT"/pkg/
 
@@ -38,3 +63,9 @@ go run sigs.k8s.io/controller-tools/cmd/controller-gen@v0.9.2 \
   paths="$EIRINI_CONTROLLER_ROOT"/pkg/apis/...
 cp "$EIRINI_TMP_CRD/eirini.cloudfoundry.org_lrps.yaml" "$EIRINI_CONTROLLER_ROOT/deployment/helm/templates/core/lrp-crd.yml"
 cp "$EIRINI_TMP_CRD/eirini.cloudfoundry.org_tasks.yaml" "$EIRINI_CONTROLLER_ROOT/deployment/helm/templates/core/task-crd.yml"
+cp "$EIRINI_TMP_CRD/eirini.cloudfoundry.org_workloaddefaults.yaml" "$EIRINI_CONTROLLER_ROOT/deployment/helm/templates/core/workloaddefaults-crd.yml"
+
+# Both versions are served, the conversion webhook translates between them
+for crd in lrp-crd.yml task-crd.yml; do
+  sed -i "/^spec:$/r $EIRINI_CONTROLLER_ROOT/hack/crd-conversion.yml" "$EIRINI_CONTROLLER_ROOT/deployment/helm/templates/core/$crd"
+done
@@ -0,0 +1,72 @@
+package health
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+//counterfeiter:generate . CacheSyncWaiter
+
+// CacheSyncWaiter waits for the informer caches to sync, like the manager
+// cache does.
+type CacheSyncWaiter interface {
+	WaitForCacheSync(ctx context.Context) bool
+}
+
+// NewCacheSyncChecker fails while the informer caches are not synced, giving
+// them up to timeout per check.
+func NewCacheSyncChecker(cache CacheSyncWaiter, timeout time.Duration) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		if !cache.WaitForCacheSync(ctx) {
+			return errors.New("informer caches are not synced")
+		}
+
+		return nil
+	}
+}
+
+// NewCertificateChecker fails when the PEM certificate in certFile cannot be
+// read, or is not valid at the time of the check. The file is read on every
+// check, so that rotated certificates are picked up.
+func NewCertificateChecker(certFile string, clck clock.PassiveClock) healthz.Checker {
+	return func(_ *http.Request) error {
+		certPEM, err := os.ReadFile(filepath.Clean(certFile))
+		if err != nil {
+			return errors.Wrap(err, "failed to read certificate")
+		}
+
+		block, _ := pem.Decode(certPEM)
+		if block == nil {
+			return errors.Errorf("no PEM data in %s", certFile)
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse certificate")
+		}
+
+		now := clck.Now()
+
+		if now.Before(cert.NotBefore) {
+			return errors.Errorf("certificate is not valid before %s", cert.NotBefore)
+		}
+
+		if now.After(cert.NotAfter) {
+			return errors.Errorf("certificate expired at %s", cert.NotAfter)
+		}
+
+		return nil
+	}
+}
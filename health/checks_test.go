@@ -0,0 +1,138 @@
+package health_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/eirini-controller/health"
+	"code.cloudfoundry.org/eirini-controller/health/healthfakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	clock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+var _ = Describe("CacheSyncChecker", func() {
+	var (
+		cache   *healthfakes.FakeCacheSyncWaiter
+		checker healthz.Checker
+	)
+
+	BeforeEach(func() {
+		cache = new(healthfakes.FakeCacheSyncWaiter)
+		cache.WaitForCacheSyncReturns(true)
+		checker = health.NewCacheSyncChecker(cache, time.Second)
+	})
+
+	It("passes when the caches are synced", func() {
+		Expect(checker(httptest.NewRequest("GET", "/readyz", nil))).To(Succeed())
+	})
+
+	It("waits no longer than the timeout", func() {
+		Expect(checker(httptest.NewRequest("GET", "/readyz", nil))).To(Succeed())
+
+		ctx := cache.WaitForCacheSyncArgsForCall(0)
+		deadline, ok := ctx.Deadline()
+		Expect(ok).To(BeTrue())
+		Expect(deadline).To(BeTemporally("~", time.Now().Add(time.Second), time.Second))
+	})
+
+	When("the caches are not synced", func() {
+		BeforeEach(func() {
+			cache.WaitForCacheSyncStub = func(ctx context.Context) bool {
+				<-ctx.Done()
+
+				return false
+			}
+		})
+
+		It("fails", func() {
+			Expect(checker(httptest.NewRequest("GET", "/readyz", nil))).To(MatchError("informer caches are not synced"))
+		})
+	})
+})
+
+var _ = Describe("CertificateChecker", func() {
+	var (
+		certFile  string
+		notBefore time.Time
+		notAfter  time.Time
+		fakeClock *clock.FakePassiveClock
+		checker   healthz.Checker
+	)
+
+	BeforeEach(func() {
+		certFile = filepath.Join(GinkgoT().TempDir(), "tls.crt")
+		notBefore = time.Now().Add(-time.Hour)
+		notAfter = time.Now().Add(time.Hour)
+		fakeClock = clock.NewFakePassiveClock(time.Now())
+	})
+
+	JustBeforeEach(func() {
+		writeCertificate(certFile, notBefore, notAfter)
+		checker = health.NewCertificateChecker(certFile, fakeClock)
+	})
+
+	It("passes while the certificate is valid", func() {
+		Expect(checker(nil)).To(Succeed())
+	})
+
+	It("fails once the certificate expired", func() {
+		fakeClock.SetTime(notAfter.Add(time.Second))
+
+		Expect(checker(nil)).To(MatchError(ContainSubstring("certificate expired at")))
+	})
+
+	It("fails before the certificate is valid", func() {
+		fakeClock.SetTime(notBefore.Add(-time.Second))
+
+		Expect(checker(nil)).To(MatchError(ContainSubstring("certificate is not valid before")))
+	})
+
+	It("picks up rotated certificates", func() {
+		fakeClock.SetTime(notAfter.Add(time.Hour))
+		Expect(checker(nil)).NotTo(Succeed())
+
+		writeCertificate(certFile, notBefore, notAfter.Add(2*time.Hour))
+		Expect(checker(nil)).To(Succeed())
+	})
+
+	It("fails when the certificate is missing", func() {
+		Expect(os.Remove(certFile)).To(Succeed())
+
+		Expect(checker(nil)).To(MatchError(ContainSubstring("failed to read certificate")))
+	})
+
+	It("fails when the file has no certificate", func() {
+		Expect(os.WriteFile(certFile, []byte("not a certificate"), 0o600)).To(Succeed())
+
+		Expect(checker(nil)).To(MatchError(ContainSubstring("no PEM data")))
+	})
+})
+
+func writeCertificate(certFile string, notBefore, notAfter time.Time) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "eirini-controller"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+
+	Expect(os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600)).To(Succeed())
+}
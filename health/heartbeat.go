@@ -0,0 +1,130 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// HeartbeatRequestName is the name of the requests sent through the work
+// queue of a reconciler to prove it is alive. Workloads are namespaced, so
+// they never clash with it.
+const HeartbeatRequestName = "eirini-heartbeat"
+
+const (
+	DefaultHeartbeatInterval = 30 * time.Second
+	DefaultHeartbeatTimeout  = 5 * time.Minute
+)
+
+// Heartbeat checks that the workers of a reconciler keep completing
+// reconciles. It periodically enqueues a request, so that idle workers show
+// progress too, but any completed reconcile counts: a long queue, as on
+// startup, delays the heartbeat requests without failing the check. A
+// reconciler whose workers are all wedged fails it.
+//
+// The heartbeat runs with the controllers: it only starts on the leader, or
+// on every replica when they are sharded. Standby replicas pass the check.
+type Heartbeat struct {
+	name     string
+	clock    clock.WithTicker
+	interval time.Duration
+	timeout  time.Duration
+	events   chan event.GenericEvent
+
+	mutex        sync.Mutex
+	started      bool
+	lastProgress time.Time
+}
+
+func NewHeartbeat(name string, clck clock.WithTicker, interval, timeout time.Duration) *Heartbeat {
+	return &Heartbeat{
+		name:     name,
+		clock:    clck,
+		interval: interval,
+		timeout:  timeout,
+		events:   make(chan event.GenericEvent, 1),
+	}
+}
+
+// Start enqueues a heartbeat request every interval until the context is
+// done.
+func (h *Heartbeat) Start(ctx context.Context) error {
+	h.mutex.Lock()
+	h.started = true
+	h.lastProgress = h.clock.Now()
+	h.mutex.Unlock()
+
+	ticker := h.clock.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	for {
+		h.send()
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C():
+		}
+	}
+}
+
+// Source emits the heartbeat requests.
+func (h *Heartbeat) Source() source.Source {
+	return &source.Channel{Source: h.events}
+}
+
+// Decorate records the progress of the reconciler, which the heartbeat
+// requests are not passed on to.
+func (h *Heartbeat) Decorate(reconciler reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+		defer h.progress()
+
+		if request.Namespace != "" || request.Name != HeartbeatRequestName {
+			return reconciler.Reconcile(ctx, request)
+		}
+
+		return reconcile.Result{}, nil
+	})
+}
+
+// Check fails when no reconcile has completed within the timeout.
+func (h *Heartbeat) Check(_ *http.Request) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if !h.started {
+		return nil
+	}
+
+	if since := h.clock.Since(h.lastProgress); since > h.timeout {
+		return errors.Errorf("reconciler %q has not completed a reconcile for %s", h.name, since.Round(time.Second))
+	}
+
+	return nil
+}
+
+func (h *Heartbeat) progress() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.lastProgress = h.clock.Now()
+}
+
+// send does not block: a heartbeat the source has not consumed yet is as
+// good as a new one.
+func (h *Heartbeat) send() {
+	select {
+	case h.events <- event.GenericEvent{Object: &metav1.PartialObjectMetadata{
+		ObjectMeta: metav1.ObjectMeta{Name: HeartbeatRequestName},
+	}}:
+	default:
+	}
+}
@@ -0,0 +1,158 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/eirini-controller/health"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	clock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+)
+
+var _ = Describe("Heartbeat", func() {
+	var (
+		fakeClock *clock.FakeClock
+		heartbeat *health.Heartbeat
+		queue     workqueue.RateLimitingInterface
+		ctx       context.Context
+		cancel    context.CancelFunc
+	)
+
+	heartbeatRequest := reconcile.Request{NamespacedName: types.NamespacedName{Name: health.HeartbeatRequestName}}
+
+	BeforeEach(func() {
+		fakeClock = clock.NewFakeClock(time.Now())
+		heartbeat = health.NewHeartbeat("lrp", fakeClock, time.Minute, 5*time.Minute)
+
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+
+		queue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		DeferCleanup(queue.ShutDown)
+
+		source := heartbeat.Source()
+		Expect(inject.StopChannelInto(ctx.Done(), source)).To(BeTrue())
+		Expect(source.Start(ctx, &handler.EnqueueRequestForObject{}, queue)).To(Succeed())
+	})
+
+	It("passes before it is started", func() {
+		fakeClock.Step(time.Hour)
+
+		Expect(heartbeat.Check(nil)).To(Succeed())
+	})
+
+	When("started", func() {
+		var heartbeatDone chan struct{}
+
+		BeforeEach(func() {
+			heartbeatDone = make(chan struct{})
+
+			go func() {
+				defer GinkgoRecover()
+				defer close(heartbeatDone)
+
+				Expect(heartbeat.Start(ctx)).To(Succeed())
+			}()
+
+			DeferCleanup(func() {
+				cancel()
+				Eventually(heartbeatDone).Should(BeClosed())
+			})
+		})
+
+		nextRequest := func() interface{} {
+			Eventually(queue.Len).Should(Equal(1))
+			item, _ := queue.Get()
+			queue.Done(item)
+
+			return item
+		}
+
+		It("enqueues a heartbeat request every interval", func() {
+			Expect(nextRequest()).To(Equal(heartbeatRequest))
+
+			Eventually(fakeClock.HasWaiters).Should(BeTrue())
+			fakeClock.Step(time.Minute)
+
+			Expect(nextRequest()).To(Equal(heartbeatRequest))
+		})
+
+		It("passes until the timeout", func() {
+			Eventually(fakeClock.HasWaiters).Should(BeTrue())
+			fakeClock.Step(5 * time.Minute)
+
+			Expect(heartbeat.Check(nil)).To(Succeed())
+		})
+
+		It("fails when no reconcile completes within the timeout", func() {
+			Eventually(fakeClock.HasWaiters).Should(BeTrue())
+			fakeClock.Step(6 * time.Minute)
+
+			Expect(heartbeat.Check(nil)).To(MatchError(`reconciler "lrp" has not completed a reconcile for 6m0s`))
+		})
+
+		It("passes while other reconciles complete, as when the queue is long", func() {
+			Eventually(fakeClock.HasWaiters).Should(BeTrue())
+			decorated := heartbeat.Decorate(reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+				return reconcile.Result{}, nil
+			}))
+
+			for i := 0; i < 3; i++ {
+				fakeClock.Step(4 * time.Minute)
+				_, err := decorated.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "some-ns", Name: "some-lrp"}})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			Expect(heartbeat.Check(nil)).To(Succeed())
+		})
+
+		It("passes when the heartbeats are handled", func() {
+			Eventually(fakeClock.HasWaiters).Should(BeTrue())
+			fakeClock.Step(6 * time.Minute)
+
+			_, err := heartbeat.Decorate(nil).Reconcile(ctx, heartbeatRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(heartbeat.Check(nil)).To(Succeed())
+		})
+	})
+
+	Describe("Decorate", func() {
+		var (
+			requests  []reconcile.Request
+			decorated reconcile.Reconciler
+		)
+
+		BeforeEach(func() {
+			requests = nil
+			decorated = heartbeat.Decorate(reconcile.Func(func(_ context.Context, request reconcile.Request) (reconcile.Result, error) {
+				requests = append(requests, request)
+
+				return reconcile.Result{Requeue: true}, errors.New("boom")
+			}))
+		})
+
+		It("passes the other requests to the reconciler", func() {
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "some-ns", Name: health.HeartbeatRequestName}}
+
+			result, err := decorated.Reconcile(ctx, request)
+			Expect(err).To(MatchError("boom"))
+			Expect(result.Requeue).To(BeTrue())
+			Expect(requests).To(ConsistOf(request))
+		})
+
+		It("does not pass the heartbeat requests to the reconciler", func() {
+			result, err := decorated.Reconcile(ctx, heartbeatRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{}))
+			Expect(requests).To(BeEmpty())
+		})
+	})
+})
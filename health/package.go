@@ -0,0 +1,5 @@
+// Package health provides the checks served on the /healthz and /readyz
+// probe endpoints of the controller.
+package health
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
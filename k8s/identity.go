@@ -0,0 +1,337 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/k8s/utils"
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	LabelAppGUID = "korifi.cloudfoundry.org/app-guid"
+
+	// AnnotationManagedAnnotations lists the annotations of a per-app
+	// service account set from the workload identity config, so that the
+	// ones removed from the config are removed from the account too
+	AnnotationManagedAnnotations = "eirini.cloudfoundry.org/managed-annotations"
+
+	ServiceAccountTokenVolumeName = "eirini-service-account-token"
+	ServiceAccountTokenMountPath  = "/var/run/secrets/eirini.cloudfoundry.org/serviceaccount"
+)
+
+// App identifies the app an LRP or task belongs to, and the service account
+// it explicitly asked for, if any. ApplicationServiceAccount replaces the
+// configured shared service account when set, and must be allowed too.
+type App struct {
+	ServiceAccountName        string
+	ApplicationServiceAccount string
+	Namespace                 string
+	AppGUID                   string
+	AppName                   string
+	SpaceGUID                 string
+	SpaceName                 string
+	OrgGUID                   string
+	OrgName                   string
+}
+
+func LRPApp(lrp *eiriniv1.LRP) App {
+	return App{
+		ServiceAccountName: lrp.Spec.ServiceAccountName,
+		Namespace:          lrp.Namespace,
+		AppGUID:            lrp.Spec.AppGUID,
+		AppName:            lrp.Spec.AppName,
+		SpaceGUID:          lrp.Spec.SpaceGUID,
+		SpaceName:          lrp.Spec.SpaceName,
+		OrgGUID:            lrp.Spec.OrgGUID,
+		OrgName:            lrp.Spec.OrgName,
+	}
+}
+
+func TaskApp(task *eiriniv1.Task) App {
+	return App{
+		ServiceAccountName: task.Spec.ServiceAccountName,
+		Namespace:          task.Namespace,
+		AppGUID:            task.Spec.AppGUID,
+		AppName:            task.Spec.AppName,
+		SpaceGUID:          task.Spec.SpaceGUID,
+		SpaceName:          task.Spec.SpaceName,
+		OrgGUID:            task.Spec.OrgGUID,
+		OrgName:            task.Spec.OrgName,
+	}
+}
+
+// WorkloadIdentity decides which service account LRPs and tasks run as.
+// Apps may explicitly ask for one of the allowed service accounts. When
+// per-app service accounts are enabled, the others get a dedicated identity,
+// and dedicated identities get a projected token, even if service account
+// tokens are not automounted.
+type WorkloadIdentity struct {
+	applicationServiceAccount string
+	config                    eirinictrl.WorkloadIdentity
+	annotations               map[string]*template.Template
+}
+
+func NewWorkloadIdentity(applicationServiceAccount string, config eirinictrl.WorkloadIdentity) (*WorkloadIdentity, error) {
+	annotations := map[string]*template.Template{}
+
+	for key, value := range config.ServiceAccountAnnotations {
+		tmpl, err := ParseServiceAccountAnnotation(key, value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid template for service account annotation %q", key)
+		}
+
+		annotations[key] = tmpl
+	}
+
+	return &WorkloadIdentity{
+		applicationServiceAccount: applicationServiceAccount,
+		config:                    config,
+		annotations:               annotations,
+	}, nil
+}
+
+// ParseServiceAccountAnnotation parses the template of a service account
+// annotation. Templates referring to fields apps do not have are rejected
+// too, as they would only fail once rendered for an app.
+func ParseServiceAccountAnnotation(key, value string) (*template.Template, error) {
+	tmpl, err := template.New(key).Option("missingkey=error").Parse(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tmpl.Execute(io.Discard, App{}); err != nil {
+		return nil, err
+	}
+
+	return tmpl, nil
+}
+
+// ServiceAccountName returns the service account the app runs as, and
+// whether it is dedicated to the app rather than the shared one. Explicitly
+// requested service accounts, and the shared ones set by the namespace
+// workload defaults, must be allowed.
+func (w *WorkloadIdentity) ServiceAccountName(app App) (string, bool, error) {
+	if app.ServiceAccountName != "" {
+		if !w.IsAllowed(app.ServiceAccountName, app.AppGUID) {
+			return "", false, errors.Errorf("service account %q is not allowed", app.ServiceAccountName)
+		}
+
+		return app.ServiceAccountName, true, nil
+	}
+
+	if !w.config.PerAppServiceAccounts {
+		if app.ApplicationServiceAccount != "" {
+			if !w.IsAllowed(app.ApplicationServiceAccount, "") {
+				return "", false, errors.Errorf("service account %q of the namespace workload defaults is not allowed", app.ApplicationServiceAccount)
+			}
+
+			return app.ApplicationServiceAccount, false, nil
+		}
+
+		return w.applicationServiceAccount, false, nil
+	}
+
+	name, err := utils.GetAppServiceAccountName(app.AppGUID)
+
+	return name, true, err
+}
+
+// IsAllowed returns whether an app may explicitly ask for the named service
+// account: either one of the allowed service accounts, or the per-app
+// service account of the app itself when those are enabled. Other apps'
+// per-app service accounts and any other account of the namespace are not.
+func (w *WorkloadIdentity) IsAllowed(name, appGUID string) bool {
+	return IsServiceAccountAllowed(w.config, name, appGUID)
+}
+
+// IsServiceAccountAllowed is IsAllowed for the webhooks, which do not build
+// a WorkloadIdentity.
+func IsServiceAccountAllowed(config eirinictrl.WorkloadIdentity, name, appGUID string) bool {
+	for _, allowed := range config.AllowedServiceAccounts {
+		if name == allowed {
+			return true
+		}
+	}
+
+	if !config.PerAppServiceAccounts || appGUID == "" {
+		return false
+	}
+
+	appServiceAccount, err := utils.GetAppServiceAccountName(appGUID)
+
+	return err == nil && name == appServiceAccount
+}
+
+// Apply runs the pod as the service account of the app. When per-app
+// service accounts are enabled, dedicated service accounts get their token
+// projected into every container.
+func (w *WorkloadIdentity) Apply(podSpec *corev1.PodSpec, app App) error {
+	name, dedicated, err := w.ServiceAccountName(app)
+	if err != nil {
+		return err
+	}
+
+	podSpec.ServiceAccountName = name
+
+	if !dedicated || !w.config.PerAppServiceAccounts {
+		return nil
+	}
+
+	tokenProjection := &corev1.ServiceAccountTokenProjection{
+		Audience: w.config.TokenAudience,
+		Path:     "token",
+	}
+
+	if w.config.TokenExpirationSeconds > 0 {
+		expirationSeconds := w.config.TokenExpirationSeconds
+		tokenProjection.ExpirationSeconds = &expirationSeconds
+	}
+
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: ServiceAccountTokenVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{ServiceAccountToken: tokenProjection}},
+			},
+		},
+	})
+
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].VolumeMounts = append(podSpec.Containers[i].VolumeMounts, corev1.VolumeMount{
+			Name:      ServiceAccountTokenVolumeName,
+			MountPath: ServiceAccountTokenMountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	return nil
+}
+
+// EnsureServiceAccount creates the per-app service account of the app, or
+// brings its annotations up to date. Explicitly requested service accounts
+// are managed by their owners. Per-app service accounts are shared by all
+// the LRPs and tasks of the app, so each of them is added as an owner, and
+// the account is garbage collected once the last of them is deleted.
+func (w *WorkloadIdentity) EnsureServiceAccount(ctx context.Context, c client.Client, app App, owner client.Object, scheme *runtime.Scheme) error {
+	if app.ServiceAccountName != "" || !w.config.PerAppServiceAccounts {
+		return nil
+	}
+
+	name, err := utils.GetAppServiceAccountName(app.AppGUID)
+	if err != nil {
+		return err
+	}
+
+	annotations, err := w.renderAnnotations(app)
+	if err != nil {
+		return err
+	}
+
+	existing := &corev1.ServiceAccount{}
+
+	err = c.Get(ctx, client.ObjectKey{Namespace: app.Namespace, Name: name}, existing)
+	if k8serrors.IsNotFound(err) {
+		serviceAccount := &corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   app.Namespace,
+				Labels:      map[string]string{LabelAppGUID: app.AppGUID},
+				Annotations: withManagedAnnotations(nil, annotations),
+			},
+		}
+
+		if err = controllerutil.SetOwnerReference(owner, serviceAccount, scheme); err != nil {
+			return errors.Wrap(err, "failed to set service account owner reference")
+		}
+
+		err = c.Create(ctx, serviceAccount)
+		if k8serrors.IsAlreadyExists(err) {
+			return nil
+		}
+
+		return errors.Wrap(err, "failed to create service account")
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "failed to get service account")
+	}
+
+	updated := existing.DeepCopy()
+	updated.Annotations = withManagedAnnotations(updated.Annotations, annotations)
+
+	if err = controllerutil.SetOwnerReference(owner, updated, scheme); err != nil {
+		return errors.Wrap(err, "failed to set service account owner reference")
+	}
+
+	if equality.Semantic.DeepEqual(existing.Annotations, updated.Annotations) &&
+		equality.Semantic.DeepEqual(existing.OwnerReferences, updated.OwnerReferences) {
+		return nil
+	}
+
+	err = c.Patch(ctx, updated, client.MergeFrom(existing))
+
+	return errors.Wrap(err, "failed to patch service account")
+}
+
+// withManagedAnnotations replaces the annotations previously set from the
+// config with the current ones, leaving the others alone, and records which
+// ones are managed.
+func withManagedAnnotations(current, managed map[string]string) map[string]string {
+	annotations := map[string]string{}
+
+	for key, value := range current {
+		annotations[key] = value
+	}
+
+	for _, key := range strings.Split(annotations[AnnotationManagedAnnotations], ",") {
+		delete(annotations, key)
+	}
+
+	delete(annotations, AnnotationManagedAnnotations)
+
+	keys := make([]string, 0, len(managed))
+	for key, value := range managed {
+		annotations[key] = value
+		keys = append(keys, key)
+	}
+
+	if len(keys) > 0 {
+		sort.Strings(keys)
+		annotations[AnnotationManagedAnnotations] = strings.Join(keys, ",")
+	}
+
+	if len(annotations) == 0 {
+		return nil
+	}
+
+	return annotations
+}
+
+func (w *WorkloadIdentity) renderAnnotations(app App) (map[string]string, error) {
+	annotations := map[string]string{}
+
+	for key, tmpl := range w.annotations {
+		var value bytes.Buffer
+		if err := tmpl.Execute(&value, app); err != nil {
+			return nil, errors.Wrapf(err, "failed to render service account annotation %q", key)
+		}
+
+		annotations[key] = value.String()
+	}
+
+	return annotations, nil
+}
@@ -0,0 +1,272 @@
+package k8s_test
+
+import (
+	"context"
+	"errors"
+
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	. "code.cloudfoundry.org/eirini-controller/k8s"
+	"code.cloudfoundry.org/eirini-controller/k8s/k8sfakes"
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	eirinischeme "code.cloudfoundry.org/eirini-controller/pkg/generated/clientset/versioned/scheme"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("WorkloadIdentity", func() {
+	var (
+		config           eirinictrl.WorkloadIdentity
+		app              App
+		workloadIdentity *WorkloadIdentity
+	)
+
+	BeforeEach(func() {
+		config = eirinictrl.WorkloadIdentity{
+			ServiceAccountAnnotations: map[string]string{
+				"eks.amazonaws.com/role-arn": "arn:aws:iam::123456789012:role/{{ .SpaceGUID }}-{{ .AppName }}",
+			},
+		}
+		app = App{
+			Namespace: "the-namespace",
+			AppGUID:   "app-guid",
+			AppName:   "dora",
+			SpaceGUID: "space-guid",
+		}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+
+		workloadIdentity, err = NewWorkloadIdentity("eirini", config)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("rejects invalid annotation templates", func() {
+		config.ServiceAccountAnnotations["broken"] = "{{ .AppGUID"
+
+		_, err := NewWorkloadIdentity("eirini", config)
+		Expect(err).To(MatchError(ContainSubstring(`invalid template for service account annotation "broken"`)))
+	})
+
+	It("rejects annotation templates referring to unknown app fields", func() {
+		config.ServiceAccountAnnotations["unknown"] = "{{ .AppID }}"
+
+		_, err := NewWorkloadIdentity("eirini", config)
+		Expect(err).To(MatchError(ContainSubstring(`invalid template for service account annotation "unknown"`)))
+	})
+
+	Describe("Apply", func() {
+		var (
+			podSpec  *v1.PodSpec
+			applyErr error
+		)
+
+		BeforeEach(func() {
+			podSpec = &v1.PodSpec{Containers: []v1.Container{{Name: "opi"}}}
+		})
+
+		JustBeforeEach(func() {
+			applyErr = workloadIdentity.Apply(podSpec, app)
+		})
+
+		It("runs the pod as the application service account without a token", func() {
+			Expect(applyErr).NotTo(HaveOccurred())
+			Expect(podSpec.ServiceAccountName).To(Equal("eirini"))
+			Expect(podSpec.Volumes).To(BeEmpty())
+			Expect(podSpec.Containers[0].VolumeMounts).To(BeEmpty())
+		})
+
+		When("the app asks for an allowed service account", func() {
+			BeforeEach(func() {
+				config.AllowedServiceAccounts = []string{"my-identity"}
+				app.ServiceAccountName = "my-identity"
+			})
+
+			It("runs the pod as it, without a token as per-app service accounts are disabled", func() {
+				Expect(applyErr).NotTo(HaveOccurred())
+				Expect(podSpec.ServiceAccountName).To(Equal("my-identity"))
+				Expect(podSpec.Volumes).To(BeEmpty())
+			})
+		})
+
+		When("the app asks for a service account that is not allowed", func() {
+			BeforeEach(func() {
+				app.ServiceAccountName = "cluster-admin"
+			})
+
+			It("fails", func() {
+				Expect(applyErr).To(MatchError(ContainSubstring(`service account "cluster-admin" is not allowed`)))
+				Expect(podSpec.ServiceAccountName).To(BeEmpty())
+			})
+		})
+
+		When("the namespace workload defaults set an allowed service account", func() {
+			BeforeEach(func() {
+				config.AllowedServiceAccounts = []string{"space-identity"}
+				app.ApplicationServiceAccount = "space-identity"
+			})
+
+			It("runs the pod as it", func() {
+				Expect(applyErr).NotTo(HaveOccurred())
+				Expect(podSpec.ServiceAccountName).To(Equal("space-identity"))
+				Expect(podSpec.Volumes).To(BeEmpty())
+			})
+		})
+
+		When("the namespace workload defaults set a service account that is not allowed", func() {
+			BeforeEach(func() {
+				app.ApplicationServiceAccount = "cluster-admin"
+			})
+
+			It("fails", func() {
+				Expect(applyErr).To(MatchError(ContainSubstring(`service account "cluster-admin" of the namespace workload defaults is not allowed`)))
+				Expect(podSpec.ServiceAccountName).To(BeEmpty())
+			})
+		})
+
+		When("per-app service accounts are enabled", func() {
+			BeforeEach(func() {
+				config.PerAppServiceAccounts = true
+				config.TokenExpirationSeconds = 3600
+			})
+
+			It("runs the pod as the app service account with a projected token", func() {
+				Expect(applyErr).NotTo(HaveOccurred())
+				Expect(podSpec.ServiceAccountName).To(Equal("eirini-app-app-guid"))
+				Expect(podSpec.Volumes).To(HaveLen(1))
+				Expect(podSpec.Volumes[0].Projected.Sources[0].ServiceAccountToken.ExpirationSeconds).To(PointTo(BeEquivalentTo(3600)))
+				Expect(podSpec.Containers[0].VolumeMounts).To(ConsistOf(v1.VolumeMount{
+					Name:      ServiceAccountTokenVolumeName,
+					MountPath: ServiceAccountTokenMountPath,
+					ReadOnly:  true,
+				}))
+			})
+
+			When("the app asks for another app's service account", func() {
+				BeforeEach(func() {
+					app.ServiceAccountName = "eirini-app-other-app-guid"
+				})
+
+				It("fails", func() {
+					Expect(applyErr).To(MatchError(ContainSubstring("is not allowed")))
+				})
+			})
+
+			When("the app asks for its own per-app service account", func() {
+				BeforeEach(func() {
+					app.ServiceAccountName = "eirini-app-app-guid"
+				})
+
+				It("runs the pod as it with a projected token", func() {
+					Expect(applyErr).NotTo(HaveOccurred())
+					Expect(podSpec.ServiceAccountName).To(Equal("eirini-app-app-guid"))
+					Expect(podSpec.Volumes).To(HaveLen(1))
+				})
+			})
+		})
+	})
+
+	Describe("EnsureServiceAccount", func() {
+		var (
+			fakeClient *k8sfakes.FakeClient
+			owner      *eiriniv1.LRP
+			ensureErr  error
+		)
+
+		BeforeEach(func() {
+			config.PerAppServiceAccounts = true
+			fakeClient = new(k8sfakes.FakeClient)
+			fakeClient.GetReturns(k8serrors.NewNotFound(schema.GroupResource{Resource: "serviceaccounts"}, "eirini-app-app-guid"))
+			owner = &eiriniv1.LRP{ObjectMeta: metav1.ObjectMeta{Name: "the-lrp", Namespace: "the-namespace", UID: "lrp-uid"}}
+		})
+
+		JustBeforeEach(func() {
+			ensureErr = workloadIdentity.EnsureServiceAccount(context.Background(), fakeClient, app, owner, eirinischeme.Scheme)
+		})
+
+		It("creates the app service account with the rendered annotations", func() {
+			Expect(ensureErr).NotTo(HaveOccurred())
+			Expect(fakeClient.CreateCallCount()).To(Equal(1))
+
+			_, obj, _ := fakeClient.CreateArgsForCall(0)
+			serviceAccount := obj.(*v1.ServiceAccount)
+			Expect(serviceAccount.Name).To(Equal("eirini-app-app-guid"))
+			Expect(serviceAccount.Namespace).To(Equal("the-namespace"))
+			Expect(serviceAccount.Labels).To(HaveKeyWithValue(LabelAppGUID, "app-guid"))
+			Expect(serviceAccount.Annotations).To(HaveKeyWithValue("eks.amazonaws.com/role-arn", "arn:aws:iam::123456789012:role/space-guid-dora"))
+			Expect(serviceAccount.Annotations).To(HaveKeyWithValue(AnnotationManagedAnnotations, "eks.amazonaws.com/role-arn"))
+		})
+
+		It("is owned by the workload, so that it is deleted with the last one", func() {
+			_, obj, _ := fakeClient.CreateArgsForCall(0)
+			Expect(obj.GetOwnerReferences()).To(ConsistOf(HaveField("UID", BeEquivalentTo("lrp-uid"))))
+			Expect(obj.GetOwnerReferences()[0].Controller).To(BeNil())
+		})
+
+		When("the service account already exists", func() {
+			BeforeEach(func() {
+				fakeClient.GetStub = func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+					obj.(*v1.ServiceAccount).Annotations = map[string]string{
+						"eks.amazonaws.com/role-arn":      "outdated",
+						"iam.gke.io/gcp-service-account":  "removed-from-config",
+						"kubernetes.io/enforce-mountable": "true",
+						AnnotationManagedAnnotations:      "eks.amazonaws.com/role-arn,iam.gke.io/gcp-service-account",
+					}
+					obj.(*v1.ServiceAccount).Namespace = "the-namespace"
+					obj.(*v1.ServiceAccount).OwnerReferences = []metav1.OwnerReference{{Kind: "Task", Name: "the-task", UID: "task-uid"}}
+
+					return nil
+				}
+			})
+
+			It("replaces the annotations set from the config, keeping the others", func() {
+				Expect(ensureErr).NotTo(HaveOccurred())
+				Expect(fakeClient.CreateCallCount()).To(BeZero())
+				Expect(fakeClient.PatchCallCount()).To(Equal(1))
+
+				_, obj, _, _ := fakeClient.PatchArgsForCall(0)
+				Expect(obj.GetAnnotations()).To(Equal(map[string]string{
+					"eks.amazonaws.com/role-arn":      "arn:aws:iam::123456789012:role/space-guid-dora",
+					"kubernetes.io/enforce-mountable": "true",
+					AnnotationManagedAnnotations:      "eks.amazonaws.com/role-arn",
+				}))
+			})
+
+			It("adds the workload to its owners", func() {
+				_, obj, _, _ := fakeClient.PatchArgsForCall(0)
+				Expect(obj.GetOwnerReferences()).To(ConsistOf(
+					HaveField("UID", BeEquivalentTo("task-uid")),
+					HaveField("UID", BeEquivalentTo("lrp-uid")),
+				))
+			})
+		})
+
+		When("getting the service account fails", func() {
+			BeforeEach(func() {
+				fakeClient.GetReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				Expect(ensureErr).To(MatchError(ContainSubstring("boom")))
+			})
+		})
+
+		When("the app asks for its own service account", func() {
+			BeforeEach(func() {
+				app.ServiceAccountName = "my-identity"
+			})
+
+			It("leaves it to its owner", func() {
+				Expect(ensureErr).NotTo(HaveOccurred())
+				Expect(fakeClient.GetCallCount()).To(BeZero())
+				Expect(fakeClient.CreateCallCount()).To(BeZero())
+			})
+		})
+	})
+})
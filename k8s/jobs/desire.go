@@ -3,7 +3,9 @@ package jobs
 import (
 	"context"
 
+	"code.cloudfoundry.org/eirini-controller/k8s"
 	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"code.cloudfoundry.org/eirini-controller/tracing"
 	"code.cloudfoundry.org/lager"
 	"github.com/pkg/errors"
 	batchv1 "k8s.io/api/batch/v1"
@@ -19,7 +21,7 @@ import (
 //counterfeiter:generate . SecretsClient
 
 type TaskToJobConverter interface {
-	Convert(*eiriniv1.Task) *batchv1.Job
+	Convert(*eiriniv1.Task, *eiriniv1.WorkloadDefaultsSpec) (*batchv1.Job, error)
 }
 
 type JobCreator interface {
@@ -35,6 +37,7 @@ type SecretsClient interface {
 type Desirer struct {
 	logger             lager.Logger
 	taskToJobConverter TaskToJobConverter
+	workloadIdentity   *k8s.WorkloadIdentity
 	client             client.Client
 	scheme             *runtime.Scheme
 }
@@ -42,21 +45,47 @@ type Desirer struct {
 func NewDesirer(
 	logger lager.Logger,
 	taskToJobConverter TaskToJobConverter,
+	workloadIdentity *k8s.WorkloadIdentity,
 	client client.Client,
 	scheme *runtime.Scheme,
 ) *Desirer {
 	return &Desirer{
 		logger:             logger,
 		taskToJobConverter: taskToJobConverter,
+		workloadIdentity:   workloadIdentity,
 		client:             client,
 		scheme:             scheme,
 	}
 }
 
 func (d *Desirer) Desire(ctx context.Context, task *eiriniv1.Task) (*batchv1.Job, error) {
+	ctx, span := tracing.Start(ctx, "jobs.Desirer.Desire", task)
+	job, err := d.desire(ctx, task)
+	tracing.End(span, err)
+
+	return job, err
+}
+
+func (d *Desirer) desire(ctx context.Context, task *eiriniv1.Task) (*batchv1.Job, error) {
 	logger := d.logger.Session("desire-task", lager.Data{"guid": task.Spec.GUID, "name": task.Name, "namespace": task.Namespace})
 
-	job := d.taskToJobConverter.Convert(task)
+	if err := d.workloadIdentity.EnsureServiceAccount(ctx, d.client, k8s.TaskApp(task), task, d.scheme); err != nil {
+		logger.Error("failed-to-ensure-service-account", err)
+
+		return nil, errors.Wrap(err, "failed to ensure service account")
+	}
+
+	defaults, err := k8s.GetWorkloadDefaults(ctx, d.client, task.Namespace)
+	if err != nil {
+		logger.Error("failed-to-get-workload-defaults", err)
+
+		return nil, err
+	}
+
+	job, err := d.taskToJobConverter.Convert(task, defaults)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert task to job")
+	}
 
 	job.Namespace = task.Namespace
 
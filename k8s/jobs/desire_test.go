@@ -1,6 +1,8 @@
 package jobs_test
 
 import (
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/k8s"
 	"code.cloudfoundry.org/eirini-controller/k8s/jobs"
 	"code.cloudfoundry.org/eirini-controller/k8s/jobs/jobsfakes"
 	"code.cloudfoundry.org/eirini-controller/k8s/k8sfakes"
@@ -13,6 +15,7 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("Desire", func() {
@@ -43,7 +46,7 @@ var _ = Describe("Desire", func() {
 
 		client = new(k8sfakes.FakeClient)
 		taskToJobConverter = new(jobsfakes.FakeTaskToJobConverter)
-		taskToJobConverter.ConvertReturns(job)
+		taskToJobConverter.ConvertReturns(job, nil)
 
 		task = &eiriniv1.Task{
 			ObjectMeta: metav1.ObjectMeta{
@@ -69,9 +72,13 @@ var _ = Describe("Desire", func() {
 			},
 		}
 
+		workloadIdentity, err := k8s.NewWorkloadIdentity("eirini", eirinictrl.WorkloadIdentity{})
+		Expect(err).NotTo(HaveOccurred())
+
 		desirer = jobs.NewDesirer(
 			tests.NewTestLogger("desiretask"),
 			taskToJobConverter,
+			workloadIdentity,
 			client,
 			eirinischeme.Scheme,
 		)
@@ -102,9 +109,40 @@ var _ = Describe("Desire", func() {
 		})
 	})
 
+	When("converting the task fails", func() {
+		BeforeEach(func() {
+			taskToJobConverter.ConvertReturns(nil, errors.New("convert-failed"))
+		})
+
+		It("returns an error without creating the job", func() {
+			Expect(desireErr).To(MatchError(ContainSubstring("convert-failed")))
+			Expect(client.CreateCallCount()).To(BeZero())
+		})
+	})
+
 	It("converts the task to job", func() {
 		Expect(taskToJobConverter.ConvertCallCount()).To(Equal(1))
-		Expect(taskToJobConverter.ConvertArgsForCall(0)).To(Equal(task))
+		actualTask, defaults := taskToJobConverter.ConvertArgsForCall(0)
+		Expect(actualTask).To(Equal(task))
+		Expect(defaults).To(Equal(&eiriniv1.WorkloadDefaultsSpec{}))
+	})
+
+	It("gets the workload defaults of the task namespace", func() {
+		Expect(client.GetCallCount()).To(Equal(1))
+		_, key, obj := client.GetArgsForCall(0)
+		Expect(key).To(Equal(ctrlclient.ObjectKey{Namespace: "app-namespace", Name: eiriniv1.WorkloadDefaultsName}))
+		Expect(obj).To(BeAssignableToTypeOf(&eiriniv1.WorkloadDefaults{}))
+	})
+
+	When("getting the workload defaults fails", func() {
+		BeforeEach(func() {
+			client.GetReturns(errors.New("get-failed"))
+		})
+
+		It("returns an error without creating the job", func() {
+			Expect(desireErr).To(MatchError(ContainSubstring("get-failed")))
+			Expect(client.CreateCallCount()).To(BeZero())
+		})
 	})
 
 	It("sets the job namespace", func() {
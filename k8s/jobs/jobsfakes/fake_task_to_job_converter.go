@@ -10,38 +10,42 @@ import (
 )
 
 type FakeTaskToJobConverter struct {
-	ConvertStub        func(*v1a.Task) *v1.Job
+	ConvertStub        func(*v1a.Task, *v1a.WorkloadDefaultsSpec) (*v1.Job, error)
 	convertMutex       sync.RWMutex
 	convertArgsForCall []struct {
 		arg1 *v1a.Task
+		arg2 *v1a.WorkloadDefaultsSpec
 	}
 	convertReturns struct {
 		result1 *v1.Job
+		result2 error
 	}
 	convertReturnsOnCall map[int]struct {
 		result1 *v1.Job
+		result2 error
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeTaskToJobConverter) Convert(arg1 *v1a.Task) *v1.Job {
+func (fake *FakeTaskToJobConverter) Convert(arg1 *v1a.Task, arg2 *v1a.WorkloadDefaultsSpec) (*v1.Job, error) {
 	fake.convertMutex.Lock()
 	ret, specificReturn := fake.convertReturnsOnCall[len(fake.convertArgsForCall)]
 	fake.convertArgsForCall = append(fake.convertArgsForCall, struct {
 		arg1 *v1a.Task
-	}{arg1})
+		arg2 *v1a.WorkloadDefaultsSpec
+	}{arg1, arg2})
 	stub := fake.ConvertStub
 	fakeReturns := fake.convertReturns
-	fake.recordInvocation("Convert", []interface{}{arg1})
+	fake.recordInvocation("Convert", []interface{}{arg1, arg2})
 	fake.convertMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fakeReturns.result1
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *FakeTaskToJobConverter) ConvertCallCount() int {
@@ -50,40 +54,43 @@ func (fake *FakeTaskToJobConverter) ConvertCallCount() int {
 	return len(fake.convertArgsForCall)
 }
 
-func (fake *FakeTaskToJobConverter) ConvertCalls(stub func(*v1a.Task) *v1.Job) {
+func (fake *FakeTaskToJobConverter) ConvertCalls(stub func(*v1a.Task, *v1a.WorkloadDefaultsSpec) (*v1.Job, error)) {
 	fake.convertMutex.Lock()
 	defer fake.convertMutex.Unlock()
 	fake.ConvertStub = stub
 }
 
-func (fake *FakeTaskToJobConverter) ConvertArgsForCall(i int) *v1a.Task {
+func (fake *FakeTaskToJobConverter) ConvertArgsForCall(i int) (*v1a.Task, *v1a.WorkloadDefaultsSpec) {
 	fake.convertMutex.RLock()
 	defer fake.convertMutex.RUnlock()
 	argsForCall := fake.convertArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeTaskToJobConverter) ConvertReturns(result1 *v1.Job) {
+func (fake *FakeTaskToJobConverter) ConvertReturns(result1 *v1.Job, result2 error) {
 	fake.convertMutex.Lock()
 	defer fake.convertMutex.Unlock()
 	fake.ConvertStub = nil
 	fake.convertReturns = struct {
 		result1 *v1.Job
-	}{result1}
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeTaskToJobConverter) ConvertReturnsOnCall(i int, result1 *v1.Job) {
+func (fake *FakeTaskToJobConverter) ConvertReturnsOnCall(i int, result1 *v1.Job, result2 error) {
 	fake.convertMutex.Lock()
 	defer fake.convertMutex.Unlock()
 	fake.ConvertStub = nil
 	if fake.convertReturnsOnCall == nil {
 		fake.convertReturnsOnCall = make(map[int]struct {
 			result1 *v1.Job
+			result2 error
 		})
 	}
 	fake.convertReturnsOnCall[i] = struct {
 		result1 *v1.Job
-	}{result1}
+		result2 error
+	}{result1, result2}
 }
 
 func (fake *FakeTaskToJobConverter) Invocations() map[string][][]interface{} {
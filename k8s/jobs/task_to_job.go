@@ -5,6 +5,7 @@ import (
 	"code.cloudfoundry.org/eirini-controller/k8s"
 	"code.cloudfoundry.org/eirini-controller/k8s/utils"
 	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"github.com/pkg/errors"
 	batch "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -17,26 +18,37 @@ const (
 )
 
 type Converter struct {
-	serviceAccountName                string
+	workloadIdentity                  *k8s.WorkloadIdentity
 	registrySecretName                string
 	allowAutomountServiceAccountToken bool
+	placementTags                     map[string]eirinictrl.PlacementTag
+	securityPolicy                    *k8s.SecurityPolicy
 }
 
 func NewTaskToJobConverter(
-	serviceAccountName string,
+	workloadIdentity *k8s.WorkloadIdentity,
 	registrySecretName string,
 	allowAutomountServiceAccountToken bool,
+	placementTags map[string]eirinictrl.PlacementTag,
+	securityPolicy *k8s.SecurityPolicy,
 ) *Converter {
 	return &Converter{
-		serviceAccountName:                serviceAccountName,
+		workloadIdentity:                  workloadIdentity,
 		registrySecretName:                registrySecretName,
 		allowAutomountServiceAccountToken: allowAutomountServiceAccountToken,
+		placementTags:                     placementTags,
+		securityPolicy:                    securityPolicy,
 	}
 }
 
-func (m *Converter) Convert(task *eiriniv1.Task) *batch.Job {
-	job := m.toJob(task)
-	job.Spec.Template.Spec.ServiceAccountName = m.serviceAccountName
+func (m *Converter) Convert(task *eiriniv1.Task, defaults *eiriniv1.WorkloadDefaultsSpec) (*batch.Job, error) {
+	if defaults == nil {
+		defaults = &eiriniv1.WorkloadDefaultsSpec{}
+	}
+
+	task = k8s.ApplyTaskDefaults(task, defaults)
+
+	job := m.toJob(task, defaults)
 	job.Labels[LabelSourceType] = TaskSourceType
 	job.Labels[LabelName] = task.Spec.Name
 	job.Spec.Template.Annotations[AnnotationGUID] = task.Spec.GUID
@@ -62,19 +74,32 @@ func (m *Converter) Convert(task *eiriniv1.Task) *batch.Job {
 					corev1.ResourceCPU:              *resource.NewScaledQuantity(task.Spec.CPUMillis, resource.Milli),
 				},
 			},
-			SecurityContext: k8s.ContainerSecurityContext(),
 		},
 	}
 
-	job.Spec.Template.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: m.registrySecretName}}
+	job.Spec.Template.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: k8s.RegistrySecretName(m.registrySecretName, defaults)}}
 	job.Spec.Template.Spec.ImagePullSecrets = append(job.Spec.Template.Spec.ImagePullSecrets, task.Spec.ImagePullSecrets...)
 
 	job.Spec.Template.Spec.Containers = containers
 
-	return job
+	if err := k8s.ApplyPlacementTags(&job.Spec.Template.Spec, task.Spec.PlacementTags, m.placementTags); err != nil {
+		return nil, errors.Wrap(err, "failed to apply the placement tags")
+	}
+
+	k8s.ApplyPodMetadataDefaults(&job.Spec.Template, defaults)
+	m.securityPolicy.Apply(task.Namespace, &job.Spec.Template, defaults.SecurityProfile)
+
+	app := k8s.TaskApp(task)
+	app.ApplicationServiceAccount = defaults.ApplicationServiceAccount
+
+	if err := m.workloadIdentity.Apply(&job.Spec.Template.Spec, app); err != nil {
+		return nil, errors.Wrap(err, "failed to set the service account")
+	}
+
+	return job, nil
 }
 
-func (m *Converter) toJob(task *eiriniv1.Task) *batch.Job {
+func (m *Converter) toJob(task *eiriniv1.Task, defaults *eiriniv1.WorkloadDefaultsSpec) *batch.Job {
 	job := &batch.Job{
 		Spec: batch.JobSpec{
 			Parallelism:  int32ptr(parallelism),
@@ -88,7 +113,7 @@ func (m *Converter) toJob(task *eiriniv1.Task) *batch.Job {
 		},
 	}
 
-	if !m.allowAutomountServiceAccountToken {
+	if !k8s.AllowAutomountServiceAccountToken(m.allowAutomountServiceAccountToken, defaults) {
 		automountServiceAccountToken := false
 		job.Spec.Template.Spec.AutomountServiceAccountToken = &automountServiceAccountToken
 	}
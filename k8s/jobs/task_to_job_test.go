@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/k8s"
 	"code.cloudfoundry.org/eirini-controller/k8s/jobs"
 	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
 	. "github.com/onsi/ginkgo/v2"
@@ -26,6 +27,10 @@ var _ = Describe("TaskToJob", func() {
 		job                               *batch.Job
 		task                              *eiriniv1.Task
 		allowAutomountServiceAccountToken bool
+		placementTags                     map[string]eirinictrl.PlacementTag
+		securityProfile                   eirinictrl.SecurityProfile
+		identityConfig                    eirinictrl.WorkloadIdentity
+		defaults                          *eiriniv1.WorkloadDefaultsSpec
 	)
 
 	assertGeneralSpec := func(job *batch.Job) {
@@ -53,6 +58,17 @@ var _ = Describe("TaskToJob", func() {
 
 	BeforeEach(func() {
 		allowAutomountServiceAccountToken = false
+		securityProfile = eirinictrl.SecurityProfile{}
+		identityConfig = eirinictrl.WorkloadIdentity{}
+		defaults = &eiriniv1.WorkloadDefaultsSpec{}
+		placementTags = map[string]eirinictrl.PlacementTag{
+			"isolated": {
+				NodeSelector: map[string]string{"segment": "isolated"},
+				Tolerations: []eirinictrl.Toleration{
+					{Key: "segment", Operator: "Exists", Effect: "NoSchedule"},
+				},
+			},
+		}
 
 		task = &eiriniv1.Task{
 			Spec: eiriniv1.TaskSpec{
@@ -77,7 +93,14 @@ var _ = Describe("TaskToJob", func() {
 	})
 
 	JustBeforeEach(func() {
-		job = jobs.NewTaskToJobConverter(serviceAccount, registrySecret, allowAutomountServiceAccountToken).Convert(task)
+		securityPolicy, err := k8s.NewSecurityPolicy(securityProfile, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		workloadIdentity, err := k8s.NewWorkloadIdentity(serviceAccount, identityConfig)
+		Expect(err).NotTo(HaveOccurred())
+
+		job, err = jobs.NewTaskToJobConverter(workloadIdentity, registrySecret, allowAutomountServiceAccountToken, placementTags, securityPolicy).Convert(task, defaults)
+		Expect(err).NotTo(HaveOccurred())
 	})
 
 	It("returns a job for the task with the correct attributes", func() {
@@ -195,6 +218,113 @@ var _ = Describe("TaskToJob", func() {
 		})
 	})
 
+	When("the task asks for its own service account", func() {
+		BeforeEach(func() {
+			task.Spec.ServiceAccountName = "my-task-identity"
+			identityConfig.PerAppServiceAccounts = true
+			identityConfig.AllowedServiceAccounts = []string{"my-task-identity"}
+			identityConfig.TokenAudience = "sts.amazonaws.com"
+		})
+
+		It("runs as that service account with a projected token", func() {
+			podSpec := job.Spec.Template.Spec
+			Expect(podSpec.ServiceAccountName).To(Equal("my-task-identity"))
+			Expect(podSpec.AutomountServiceAccountToken).To(PointTo(BeFalse()))
+			Expect(podSpec.Volumes).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Name": Equal(k8s.ServiceAccountTokenVolumeName),
+				"VolumeSource": MatchFields(IgnoreExtras, Fields{
+					"Projected": PointTo(MatchFields(IgnoreExtras, Fields{
+						"Sources": ConsistOf(corev1.VolumeProjection{
+							ServiceAccountToken: &corev1.ServiceAccountTokenProjection{Audience: "sts.amazonaws.com", Path: "token"},
+						}),
+					})),
+				}),
+			})))
+			Expect(podSpec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+				Name:      k8s.ServiceAccountTokenVolumeName,
+				MountPath: k8s.ServiceAccountTokenMountPath,
+				ReadOnly:  true,
+			}))
+		})
+	})
+
+	When("a security profile is configured", func() {
+		BeforeEach(func() {
+			uid := int64(2000)
+			readOnly := true
+			securityProfile = eirinictrl.SecurityProfile{
+				RunAsUser:              &uid,
+				ReadOnlyRootFilesystem: &readOnly,
+				AppArmorProfile:        "runtime/default",
+			}
+		})
+
+		It("applies it to the task pod", func() {
+			Expect(job.Spec.Template.Spec.SecurityContext.RunAsUser).To(PointTo(BeEquivalentTo(2000)))
+
+			container := job.Spec.Template.Spec.Containers[0]
+			Expect(container.SecurityContext.ReadOnlyRootFilesystem).To(PointTo(BeTrue()))
+			Expect(container.VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: "eirini-tmp", MountPath: "/tmp"}))
+			Expect(job.Spec.Template.Annotations).To(HaveKeyWithValue(
+				corev1.AppArmorBetaContainerAnnotationKeyPrefix+container.Name, "runtime/default",
+			))
+			Expect(job.Annotations).NotTo(HaveKey(HavePrefix(corev1.AppArmorBetaContainerAnnotationKeyPrefix)))
+		})
+	})
+
+	When("the task has placement tags", func() {
+		BeforeEach(func() {
+			task.Spec.PlacementTags = []string{"isolated"}
+		})
+
+		It("schedules the job on the nodes of the isolation segment", func() {
+			Expect(job.Spec.Template.Spec.NodeSelector).To(Equal(map[string]string{"segment": "isolated"}))
+			Expect(job.Spec.Template.Spec.Tolerations).To(ConsistOf(corev1.Toleration{
+				Key:      "segment",
+				Operator: corev1.TolerationOpExists,
+				Effect:   corev1.TaintEffectNoSchedule,
+			}))
+		})
+	})
+
+	When("the namespace has workload defaults", func() {
+		BeforeEach(func() {
+			identityConfig.AllowedServiceAccounts = []string{"namespace-account"}
+			allowAutomount := true
+			defaults = &eiriniv1.WorkloadDefaultsSpec{
+				ApplicationServiceAccount:         "namespace-account",
+				RegistrySecretName:                "namespace-registry-secret",
+				AllowAutomountServiceAccountToken: &allowAutomount,
+				PlacementTags:                     []string{"isolated"},
+				SecurityProfile:                   &eiriniv1.SecurityProfile{RunAsUser: int64Ptr(3000)},
+				Resources:                         &eiriniv1.ResourceDefaults{DiskMB: 512},
+				Labels:                            map[string]string{"team": "payments", jobs.LabelGUID: "overridden"},
+			}
+			task.Spec.DiskMB = 0
+		})
+
+		It("uses them instead of the controller configuration", func() {
+			podSpec := job.Spec.Template.Spec
+			Expect(podSpec.ServiceAccountName).To(Equal("namespace-account"))
+			Expect(podSpec.ImagePullSecrets).To(ConsistOf(corev1.LocalObjectReference{Name: "namespace-registry-secret"}))
+			Expect(podSpec.AutomountServiceAccountToken).To(BeNil())
+			Expect(podSpec.NodeSelector).To(Equal(map[string]string{"segment": "isolated"}))
+			Expect(podSpec.SecurityContext.RunAsUser).To(PointTo(BeEquivalentTo(3000)))
+			Expect(podSpec.Containers[0].Resources.Limits.StorageEphemeral().ScaledValue(resource.Mega)).To(BeEquivalentTo(512))
+		})
+
+		It("adds the default labels without replacing the controller ones", func() {
+			Expect(job.Spec.Template.Labels).To(HaveKeyWithValue("team", "payments"))
+			Expect(job.Spec.Template.Labels).To(HaveKeyWithValue(jobs.LabelGUID, taskGUID))
+			Expect(job.Labels).NotTo(HaveKey("team"))
+		})
+
+		It("does not change the task", func() {
+			Expect(task.Spec.DiskMB).To(BeZero())
+			Expect(task.Spec.PlacementTags).To(BeEmpty())
+		})
+	})
+
 	When("allowAutomountServiceAccountToken is true", func() {
 		BeforeEach(func() {
 			allowAutomountServiceAccountToken = true
@@ -240,3 +370,7 @@ var _ = Describe("TaskToJob", func() {
 		})
 	})
 })
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
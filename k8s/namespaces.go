@@ -0,0 +1,81 @@
+package k8s
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NamespaceFilter tells whether the controller serves the workloads of a
+// namespace. Namespaces are matched against an explicit list and a label
+// selector, each only when set, so that labelling a namespace is enough to
+// bring its workloads under the controller.
+type NamespaceFilter struct {
+	client     client.Reader
+	namespaces map[string]bool
+	selector   labels.Selector
+}
+
+func NewNamespaceFilter(client client.Reader, namespaces []string, selector string) (*NamespaceFilter, error) {
+	filter := &NamespaceFilter{client: client}
+
+	if len(namespaces) > 0 {
+		filter.namespaces = map[string]bool{}
+
+		for _, namespace := range namespaces {
+			filter.namespaces[namespace] = true
+		}
+	}
+
+	if selector != "" {
+		parsedSelector, err := labels.Parse(selector)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid namespace selector %q", selector)
+		}
+
+		filter.selector = parsedSelector
+	}
+
+	return filter, nil
+}
+
+// Selective tells whether the filter needs to look at namespace labels.
+func (f *NamespaceFilter) Selective() bool {
+	return f.selector != nil
+}
+
+func (f *NamespaceFilter) Includes(ctx context.Context, namespace string) (bool, error) {
+	if f.namespaces != nil && !f.namespaces[namespace] {
+		return false, nil
+	}
+
+	if f.selector == nil {
+		return true, nil
+	}
+
+	ns := &corev1.Namespace{}
+
+	err := f.client.Get(ctx, client.ObjectKey{Name: namespace}, ns)
+	if k8serrors.IsNotFound(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get namespace")
+	}
+
+	return f.selector.Matches(labels.Set(ns.Labels)), nil
+}
+
+// IncludesNamespace is Includes for a namespace already at hand.
+func (f *NamespaceFilter) IncludesNamespace(namespace *corev1.Namespace) bool {
+	if f.namespaces != nil && !f.namespaces[namespace.Name] {
+		return false
+	}
+
+	return f.selector == nil || f.selector.Matches(labels.Set(namespace.Labels))
+}
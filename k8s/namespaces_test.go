@@ -0,0 +1,103 @@
+package k8s_test
+
+import (
+	"context"
+
+	. "code.cloudfoundry.org/eirini-controller/k8s"
+	"code.cloudfoundry.org/eirini-controller/k8s/k8sfakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("NamespaceFilter", func() {
+	var (
+		fakeClient *k8sfakes.FakeClient
+		namespaces []string
+		selector   string
+		filter     *NamespaceFilter
+	)
+
+	BeforeEach(func() {
+		fakeClient = new(k8sfakes.FakeClient)
+		fakeClient.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			if key.Name != "labelled" {
+				return k8serrors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, key.Name)
+			}
+
+			obj.(*v1.Namespace).Labels = map[string]string{"workloads": "true"}
+
+			return nil
+		}
+		namespaces = nil
+		selector = ""
+	})
+
+	JustBeforeEach(func() {
+		var err error
+
+		filter, err = NewNamespaceFilter(fakeClient, namespaces, selector)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	includes := func(namespace string) bool {
+		included, err := filter.Includes(context.Background(), namespace)
+		Expect(err).NotTo(HaveOccurred())
+
+		return included
+	}
+
+	It("includes all namespaces without looking them up", func() {
+		Expect(filter.Selective()).To(BeFalse())
+		Expect(includes("any")).To(BeTrue())
+		Expect(fakeClient.GetCallCount()).To(BeZero())
+	})
+
+	When("namespaces are listed", func() {
+		BeforeEach(func() {
+			namespaces = []string{"listed", "labelled"}
+		})
+
+		It("includes only them", func() {
+			Expect(includes("listed")).To(BeTrue())
+			Expect(includes("other")).To(BeFalse())
+		})
+	})
+
+	When("a selector is set", func() {
+		BeforeEach(func() {
+			selector = "workloads=true"
+		})
+
+		It("includes the namespaces matching it", func() {
+			Expect(filter.Selective()).To(BeTrue())
+			Expect(includes("labelled")).To(BeTrue())
+			Expect(includes("missing")).To(BeFalse())
+		})
+
+		It("matches namespaces at hand without looking them up", func() {
+			Expect(filter.IncludesNamespace(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"workloads": "true"}}})).To(BeTrue())
+			Expect(filter.IncludesNamespace(&v1.Namespace{})).To(BeFalse())
+			Expect(fakeClient.GetCallCount()).To(BeZero())
+		})
+
+		When("namespaces are listed too", func() {
+			BeforeEach(func() {
+				namespaces = []string{"listed"}
+			})
+
+			It("requires both", func() {
+				Expect(includes("labelled")).To(BeFalse())
+			})
+		})
+	})
+
+	It("rejects invalid selectors", func() {
+		_, err := NewNamespaceFilter(fakeClient, nil, "workloads in (")
+		Expect(err).To(MatchError(ContainSubstring("invalid namespace selector")))
+	})
+})
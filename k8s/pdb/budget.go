@@ -0,0 +1,25 @@
+package pdb
+
+import (
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// BlocksAllEvictions tells whether a disruption budget keeping minAvailable,
+// or allowing maxUnavailable, of the given number of instances never allows
+// any of them to be evicted, which hangs node drains. Percentages are rounded
+// up, as the disruption controller does.
+func BlocksAllEvictions(minAvailable, maxUnavailable *intstr.IntOrString, instances int) bool {
+	if minAvailable != nil {
+		value, err := intstr.GetScaledValueFromIntOrPercent(minAvailable, instances, true)
+
+		return err == nil && value >= instances
+	}
+
+	if maxUnavailable != nil {
+		value, err := intstr.GetScaledValueFromIntOrPercent(maxUnavailable, instances, true)
+
+		return err == nil && value <= 0
+	}
+
+	return false
+}
@@ -2,12 +2,15 @@ package pdb
 
 import (
 	"context"
+	"sync"
 
+	"code.cloudfoundry.org/eirini-controller/k8s"
 	"code.cloudfoundry.org/eirini-controller/k8s/stset"
 	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
-	"k8s.io/api/policy/v1beta1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -16,30 +19,87 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
+// PdbMinAvailableInstances is the minimum number of available instances
+// used when the controller configuration does not set one.
 const PdbMinAvailableInstances = "50%"
 
 type Updater struct {
-	client client.Client
+	client                   client.Client
+	defaultMinAvailableMutex sync.RWMutex
+	defaultMinAvailable      intstr.IntOrString
 }
 
-func NewUpdater(client client.Client) *Updater {
+// NewUpdater creates an updater keeping defaultMinAvailable instances, either
+// a number or a percentage, unless the LRP sets its own disruption budget or
+// its namespace WorkloadDefaults set another default. An empty
+// defaultMinAvailable falls back to PdbMinAvailableInstances.
+func NewUpdater(client client.Client, defaultMinAvailable string) *Updater {
+	if defaultMinAvailable == "" {
+		defaultMinAvailable = PdbMinAvailableInstances
+	}
+
 	return &Updater{
-		client: client,
+		client:              client,
+		defaultMinAvailable: intstr.Parse(defaultMinAvailable),
+	}
+}
+
+// SetDefaultMinAvailable changes the default minimum of available instances,
+// falling back to PdbMinAvailableInstances when empty.
+func (c *Updater) SetDefaultMinAvailable(defaultMinAvailable string) {
+	if defaultMinAvailable == "" {
+		defaultMinAvailable = PdbMinAvailableInstances
 	}
+
+	c.defaultMinAvailableMutex.Lock()
+	defer c.defaultMinAvailableMutex.Unlock()
+
+	c.defaultMinAvailable = intstr.Parse(defaultMinAvailable)
 }
 
 func (c *Updater) Update(ctx context.Context, statefulSet *appsv1.StatefulSet, lrp *eiriniv1.LRP) error {
 	if lrp.Spec.Instances > 1 {
-		return c.createPDB(ctx, statefulSet, lrp)
+		return c.createOrPatchPDB(ctx, statefulSet, lrp)
 	}
 
 	return c.deletePDB(ctx, statefulSet)
 }
 
-func (c *Updater) createPDB(ctx context.Context, statefulSet *appsv1.StatefulSet, lrp *eiriniv1.LRP) error {
-	minAvailable := intstr.FromString(PdbMinAvailableInstances)
+func (c *Updater) createOrPatchPDB(ctx context.Context, statefulSet *appsv1.StatefulSet, lrp *eiriniv1.LRP) error {
+	existing := &policyv1.PodDisruptionBudget{}
+
+	err := c.client.Get(ctx, client.ObjectKey{Namespace: statefulSet.Namespace, Name: statefulSet.Name}, existing)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrap(err, "failed to get pod disruption budget")
+	}
+
+	defaults, defaultsErr := k8s.GetWorkloadDefaults(ctx, c.client, statefulSet.Namespace)
+	if defaultsErr != nil {
+		return defaultsErr
+	}
+
+	desiredSpec := c.pdbSpec(lrp, defaults)
+
+	if k8serrors.IsNotFound(err) {
+		return c.createPDB(ctx, statefulSet, lrp, desiredSpec)
+	}
+
+	if equality.Semantic.DeepEqual(existing.Spec.MinAvailable, desiredSpec.MinAvailable) &&
+		equality.Semantic.DeepEqual(existing.Spec.MaxUnavailable, desiredSpec.MaxUnavailable) {
+		return nil
+	}
+
+	updated := existing.DeepCopy()
+	updated.Spec.MinAvailable = desiredSpec.MinAvailable
+	updated.Spec.MaxUnavailable = desiredSpec.MaxUnavailable
+
+	err = c.client.Patch(ctx, updated, client.MergeFrom(existing))
 
-	pdb := &v1beta1.PodDisruptionBudget{
+	return errors.Wrap(err, "failed to patch pod disruption budget")
+}
+
+func (c *Updater) createPDB(ctx context.Context, statefulSet *appsv1.StatefulSet, lrp *eiriniv1.LRP, spec policyv1.PodDisruptionBudgetSpec) error {
+	pdb := &policyv1.PodDisruptionBudget{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      statefulSet.Name,
 			Namespace: statefulSet.Namespace,
@@ -48,10 +108,7 @@ func (c *Updater) createPDB(ctx context.Context, statefulSet *appsv1.StatefulSet
 				stset.LabelVersion: lrp.Spec.Version,
 			},
 		},
-		Spec: v1beta1.PodDisruptionBudgetSpec{
-			MinAvailable: &minAvailable,
-			Selector:     stset.StatefulSetLabelSelector(lrp),
-		},
+		Spec: spec,
 	}
 
 	if err := controllerutil.SetOwnerReference(statefulSet, pdb, scheme.Scheme); err != nil {
@@ -59,7 +116,6 @@ func (c *Updater) createPDB(ctx context.Context, statefulSet *appsv1.StatefulSet
 	}
 
 	err := c.client.Create(ctx, pdb)
-
 	if k8serrors.IsAlreadyExists(err) {
 		return nil
 	}
@@ -67,8 +123,57 @@ func (c *Updater) createPDB(ctx context.Context, statefulSet *appsv1.StatefulSet
 	return errors.Wrap(err, "failed to create pod distruption budget")
 }
 
+// pdbSpec honours the disruption budget of the LRP when set, and keeps the
+// namespace or configured default minimum of available instances otherwise.
+// Either is relaxed when it would block every eviction of the LRP, as happens
+// when the LRP is scaled down to its minimum of available instances.
+func (c *Updater) pdbSpec(lrp *eiriniv1.LRP, defaults *eiriniv1.WorkloadDefaultsSpec) policyv1.PodDisruptionBudgetSpec {
+	spec := policyv1.PodDisruptionBudgetSpec{
+		Selector: stset.StatefulSetLabelSelector(lrp),
+	}
+
+	budget := lrp.Spec.DisruptionBudget
+
+	switch {
+	case budget != nil && budget.MinAvailable != nil:
+		spec.MinAvailable = nonBlockingMinAvailable(*budget.MinAvailable, lrp.Spec.Instances)
+	case budget != nil && budget.MaxUnavailable != nil:
+		spec.MaxUnavailable = nonBlockingMaxUnavailable(*budget.MaxUnavailable, lrp.Spec.Instances)
+	case defaults.DefaultMinAvailableInstances != nil:
+		spec.MinAvailable = nonBlockingMinAvailable(*defaults.DefaultMinAvailableInstances, lrp.Spec.Instances)
+	default:
+		c.defaultMinAvailableMutex.RLock()
+		minAvailable := c.defaultMinAvailable
+		c.defaultMinAvailableMutex.RUnlock()
+		spec.MinAvailable = nonBlockingMinAvailable(minAvailable, lrp.Spec.Instances)
+	}
+
+	return spec
+}
+
+// nonBlockingMinAvailable lowers a minimum of available instances that would
+// block every eviction of the LRP, such as a minimum of 3 for 3 instances, to
+// one instance fewer than the LRP has.
+func nonBlockingMinAvailable(minAvailable intstr.IntOrString, instances int) *intstr.IntOrString {
+	if BlocksAllEvictions(&minAvailable, nil, instances) {
+		minAvailable = intstr.FromInt(instances - 1)
+	}
+
+	return &minAvailable
+}
+
+// nonBlockingMaxUnavailable raises a maximum of unavailable instances that
+// would block every eviction of the LRP, such as 0%, to one instance.
+func nonBlockingMaxUnavailable(maxUnavailable intstr.IntOrString, instances int) *intstr.IntOrString {
+	if BlocksAllEvictions(nil, &maxUnavailable, instances) {
+		maxUnavailable = intstr.FromInt(1)
+	}
+
+	return &maxUnavailable
+}
+
 func (c *Updater) deletePDB(ctx context.Context, statefulSet *appsv1.StatefulSet) error {
-	err := c.client.DeleteAllOf(ctx, &v1beta1.PodDisruptionBudget{}, client.InNamespace(statefulSet.Namespace), client.MatchingFields{"metadata.name": statefulSet.Name})
+	err := c.client.DeleteAllOf(ctx, &policyv1.PodDisruptionBudget{}, client.InNamespace(statefulSet.Namespace), client.MatchingFields{"metadata.name": statefulSet.Name})
 
 	return errors.Wrap(err, "failed to delete pod distruption budget")
 }
@@ -12,25 +12,28 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	appsv1 "k8s.io/api/apps/v1"
-	"k8s.io/api/policy/v1beta1"
+	policyv1 "k8s.io/api/policy/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("PDB", func() {
 	var (
-		creator   *pdb.Updater
-		k8sClient *k8sfakes.FakeClient
-		stSet     *appsv1.StatefulSet
-		lrp       *eiriniv1.LRP
-		ctx       context.Context
+		creator             *pdb.Updater
+		k8sClient           *k8sfakes.FakeClient
+		defaultMinAvailable string
+		stSet               *appsv1.StatefulSet
+		lrp                 *eiriniv1.LRP
+		ctx                 context.Context
 	)
 
 	BeforeEach(func() {
 		k8sClient = new(k8sfakes.FakeClient)
-		creator = pdb.NewUpdater(k8sClient)
+		k8sClient.GetReturns(k8serrors.NewNotFound(schema.GroupResource{}, "name"))
+		defaultMinAvailable = ""
 
 		stSet = &appsv1.StatefulSet{
 			ObjectMeta: v1.ObjectMeta{
@@ -56,6 +59,7 @@ var _ = Describe("PDB", func() {
 	Describe("Update", func() {
 		var updateErr error
 		JustBeforeEach(func() {
+			creator = pdb.NewUpdater(k8sClient, defaultMinAvailable)
 			updateErr = creator.Update(ctx, stSet, lrp)
 		})
 
@@ -68,8 +72,8 @@ var _ = Describe("PDB", func() {
 
 			_, obj, createOpts := k8sClient.CreateArgsForCall(0)
 
-			Expect(obj).To(BeAssignableToTypeOf(&v1beta1.PodDisruptionBudget{}))
-			pdb := obj.(*v1beta1.PodDisruptionBudget)
+			Expect(obj).To(BeAssignableToTypeOf(&policyv1.PodDisruptionBudget{}))
+			pdb := obj.(*policyv1.PodDisruptionBudget)
 
 			Expect(pdb.Namespace).To(Equal("namespace"))
 			Expect(pdb.Name).To(Equal("name"))
@@ -125,7 +129,7 @@ var _ = Describe("PDB", func() {
 			})
 		})
 
-		When("the pod distruption budget already exists", func() {
+		When("the pod distruption budget is created concurrently", func() {
 			BeforeEach(func() {
 				k8sClient.CreateReturns(k8serrors.NewAlreadyExists(schema.GroupResource{}, "boom"))
 			})
@@ -134,5 +138,204 @@ var _ = Describe("PDB", func() {
 				Expect(updateErr).NotTo(HaveOccurred())
 			})
 		})
+
+		When("a default minimum of available instances is configured", func() {
+			BeforeEach(func() {
+				defaultMinAvailable = "1"
+			})
+
+			It("uses it", func() {
+				_, obj, _ := k8sClient.CreateArgsForCall(0)
+				pdb := obj.(*policyv1.PodDisruptionBudget)
+				Expect(pdb.Spec.MinAvailable).To(PointTo(Equal(intstr.FromInt(1))))
+			})
+		})
+
+		When("the default minimum of available instances would block every eviction", func() {
+			BeforeEach(func() {
+				defaultMinAvailable = "2"
+			})
+
+			It("keeps one instance fewer than the LRP has", func() {
+				_, obj, _ := k8sClient.CreateArgsForCall(0)
+				pdb := obj.(*policyv1.PodDisruptionBudget)
+				Expect(pdb.Spec.MinAvailable).To(PointTo(Equal(intstr.FromInt(1))))
+			})
+		})
+
+		When("the namespace workload defaults set a minimum of available instances", func() {
+			BeforeEach(func() {
+				defaultMinAvailable = "1"
+				k8sClient.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+					defaults, ok := obj.(*eiriniv1.WorkloadDefaults)
+					if !ok {
+						return k8serrors.NewNotFound(schema.GroupResource{}, key.Name)
+					}
+
+					Expect(key).To(Equal(client.ObjectKey{Namespace: "namespace", Name: eiriniv1.WorkloadDefaultsName}))
+					minAvailable := intstr.FromString("25%")
+					defaults.Spec.DefaultMinAvailableInstances = &minAvailable
+
+					return nil
+				}
+			})
+
+			It("uses it instead of the configured one", func() {
+				_, obj, _ := k8sClient.CreateArgsForCall(0)
+				pdb := obj.(*policyv1.PodDisruptionBudget)
+				Expect(pdb.Spec.MinAvailable).To(PointTo(Equal(intstr.FromString("25%"))))
+			})
+		})
+
+		When("the LRP sets a minimum of available instances", func() {
+			BeforeEach(func() {
+				lrp.Spec.Instances = 4
+				minAvailable := intstr.FromString("75%")
+				lrp.Spec.DisruptionBudget = &eiriniv1.DisruptionBudget{MinAvailable: &minAvailable}
+			})
+
+			It("uses it", func() {
+				_, obj, _ := k8sClient.CreateArgsForCall(0)
+				pdb := obj.(*policyv1.PodDisruptionBudget)
+				Expect(pdb.Spec.MinAvailable).To(PointTo(Equal(intstr.FromString("75%"))))
+				Expect(pdb.Spec.MaxUnavailable).To(BeNil())
+			})
+
+			When("the LRP is scaled down to its minimum of available instances", func() {
+				BeforeEach(func() {
+					lrp.Spec.Instances = 3
+				})
+
+				It("keeps one instance fewer than the LRP has", func() {
+					_, obj, _ := k8sClient.CreateArgsForCall(0)
+					pdb := obj.(*policyv1.PodDisruptionBudget)
+					Expect(pdb.Spec.MinAvailable).To(PointTo(Equal(intstr.FromInt(2))))
+				})
+			})
+		})
+
+		When("the LRP sets a maximum of unavailable instances", func() {
+			BeforeEach(func() {
+				maxUnavailable := intstr.FromInt(1)
+				lrp.Spec.DisruptionBudget = &eiriniv1.DisruptionBudget{MaxUnavailable: &maxUnavailable}
+			})
+
+			It("uses it instead of the default minimum", func() {
+				_, obj, _ := k8sClient.CreateArgsForCall(0)
+				pdb := obj.(*policyv1.PodDisruptionBudget)
+				Expect(pdb.Spec.MinAvailable).To(BeNil())
+				Expect(pdb.Spec.MaxUnavailable).To(PointTo(Equal(intstr.FromInt(1))))
+			})
+
+			When("it would block every eviction", func() {
+				BeforeEach(func() {
+					maxUnavailable := intstr.FromString("0%")
+					lrp.Spec.DisruptionBudget = &eiriniv1.DisruptionBudget{MaxUnavailable: &maxUnavailable}
+				})
+
+				It("allows one instance to be unavailable", func() {
+					_, obj, _ := k8sClient.CreateArgsForCall(0)
+					pdb := obj.(*policyv1.PodDisruptionBudget)
+					Expect(pdb.Spec.MaxUnavailable).To(PointTo(Equal(intstr.FromInt(1))))
+				})
+			})
+		})
+
+		When("getting the pod disruption budget fails", func() {
+			BeforeEach(func() {
+				k8sClient.GetReturns(errors.New("get-error"))
+			})
+
+			It("returns an error", func() {
+				Expect(updateErr).To(MatchError(ContainSubstring("get-error")))
+				Expect(k8sClient.CreateCallCount()).To(BeZero())
+			})
+		})
+
+		When("the pod disruption budget already exists", func() {
+			var existingMinAvailable intstr.IntOrString
+
+			BeforeEach(func() {
+				existingMinAvailable = intstr.FromString("50%")
+
+				k8sClient.GetStub = func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+					existing, ok := obj.(*policyv1.PodDisruptionBudget)
+					if !ok {
+						return k8serrors.NewNotFound(schema.GroupResource{}, "default")
+					}
+
+					existing.Name = "name"
+					existing.Namespace = "namespace"
+					existing.Spec.MinAvailable = &existingMinAvailable
+
+					return nil
+				}
+			})
+
+			It("gets it by the statefulset name", func() {
+				Expect(k8sClient.GetCallCount()).To(Equal(2))
+				_, key, _ := k8sClient.GetArgsForCall(0)
+				Expect(key).To(Equal(client.ObjectKey{Namespace: "namespace", Name: "name"}))
+			})
+
+			It("neither creates nor patches it", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(k8sClient.CreateCallCount()).To(BeZero())
+				Expect(k8sClient.PatchCallCount()).To(BeZero())
+			})
+
+			When("the policy changes", func() {
+				BeforeEach(func() {
+					maxUnavailable := intstr.FromInt(2)
+					lrp.Spec.DisruptionBudget = &eiriniv1.DisruptionBudget{MaxUnavailable: &maxUnavailable}
+				})
+
+				It("patches it", func() {
+					Expect(k8sClient.PatchCallCount()).To(Equal(1))
+					_, obj, patch, _ := k8sClient.PatchArgsForCall(0)
+					pdb := obj.(*policyv1.PodDisruptionBudget)
+					Expect(pdb.Spec.MinAvailable).To(BeNil())
+					Expect(pdb.Spec.MaxUnavailable).To(PointTo(Equal(intstr.FromInt(2))))
+
+					patchBytes, err := patch.Data(pdb)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(patchBytes)).To(Equal(`{"spec":{"maxUnavailable":2,"minAvailable":null}}`))
+				})
+
+				When("patching fails", func() {
+					BeforeEach(func() {
+						k8sClient.PatchReturns(errors.New("patch-error"))
+					})
+
+					It("returns an error", func() {
+						Expect(updateErr).To(MatchError(ContainSubstring("patch-error")))
+					})
+				})
+			})
+		})
+	})
+
+	Describe("SetDefaultMinAvailable", func() {
+		BeforeEach(func() {
+			creator = pdb.NewUpdater(k8sClient, "1")
+		})
+
+		It("changes the default minimum of available instances", func() {
+			creator.SetDefaultMinAvailable("25%")
+			Expect(creator.Update(ctx, stSet, lrp)).To(Succeed())
+
+			_, obj, _ := k8sClient.CreateArgsForCall(0)
+			budget := obj.(*policyv1.PodDisruptionBudget)
+			Expect(budget.Spec.MinAvailable).To(PointTo(Equal(intstr.FromString("25%"))))
+		})
+
+		It("falls back to the built-in default when empty", func() {
+			creator.SetDefaultMinAvailable("")
+			Expect(creator.Update(ctx, stSet, lrp)).To(Succeed())
+
+			_, obj, _ := k8sClient.CreateArgsForCall(0)
+			budget := obj.(*policyv1.PodDisruptionBudget)
+			Expect(budget.Spec.MinAvailable).To(PointTo(Equal(intstr.FromString(pdb.PdbMinAvailableInstances))))
+		})
 	})
 })
@@ -0,0 +1,102 @@
+package k8s
+
+import (
+	"sort"
+
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ApplyPlacementTags schedules the pod on the nodes its placement tags map
+// to. The node selectors and node affinity requirements of all the tags must
+// hold at once, along with the node affinity the pod already has. Tags
+// without a mapping, such as tags removed from the configuration since the
+// workload was validated, and tags selecting different values of a node
+// label are rejected, rather than scheduling the pod on any node.
+func ApplyPlacementTags(podSpec *corev1.PodSpec, tags []string, placementTags map[string]eirinictrl.PlacementTag) error {
+	requirements := []corev1.NodeSelectorRequirement{}
+	selectedBy := map[string]string{}
+
+	for _, tag := range tags {
+		placement, ok := placementTags[tag]
+		if !ok {
+			return errors.Errorf("placement tag %q is not configured", tag)
+		}
+
+		keys := make([]string, 0, len(placement.NodeSelector))
+		for key := range placement.NodeSelector {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			value := placement.NodeSelector[key]
+
+			if current, ok := podSpec.NodeSelector[key]; ok && current != value {
+				if other, ok := selectedBy[key]; ok {
+					return errors.Errorf("placement tags %q and %q select different values of node label %q", other, tag, key)
+				}
+
+				return errors.Errorf("placement tag %q selects a different value of node label %q than the pod", tag, key)
+			}
+
+			if podSpec.NodeSelector == nil {
+				podSpec.NodeSelector = map[string]string{}
+			}
+
+			podSpec.NodeSelector[key] = value
+			selectedBy[key] = tag
+		}
+
+		for _, toleration := range placement.Tolerations {
+			podSpec.Tolerations = append(podSpec.Tolerations, corev1.Toleration{
+				Key:      toleration.Key,
+				Operator: corev1.TolerationOperator(toleration.Operator),
+				Value:    toleration.Value,
+				Effect:   corev1.TaintEffect(toleration.Effect),
+			})
+		}
+
+		for _, requirement := range placement.NodeAffinity {
+			requirements = append(requirements, corev1.NodeSelectorRequirement{
+				Key:      requirement.Key,
+				Operator: corev1.NodeSelectorOperator(requirement.Operator),
+				Values:   requirement.Values,
+			})
+		}
+	}
+
+	if len(requirements) > 0 {
+		requireNodeAffinity(podSpec, requirements)
+	}
+
+	return nil
+}
+
+// requireNodeAffinity adds the requirements to every term of the required
+// node affinity of the pod, as the terms are alternatives.
+func requireNodeAffinity(podSpec *corev1.PodSpec, requirements []corev1.NodeSelectorRequirement) {
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = &corev1.Affinity{}
+	}
+
+	if podSpec.Affinity.NodeAffinity == nil {
+		podSpec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+
+	nodeAffinity := podSpec.Affinity.NodeAffinity
+
+	if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil ||
+		len(nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms) == 0 {
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{}},
+		}
+	}
+
+	terms := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	for i := range terms {
+		terms[i].MatchExpressions = append(terms[i].MatchExpressions, requirements...)
+	}
+}
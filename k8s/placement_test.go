@@ -0,0 +1,136 @@
+package k8s_test
+
+import (
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	. "code.cloudfoundry.org/eirini-controller/k8s"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("ApplyPlacementTags", func() {
+	var (
+		podSpec       *v1.PodSpec
+		tags          []string
+		placementTags map[string]eirinictrl.PlacementTag
+		applyErr      error
+	)
+
+	BeforeEach(func() {
+		podSpec = &v1.PodSpec{}
+		tags = []string{"segment-a"}
+		placementTags = map[string]eirinictrl.PlacementTag{
+			"segment-a": {
+				NodeSelector: map[string]string{"segment": "a"},
+				Tolerations:  []eirinictrl.Toleration{{Key: "dedicated", Operator: "Equal", Value: "a", Effect: "NoSchedule"}},
+				NodeAffinity: []eirinictrl.NodeSelectorRequirement{{Key: "pool", Operator: "In", Values: []string{"a1", "a2"}}},
+			},
+			"gpu": {
+				NodeSelector: map[string]string{"gpu": "true"},
+				NodeAffinity: []eirinictrl.NodeSelectorRequirement{{Key: "gpu-model", Operator: "Exists"}},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		applyErr = ApplyPlacementTags(podSpec, tags, placementTags)
+	})
+
+	It("succeeds", func() {
+		Expect(applyErr).NotTo(HaveOccurred())
+	})
+
+	It("sets the node selector", func() {
+		Expect(podSpec.NodeSelector).To(Equal(map[string]string{"segment": "a"}))
+	})
+
+	It("sets the tolerations", func() {
+		Expect(podSpec.Tolerations).To(ConsistOf(v1.Toleration{
+			Key:      "dedicated",
+			Operator: v1.TolerationOpEqual,
+			Value:    "a",
+			Effect:   v1.TaintEffectNoSchedule,
+		}))
+	})
+
+	It("requires the node affinity", func() {
+		Expect(podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(ConsistOf(
+			v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{
+				{Key: "pool", Operator: v1.NodeSelectorOpIn, Values: []string{"a1", "a2"}},
+			}},
+		))
+	})
+
+	When("the workload has several tags", func() {
+		BeforeEach(func() {
+			tags = []string{"segment-a", "gpu"}
+		})
+
+		It("requires the nodes to match all of them", func() {
+			Expect(podSpec.NodeSelector).To(Equal(map[string]string{"segment": "a", "gpu": "true"}))
+			Expect(podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(ConsistOf(
+				v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{
+					{Key: "pool", Operator: v1.NodeSelectorOpIn, Values: []string{"a1", "a2"}},
+					{Key: "gpu-model", Operator: v1.NodeSelectorOpExists},
+				}},
+			))
+		})
+	})
+
+	When("the workload has no tags", func() {
+		BeforeEach(func() {
+			tags = nil
+		})
+
+		It("leaves the pod spec alone", func() {
+			Expect(*podSpec).To(Equal(v1.PodSpec{}))
+		})
+	})
+
+	When("the pod already requires a node affinity", func() {
+		BeforeEach(func() {
+			podSpec.Affinity = &v1.Affinity{NodeAffinity: &v1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+					NodeSelectorTerms: []v1.NodeSelectorTerm{
+						{MatchExpressions: []v1.NodeSelectorRequirement{{Key: "zone", Operator: v1.NodeSelectorOpIn, Values: []string{"z1"}}}},
+						{MatchExpressions: []v1.NodeSelectorRequirement{{Key: "zone", Operator: v1.NodeSelectorOpIn, Values: []string{"z2"}}}},
+					},
+				},
+			}}
+		})
+
+		It("adds the requirements of the tags to each of its terms", func() {
+			Expect(podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(ConsistOf(
+				v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{
+					{Key: "zone", Operator: v1.NodeSelectorOpIn, Values: []string{"z1"}},
+					{Key: "pool", Operator: v1.NodeSelectorOpIn, Values: []string{"a1", "a2"}},
+				}},
+				v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{
+					{Key: "zone", Operator: v1.NodeSelectorOpIn, Values: []string{"z2"}},
+					{Key: "pool", Operator: v1.NodeSelectorOpIn, Values: []string{"a1", "a2"}},
+				}},
+			))
+		})
+	})
+
+	When("tags select different values of a node label", func() {
+		BeforeEach(func() {
+			placementTags["segment-b"] = eirinictrl.PlacementTag{NodeSelector: map[string]string{"segment": "b"}}
+			tags = []string{"segment-a", "segment-b"}
+		})
+
+		It("fails", func() {
+			Expect(applyErr).To(MatchError(`placement tags "segment-a" and "segment-b" select different values of node label "segment"`))
+		})
+	})
+
+	When("a tag is not configured", func() {
+		BeforeEach(func() {
+			tags = []string{"unknown"}
+		})
+
+		It("fails", func() {
+			Expect(applyErr).To(MatchError(`placement tag "unknown" is not configured`))
+		})
+	})
+})
@@ -1,6 +1,9 @@
 package k8s
 
 import (
+	"errors"
+	"fmt"
+
 	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -9,65 +12,134 @@ import (
 const (
 	livenessFailureThreshold  = 4
 	readinessFailureThreshold = 1
+	startupPeriodSeconds      = 1
 )
 
 func CreateLivenessProbe(lrp *eiriniv1.LRP) *v1.Probe {
-	initialDelay := toSeconds(lrp.Spec.Health.TimeoutMs)
+	return createProbe(lrp.Spec.Health, livenessFailureThreshold)
+}
+
+// CreateReadinessProbe uses the readiness health check of the LRP when
+// set, falling back to its liveness health check otherwise.
+func CreateReadinessProbe(lrp *eiriniv1.LRP) *v1.Probe {
+	if lrp.Spec.ReadinessHealth != nil {
+		return createProbe(*lrp.Spec.ReadinessHealth, readinessFailureThreshold)
+	}
+
+	return createProbe(lrp.Spec.Health, readinessFailureThreshold)
+}
 
-	if lrp.Spec.Health.Type == "http" {
-		return createHTTPProbe(lrp, initialDelay, livenessFailureThreshold)
+// CreateStartupProbe creates a probe that gives the app StartupTimeoutMs to
+// pass its first health check. Liveness and readiness probes only start
+// running once the startup probe has succeeded.
+func CreateStartupProbe(lrp *eiriniv1.LRP) *v1.Probe {
+	startupTimeout := toSeconds(startupTimeoutMs(lrp.Spec.Health))
+	if startupTimeout == 0 {
+		return nil
 	}
 
-	if lrp.Spec.Health.Type == "port" {
-		return createPortProbe(lrp, initialDelay, livenessFailureThreshold)
+	handler, ok := probeHandler(lrp.Spec.Health)
+	if !ok {
+		return nil
 	}
 
-	return nil
+	return &v1.Probe{
+		ProbeHandler:     handler,
+		PeriodSeconds:    startupPeriodSeconds,
+		TimeoutSeconds:   toSeconds(lrp.Spec.Health.InvocationTimeoutMs),
+		FailureThreshold: startupTimeout / startupPeriodSeconds,
+	}
 }
 
-func CreateReadinessProbe(lrp *eiriniv1.LRP) *v1.Probe {
-	if lrp.Spec.Health.Type == "http" {
-		return createHTTPProbe(lrp, 0, readinessFailureThreshold)
+func createProbe(health eiriniv1.Healthcheck, failureThreshold int32) *v1.Probe {
+	handler, ok := probeHandler(health)
+	if !ok {
+		return nil
+	}
+
+	return &v1.Probe{
+		ProbeHandler:     handler,
+		PeriodSeconds:    toSeconds(health.IntervalMs),
+		TimeoutSeconds:   toSeconds(health.InvocationTimeoutMs),
+		FailureThreshold: failureThreshold,
 	}
+}
 
-	if lrp.Spec.Health.Type == "port" {
-		return createPortProbe(lrp, 0, readinessFailureThreshold)
+// CreateSidecarLivenessProbe creates the liveness probe of a sidecar
+// container from its own health check, if any.
+func CreateSidecarLivenessProbe(sidecar *eiriniv1.Sidecar) *v1.Probe {
+	if sidecar.Health == nil {
+		return nil
 	}
 
-	return nil
+	return createProbe(*sidecar.Health, livenessFailureThreshold)
 }
 
-func createPortProbe(lrp *eiriniv1.LRP, initialDelay, failureThreshold int32) *v1.Probe {
-	return &v1.Probe{
-		ProbeHandler: v1.ProbeHandler{
-			TCPSocket: tcpSocketAction(lrp),
-		},
-		InitialDelaySeconds: initialDelay,
-		FailureThreshold:    failureThreshold,
+// CreateSidecarReadinessProbe creates the readiness probe of a sidecar
+// container from its own health check, if any.
+func CreateSidecarReadinessProbe(sidecar *eiriniv1.Sidecar) *v1.Probe {
+	if sidecar.Health == nil {
+		return nil
 	}
+
+	return createProbe(*sidecar.Health, readinessFailureThreshold)
 }
 
-func createHTTPProbe(lrp *eiriniv1.LRP, initialDelay, failureThreshold int32) *v1.Probe {
-	return &v1.Probe{
-		ProbeHandler: v1.ProbeHandler{
-			HTTPGet: httpGetAction(lrp),
-		},
-		InitialDelaySeconds: initialDelay,
-		FailureThreshold:    failureThreshold,
+// ValidateHealthcheck reports health checks that would not result in the
+// probe the user asked for.
+func ValidateHealthcheck(health eiriniv1.Healthcheck) error {
+	switch health.Type {
+	case "", eiriniv1.HealthcheckTypeProcess:
+		return nil
+	case eiriniv1.HealthcheckTypeHTTP, eiriniv1.HealthcheckTypePort:
+		if health.Port <= 0 {
+			return fmt.Errorf("%s health check requires a port", health.Type)
+		}
+
+		return nil
+	case eiriniv1.HealthcheckTypeExec:
+		if len(health.Command) == 0 {
+			return errors.New("exec health check requires a command")
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unsupported health check type %q", health.Type)
 	}
 }
 
-func httpGetAction(lrp *eiriniv1.LRP) *v1.HTTPGetAction {
+func probeHandler(health eiriniv1.Healthcheck) (v1.ProbeHandler, bool) {
+	switch health.Type {
+	case eiriniv1.HealthcheckTypeHTTP:
+		return v1.ProbeHandler{HTTPGet: httpGetAction(health)}, true
+	case eiriniv1.HealthcheckTypePort:
+		return v1.ProbeHandler{TCPSocket: tcpSocketAction(health)}, true
+	case eiriniv1.HealthcheckTypeExec:
+		return v1.ProbeHandler{Exec: &v1.ExecAction{Command: health.Command}}, true
+	default:
+		return v1.ProbeHandler{}, false
+	}
+}
+
+func httpGetAction(health eiriniv1.Healthcheck) *v1.HTTPGetAction {
 	return &v1.HTTPGetAction{
-		Path: lrp.Spec.Health.Endpoint,
-		Port: intstr.IntOrString{Type: intstr.Int, IntVal: lrp.Spec.Health.Port},
+		Path: health.Endpoint,
+		Port: intstr.IntOrString{Type: intstr.Int, IntVal: health.Port},
 	}
 }
 
-func tcpSocketAction(lrp *eiriniv1.LRP) *v1.TCPSocketAction {
+func tcpSocketAction(health eiriniv1.Healthcheck) *v1.TCPSocketAction {
 	return &v1.TCPSocketAction{
-		Port: intstr.IntOrString{Type: intstr.Int, IntVal: lrp.Spec.Health.Port},
+		Port: intstr.IntOrString{Type: intstr.Int, IntVal: health.Port},
+	}
+}
+
+func startupTimeoutMs(health eiriniv1.Healthcheck) uint {
+	if health.StartupTimeoutMs != 0 {
+		return health.StartupTimeoutMs
 	}
+
+	return health.TimeoutMs
 }
 
 func toSeconds(millis uint) int32 {
@@ -20,9 +20,11 @@ var _ = Describe("PrrobeCreator", func() {
 		lrp = &eiriniv1.LRP{
 			Spec: eiriniv1.LRPSpec{
 				Health: eiriniv1.Healthcheck{
-					Endpoint:  "/healthz",
-					Port:      8080,
-					TimeoutMs: 3000,
+					Endpoint:            "/healthz",
+					Port:                8080,
+					TimeoutMs:           3000,
+					IntervalMs:          30000,
+					InvocationTimeoutMs: 2000,
 				},
 			},
 		}
@@ -46,8 +48,9 @@ var _ = Describe("PrrobeCreator", func() {
 							Port: intstr.IntOrString{Type: intstr.Int, IntVal: 8080},
 						},
 					},
-					InitialDelaySeconds: 3,
-					FailureThreshold:    4,
+					PeriodSeconds:    30,
+					TimeoutSeconds:   2,
+					FailureThreshold: 4,
 				}))
 			})
 		})
@@ -64,20 +67,61 @@ var _ = Describe("PrrobeCreator", func() {
 							Port: intstr.IntOrString{Type: intstr.Int, IntVal: 8080},
 						},
 					},
-					InitialDelaySeconds: 3,
-					FailureThreshold:    4,
+					PeriodSeconds:    30,
+					TimeoutSeconds:   2,
+					FailureThreshold: 4,
 				}))
 			})
 		})
 
-		Context("When timeout is not a whole number", func() {
+		Context("When healthcheck type is exec", func() {
+			BeforeEach(func() {
+				lrp.Spec.Health.Type = "exec"
+				lrp.Spec.Health.Command = []string{"/bin/check", "--now"}
+			})
+
+			It("creates a probe with Exec action", func() {
+				Expect(probe).To(Equal(&v1.Probe{
+					ProbeHandler: v1.ProbeHandler{
+						Exec: &v1.ExecAction{
+							Command: []string{"/bin/check", "--now"},
+						},
+					},
+					PeriodSeconds:    30,
+					TimeoutSeconds:   2,
+					FailureThreshold: 4,
+				}))
+			})
+		})
+
+		Context("When healthcheck type is process", func() {
+			BeforeEach(func() {
+				lrp.Spec.Health.Type = "process"
+			})
+
+			It("returns nil", func() {
+				Expect(probe).To(BeNil())
+			})
+		})
+
+		Context("When interval is not a whole number", func() {
 			BeforeEach(func() {
 				lrp.Spec.Health.Type = "http"
-				lrp.Spec.Health.TimeoutMs = 5700
+				lrp.Spec.Health.IntervalMs = 5700
 			})
 
 			It("rounds it down", func() {
-				Expect(probe.InitialDelaySeconds).To(Equal(int32(5)))
+				Expect(probe.PeriodSeconds).To(Equal(int32(5)))
+			})
+		})
+
+		Context("When a timeout is set", func() {
+			BeforeEach(func() {
+				lrp.Spec.Health.Type = "http"
+			})
+
+			It("does not delay the probe", func() {
+				Expect(probe.InitialDelaySeconds).To(BeZero())
 			})
 		})
 
@@ -110,8 +154,9 @@ var _ = Describe("PrrobeCreator", func() {
 							Port: intstr.IntOrString{Type: intstr.Int, IntVal: 8080},
 						},
 					},
-					InitialDelaySeconds: 0,
-					FailureThreshold:    1,
+					PeriodSeconds:    30,
+					TimeoutSeconds:   2,
+					FailureThreshold: 1,
 				}))
 			})
 		})
@@ -128,12 +173,146 @@ var _ = Describe("PrrobeCreator", func() {
 							Port: intstr.IntOrString{Type: intstr.Int, IntVal: 8080},
 						},
 					},
-					InitialDelaySeconds: 0,
-					FailureThreshold:    1,
+					PeriodSeconds:    30,
+					TimeoutSeconds:   2,
+					FailureThreshold: 1,
+				}))
+			})
+		})
+
+		Context("When healthcheck information is missing", func() {
+			BeforeEach(func() {
+				lrp = &eiriniv1.LRP{}
+			})
+
+			It("returns nil", func() {
+				Expect(probe).To(BeNil())
+			})
+		})
+	})
+
+	Context("ReadinessProbeCreator with a dedicated readiness health check", func() {
+		BeforeEach(func() {
+			lrp.Spec.Health.Type = "port"
+			lrp.Spec.ReadinessHealth = &eiriniv1.Healthcheck{
+				Type:                "http",
+				Port:                9090,
+				Endpoint:            "/ready",
+				IntervalMs:          5000,
+				InvocationTimeoutMs: 1000,
+			}
+		})
+
+		JustBeforeEach(func() {
+			probe = CreateReadinessProbe(lrp)
+		})
+
+		It("creates the probe from the readiness health check", func() {
+			Expect(probe).To(Equal(&v1.Probe{
+				ProbeHandler: v1.ProbeHandler{
+					HTTPGet: &v1.HTTPGetAction{
+						Path: "/ready",
+						Port: intstr.IntOrString{Type: intstr.Int, IntVal: 9090},
+					},
+				},
+				PeriodSeconds:    5,
+				TimeoutSeconds:   1,
+				FailureThreshold: 1,
+			}))
+		})
+
+		It("does not affect the liveness probe", func() {
+			Expect(CreateLivenessProbe(lrp).TCPSocket).NotTo(BeNil())
+		})
+
+		When("the readiness health check type is process", func() {
+			BeforeEach(func() {
+				lrp.Spec.ReadinessHealth.Type = "process"
+			})
+
+			It("returns nil", func() {
+				Expect(probe).To(BeNil())
+			})
+		})
+	})
+
+	Context("StartupProbeCreator", func() {
+		JustBeforeEach(func() {
+			probe = CreateStartupProbe(lrp)
+		})
+
+		Context("When healthcheck type is HTTP", func() {
+			BeforeEach(func() {
+				lrp.Spec.Health.Type = "http"
+			})
+
+			It("creates a probe with HTTPGet action allowing the app the whole timeout to start", func() {
+				Expect(probe).To(Equal(&v1.Probe{
+					ProbeHandler: v1.ProbeHandler{
+						HTTPGet: &v1.HTTPGetAction{
+							Path: "/healthz",
+							Port: intstr.IntOrString{Type: intstr.Int, IntVal: 8080},
+						},
+					},
+					PeriodSeconds:    1,
+					TimeoutSeconds:   2,
+					FailureThreshold: 3,
+				}))
+			})
+		})
+
+		Context("When healthcheck type is Port", func() {
+			BeforeEach(func() {
+				lrp.Spec.Health.Type = "port"
+			})
+
+			It("creates a probe with TCPSocket action allowing the app the whole timeout to start", func() {
+				Expect(probe).To(Equal(&v1.Probe{
+					ProbeHandler: v1.ProbeHandler{
+						TCPSocket: &v1.TCPSocketAction{
+							Port: intstr.IntOrString{Type: intstr.Int, IntVal: 8080},
+						},
+					},
+					PeriodSeconds:    1,
+					TimeoutSeconds:   2,
+					FailureThreshold: 3,
 				}))
 			})
 		})
 
+		Context("When the startup timeout is set", func() {
+			BeforeEach(func() {
+				lrp.Spec.Health.Type = "http"
+				lrp.Spec.Health.StartupTimeoutMs = 60000
+			})
+
+			It("takes precedence over the deprecated timeout", func() {
+				Expect(probe.FailureThreshold).To(Equal(int32(60)))
+			})
+		})
+
+		Context("When the startup timeout is not a whole number", func() {
+			BeforeEach(func() {
+				lrp.Spec.Health.Type = "http"
+				lrp.Spec.Health.TimeoutMs = 5700
+			})
+
+			It("rounds it down", func() {
+				Expect(probe.FailureThreshold).To(Equal(int32(5)))
+			})
+		})
+
+		Context("When no startup timeout is set", func() {
+			BeforeEach(func() {
+				lrp.Spec.Health.Type = "http"
+				lrp.Spec.Health.TimeoutMs = 0
+			})
+
+			It("returns nil", func() {
+				Expect(probe).To(BeNil())
+			})
+		})
+
 		Context("When healthcheck information is missing", func() {
 			BeforeEach(func() {
 				lrp = &eiriniv1.LRP{}
@@ -144,4 +323,58 @@ var _ = Describe("PrrobeCreator", func() {
 			})
 		})
 	})
+
+	Context("SidecarProbeCreators", func() {
+		var (
+			sidecar        *eiriniv1.Sidecar
+			readinessProbe *v1.Probe
+		)
+
+		BeforeEach(func() {
+			sidecar = &eiriniv1.Sidecar{
+				Name: "the-sidecar",
+				Health: &eiriniv1.Healthcheck{
+					Type:    "exec",
+					Command: []string{"/bin/check"},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			probe = CreateSidecarLivenessProbe(sidecar)
+			readinessProbe = CreateSidecarReadinessProbe(sidecar)
+		})
+
+		It("creates probes from the sidecar health check", func() {
+			Expect(probe.Exec.Command).To(Equal([]string{"/bin/check"}))
+			Expect(probe.FailureThreshold).To(Equal(int32(4)))
+			Expect(readinessProbe.Exec.Command).To(Equal([]string{"/bin/check"}))
+			Expect(readinessProbe.FailureThreshold).To(Equal(int32(1)))
+		})
+
+		When("the sidecar has no health check", func() {
+			BeforeEach(func() {
+				sidecar.Health = nil
+			})
+
+			It("returns nil", func() {
+				Expect(probe).To(BeNil())
+				Expect(readinessProbe).To(BeNil())
+			})
+		})
+	})
+
+	DescribeTable("ValidateHealthcheck",
+		func(health eiriniv1.Healthcheck, errMatcher OmegaMatcher) {
+			Expect(ValidateHealthcheck(health)).To(errMatcher)
+		},
+		Entry("empty type", eiriniv1.Healthcheck{}, Succeed()),
+		Entry("process", eiriniv1.Healthcheck{Type: "process"}, Succeed()),
+		Entry("http", eiriniv1.Healthcheck{Type: "http", Port: 8080}, Succeed()),
+		Entry("http without port", eiriniv1.Healthcheck{Type: "http"}, MatchError("http health check requires a port")),
+		Entry("port", eiriniv1.Healthcheck{Type: "port", Port: 8080}, Succeed()),
+		Entry("exec", eiriniv1.Healthcheck{Type: "exec", Command: []string{"true"}}, Succeed()),
+		Entry("exec without command", eiriniv1.Healthcheck{Type: "exec"}, MatchError("exec health check requires a command")),
+		Entry("unknown type", eiriniv1.Healthcheck{Type: "foo"}, MatchError(`unsupported health check type "foo"`)),
+	)
 })
@@ -0,0 +1,33 @@
+package reconciler
+
+import (
+	"context"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ConflictRequeuer requeues requests whose reconcile failed on a conflicting
+// update after a fixed delay. Conflicts only mean the cache was behind, so
+// retrying them soon beats backing off as for other failures.
+type ConflictRequeuer struct {
+	reconciler reconcile.Reconciler
+	delay      time.Duration
+}
+
+func NewConflictRequeuer(reconciler reconcile.Reconciler, delay time.Duration) *ConflictRequeuer {
+	return &ConflictRequeuer{
+		reconciler: reconciler,
+		delay:      delay,
+	}
+}
+
+func (r *ConflictRequeuer) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	result, err := r.reconciler.Reconcile(ctx, request)
+	if k8serrors.IsConflict(err) {
+		return reconcile.Result{RequeueAfter: r.delay}, nil
+	}
+
+	return result, err
+}
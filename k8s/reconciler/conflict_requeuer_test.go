@@ -0,0 +1,66 @@
+package reconciler_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/eirini-controller/k8s/reconciler"
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("ConflictRequeuer", func() {
+	var (
+		innerResult reconcile.Result
+		innerErr    error
+		result      reconcile.Result
+		err         error
+	)
+
+	BeforeEach(func() {
+		innerResult = reconcile.Result{RequeueAfter: time.Minute}
+		innerErr = nil
+	})
+
+	JustBeforeEach(func() {
+		inner := reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+			return innerResult, innerErr
+		})
+
+		requeuer := reconciler.NewConflictRequeuer(inner, 100*time.Millisecond)
+		result, err = requeuer.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "name"}})
+	})
+
+	It("returns the result of the reconciler", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(innerResult))
+	})
+
+	When("the reconciler fails", func() {
+		BeforeEach(func() {
+			innerErr = errors.New("boom")
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(MatchError("boom"))
+		})
+	})
+
+	When("the reconciler fails on a conflict", func() {
+		BeforeEach(func() {
+			conflict := k8serrors.NewConflict(eiriniv1.SchemeGroupVersion.WithResource("lrps").GroupResource(), "name", errors.New("stale"))
+			innerErr = fmt.Errorf("failed to update: %w", conflict)
+		})
+
+		It("requeues the request after the delay", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{RequeueAfter: 100 * time.Millisecond}))
+		})
+	})
+})
@@ -0,0 +1,38 @@
+package reconciler
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+// InstanceStatePredicate keeps the pod events that change the instance
+// statuses of an LRP: pods coming and going, changing state or being
+// scheduled on a node.
+type InstanceStatePredicate struct{}
+
+func NewInstanceStatePredicate() InstanceStatePredicate {
+	return InstanceStatePredicate{}
+}
+
+func (InstanceStatePredicate) Update(e event.UpdateEvent) bool {
+	oldPod, oldOK := e.ObjectOld.(*corev1.Pod)
+	newPod, newOK := e.ObjectNew.(*corev1.Pod)
+
+	if !oldOK || !newOK {
+		return false
+	}
+
+	return InstanceState(oldPod) != InstanceState(newPod) || oldPod.Spec.NodeName != newPod.Spec.NodeName
+}
+
+func (InstanceStatePredicate) Create(event.CreateEvent) bool {
+	return true
+}
+
+func (InstanceStatePredicate) Delete(event.DeleteEvent) bool {
+	return true
+}
+
+func (InstanceStatePredicate) Generic(event.GenericEvent) bool {
+	return false
+}
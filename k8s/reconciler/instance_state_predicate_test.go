@@ -0,0 +1,59 @@
+package reconciler_test
+
+import (
+	"code.cloudfoundry.org/eirini-controller/k8s/reconciler"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+var _ = Describe("InstanceStatePredicate", func() {
+	var (
+		predicate reconciler.InstanceStatePredicate
+		oldPod    *corev1.Pod
+		newPod    *corev1.Pod
+	)
+
+	BeforeEach(func() {
+		predicate = reconciler.NewInstanceStatePredicate()
+
+		started := true
+		oldPod = &corev1.Pod{
+			Spec: corev1.PodSpec{NodeName: "node-1"},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:    "opi",
+					Ready:   true,
+					Started: &started,
+					State:   corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+				}},
+			},
+		}
+		newPod = oldPod.DeepCopy()
+	})
+
+	It("allows creations and deletions", func() {
+		Expect(predicate.Create(event.CreateEvent{Object: newPod})).To(BeTrue())
+		Expect(predicate.Delete(event.DeleteEvent{Object: newPod})).To(BeTrue())
+		Expect(predicate.Generic(event.GenericEvent{Object: newPod})).To(BeFalse())
+	})
+
+	It("rejects updates leaving the instance state unchanged", func() {
+		newPod.Annotations = map[string]string{"foo": "bar"}
+
+		Expect(predicate.Update(event.UpdateEvent{ObjectOld: oldPod, ObjectNew: newPod})).To(BeFalse())
+	})
+
+	It("allows updates changing the instance state", func() {
+		newPod.Status.ContainerStatuses[0].Ready = false
+
+		Expect(predicate.Update(event.UpdateEvent{ObjectOld: oldPod, ObjectNew: newPod})).To(BeTrue())
+	})
+
+	It("allows updates changing the node", func() {
+		newPod.Spec.NodeName = "node-2"
+
+		Expect(predicate.Update(event.UpdateEvent{ObjectOld: oldPod, ObjectNew: newPod})).To(BeTrue())
+	})
+})
@@ -2,13 +2,18 @@ package reconciler
 
 import (
 	"context"
+	"sort"
 
+	"code.cloudfoundry.org/eirini-controller/k8s/stset"
 	"code.cloudfoundry.org/eirini-controller/k8s/utils"
 	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"code.cloudfoundry.org/eirini-controller/tracing"
+	"code.cloudfoundry.org/eirini-controller/util"
 	"code.cloudfoundry.org/lager"
 	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -63,11 +68,15 @@ func (r *LRP) Reconcile(ctx context.Context, request reconcile.Request) (reconci
 		return reconcile.Result{}, errors.Wrap(err, "failed to get lrp")
 	}
 
+	ctx, span := tracing.StartFromObject(ctx, "reconciler.LRP.Reconcile", lrp)
+
 	err = r.do(ctx, lrp)
 	if err != nil {
 		logger.Error("failed-to-reconcile", err)
 	}
 
+	tracing.End(span, err)
+
 	return reconcile.Result{}, err
 }
 
@@ -102,8 +111,108 @@ func (r *LRP) do(ctx context.Context, lrp *eiriniv1.LRP) error {
 }
 
 func (r *LRP) updateLRPStatus(ctx context.Context, lrp *eiriniv1.LRP, stSet *appsv1.StatefulSet) error {
+	instances, err := r.getInstanceStatuses(ctx, lrp)
+	if err != nil {
+		return errors.Wrap(err, "failed to get instance statuses")
+	}
+
 	originalLRP := lrp.DeepCopy()
 	lrp.Status.Replicas = stSet.Status.ReadyReplicas
+	lrp.Status.Instances = instances
 
 	return r.client.Status().Patch(ctx, lrp, client.MergeFrom(originalLRP))
 }
+
+func (r *LRP) getInstanceStatuses(ctx context.Context, lrp *eiriniv1.LRP) ([]eiriniv1.LRPInstanceStatus, error) {
+	pods := &corev1.PodList{}
+
+	err := r.client.List(ctx, pods,
+		client.InNamespace(lrp.Namespace),
+		client.MatchingLabels(stset.StatefulSetLabelSelector(lrp).MatchLabels),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list pods")
+	}
+
+	instances := []eiriniv1.LRPInstanceStatus{}
+	zones := map[string]string{}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+
+		index, err := util.ParseAppIndex(pod.Name)
+		if err != nil {
+			continue
+		}
+
+		zone, err := r.getNodeZone(ctx, pod.Spec.NodeName, zones)
+		if err != nil {
+			return nil, err
+		}
+
+		instances = append(instances, eiriniv1.LRPInstanceStatus{
+			Index:   index,
+			PodName: pod.Name,
+			State:   InstanceState(pod),
+			Node:    pod.Spec.NodeName,
+			Zone:    zone,
+		})
+	}
+
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].Index < instances[j].Index
+	})
+
+	return instances, nil
+}
+
+// getNodeZone returns the zone of the node, caching it in zones as the
+// instances of an LRP usually share nodes. Unscheduled pods and nodes that
+// are gone have no zone.
+func (r *LRP) getNodeZone(ctx context.Context, nodeName string, zones map[string]string) (string, error) {
+	if nodeName == "" {
+		return "", nil
+	}
+
+	if zone, ok := zones[nodeName]; ok {
+		return zone, nil
+	}
+
+	node := &corev1.Node{}
+
+	err := r.client.Get(ctx, client.ObjectKey{Name: nodeName}, node)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return "", errors.Wrap(err, "failed to get node")
+	}
+
+	zones[nodeName] = node.Labels[corev1.LabelTopologyZone]
+
+	return zones[nodeName], nil
+}
+
+// InstanceState tells a crashed instance from one that is up but failing
+// its readiness check, so that the latter is not reported as a crash.
+func InstanceState(pod *corev1.Pod) string {
+	var status *corev1.ContainerStatus
+
+	for i := range pod.Status.ContainerStatuses {
+		if pod.Status.ContainerStatuses[i].Name == stset.ApplicationContainerName {
+			status = &pod.Status.ContainerStatuses[i]
+		}
+	}
+
+	switch {
+	case status == nil:
+		return eiriniv1.InstanceStateStarting
+	case status.State.Terminated != nil:
+		return eiriniv1.InstanceStateCrashed
+	case status.State.Waiting != nil && status.LastTerminationState.Terminated != nil:
+		return eiriniv1.InstanceStateCrashed
+	case status.State.Running == nil || status.Started == nil || !*status.Started:
+		return eiriniv1.InstanceStateStarting
+	case !status.Ready:
+		return eiriniv1.InstanceStateUnready
+	default:
+		return eiriniv1.InstanceStateRunning
+	}
+}
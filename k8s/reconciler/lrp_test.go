@@ -7,6 +7,7 @@ import (
 	"code.cloudfoundry.org/eirini-controller/k8s/k8sfakes"
 	"code.cloudfoundry.org/eirini-controller/k8s/reconciler"
 	"code.cloudfoundry.org/eirini-controller/k8s/reconciler/reconcilerfakes"
+	"code.cloudfoundry.org/eirini-controller/k8s/stset"
 	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
 	"code.cloudfoundry.org/eirini-controller/tests"
 	"code.cloudfoundry.org/lager"
@@ -37,6 +38,7 @@ var _ = Describe("reconciler.LRP", func() {
 		getLrpError   error
 		statefulSet   *appsv1.StatefulSet
 		getStSetError error
+		getNodeError  error
 	)
 
 	BeforeEach(func() {
@@ -125,7 +127,8 @@ var _ = Describe("reconciler.LRP", func() {
 
 		getLrpError = nil
 		getStSetError = apierrors.NewNotFound(schema.GroupResource{}, "not found")
-		client.GetStub = func(_ context.Context, _ types.NamespacedName, object k8sclient.Object) error {
+		getNodeError = nil
+		client.GetStub = func(_ context.Context, key types.NamespacedName, object k8sclient.Object) error {
 			lrpPtr, ok := object.(*eiriniv1.LRP)
 			if ok {
 				if getLrpError != nil {
@@ -156,6 +159,18 @@ var _ = Describe("reconciler.LRP", func() {
 				return nil
 			}
 
+			nodePtr, ok := object.(*corev1.Node)
+			if ok {
+				if getNodeError != nil {
+					return getNodeError
+				}
+
+				nodePtr.Name = key.Name
+				nodePtr.Labels = map[string]string{corev1.LabelTopologyZone: "zone-of-" + key.Name}
+
+				return nil
+			}
+
 			Fail(fmt.Sprintf("Unexpected object: %v", object))
 
 			return nil
@@ -264,6 +279,118 @@ var _ = Describe("reconciler.LRP", func() {
 			Expect(updater.UpdateCallCount()).To(Equal(1))
 		})
 
+		When("the statefulset has pods", func() {
+			var pods []corev1.Pod
+
+			BeforeEach(func() {
+				started, notStarted := true, false
+				pods = []corev1.Pod{
+					appPod("app-2", corev1.ContainerStatus{
+						State:   corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+						Started: &started,
+						Ready:   false,
+					}),
+					appPod("app-0", corev1.ContainerStatus{
+						State:   corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+						Started: &started,
+						Ready:   true,
+					}),
+					appPod("app-1", corev1.ContainerStatus{
+						State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+						LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1}},
+					}),
+					appPod("app-3", corev1.ContainerStatus{
+						State:   corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+						Started: &notStarted,
+					}),
+				}
+
+				pods[0].Spec.NodeName = "node-a"
+				pods[1].Spec.NodeName = "node-b"
+				pods[2].Spec.NodeName = "node-a"
+
+				client.ListStub = func(_ context.Context, list k8sclient.ObjectList, _ ...k8sclient.ListOption) error {
+					podList, ok := list.(*corev1.PodList)
+					Expect(ok).To(BeTrue())
+					podList.Items = pods
+
+					return nil
+				}
+			})
+
+			It("lists the pods of the LRP", func() {
+				Expect(client.ListCallCount()).To(Equal(1))
+				_, _, opts := client.ListArgsForCall(0)
+				Expect(opts).To(ContainElements(
+					k8sclient.InNamespace("some-ns"),
+					k8sclient.MatchingLabels{
+						stset.LabelGUID:       "the-lrp-guid",
+						stset.LabelVersion:    "the-lrp-version",
+						stset.LabelSourceType: stset.AppSourceType,
+					},
+				))
+			})
+
+			It("reports the state of each instance", func() {
+				Expect(statusWriter.PatchCallCount()).To(Equal(1))
+				_, actualObject, _, _ := statusWriter.PatchArgsForCall(0)
+				actualLrp, ok := actualObject.(*eiriniv1.LRP)
+				Expect(ok).To(BeTrue())
+				Expect(actualLrp.Status.Instances).To(Equal([]eiriniv1.LRPInstanceStatus{
+					{Index: 0, PodName: "app-0", State: eiriniv1.InstanceStateRunning, Node: "node-b", Zone: "zone-of-node-b"},
+					{Index: 1, PodName: "app-1", State: eiriniv1.InstanceStateCrashed, Node: "node-a", Zone: "zone-of-node-a"},
+					{Index: 2, PodName: "app-2", State: eiriniv1.InstanceStateUnready, Node: "node-a", Zone: "zone-of-node-a"},
+					{Index: 3, PodName: "app-3", State: eiriniv1.InstanceStateStarting},
+				}))
+			})
+
+			It("gets each node only once", func() {
+				nodeGets := 0
+
+				for i := 0; i < client.GetCallCount(); i++ {
+					_, _, obj := client.GetArgsForCall(i)
+					if _, ok := obj.(*corev1.Node); ok {
+						nodeGets++
+					}
+				}
+
+				Expect(nodeGets).To(Equal(2))
+			})
+
+			When("a node is gone", func() {
+				BeforeEach(func() {
+					getNodeError = apierrors.NewNotFound(schema.GroupResource{}, "node-a")
+				})
+
+				It("reports the node without a zone", func() {
+					_, actualObject, _, _ := statusWriter.PatchArgsForCall(0)
+					actualLrp := actualObject.(*eiriniv1.LRP)
+					Expect(actualLrp.Status.Instances[1].Node).To(Equal("node-a"))
+					Expect(actualLrp.Status.Instances[1].Zone).To(BeEmpty())
+				})
+			})
+
+			When("getting a node fails", func() {
+				BeforeEach(func() {
+					getNodeError = errors.New("node-boom")
+				})
+
+				It("returns an error", func() {
+					Expect(resultErr).To(MatchError(ContainSubstring("node-boom")))
+				})
+			})
+
+			When("listing the pods fails", func() {
+				BeforeEach(func() {
+					client.ListReturns(errors.New("list-boom"))
+				})
+
+				It("returns an error", func() {
+					Expect(resultErr).To(MatchError(ContainSubstring("list-boom")))
+				})
+			})
+		})
+
 		When("the workload client fails to update the app", func() {
 			BeforeEach(func() {
 				updater.UpdateReturns(errors.New("boom"))
@@ -338,3 +465,14 @@ var _ = Describe("reconciler.LRP", func() {
 		})
 	})
 })
+
+func appPod(name string, appStatus corev1.ContainerStatus) corev1.Pod {
+	appStatus.Name = stset.ApplicationContainerName
+
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{appStatus},
+		},
+	}
+}
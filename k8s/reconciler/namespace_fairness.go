@@ -0,0 +1,120 @@
+package reconciler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"golang.org/x/time/rate"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// NamespaceFairness rate limits the reconciles of every namespace on its
+// own. Requests of a namespace over its rate are requeued for when it has
+// capacity again, freeing the workers for the other namespaces.
+type NamespaceFairness struct {
+	logger     lager.Logger
+	reconciler reconcile.Reconciler
+	limit      rate.Limit
+	burst      int
+	refill     time.Duration
+	clock      clock.PassiveClock
+
+	mutex     sync.Mutex
+	limiters  map[string]*namespaceLimiter
+	nextPrune time.Time
+}
+
+// namespaceLimiter is the limiter of a namespace, which is full again by
+// idleAt unless the namespace reconciles again.
+type namespaceLimiter struct {
+	limiter *rate.Limiter
+	idleAt  time.Time
+}
+
+// NewNamespaceFairness allows reconcilesPerSecond reconciles per namespace,
+// in bursts of up to burst reconciles, or of one when burst is not positive.
+func NewNamespaceFairness(
+	logger lager.Logger,
+	reconciler reconcile.Reconciler,
+	reconcilesPerSecond float64,
+	burst int,
+	clck clock.PassiveClock,
+) *NamespaceFairness {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &NamespaceFairness{
+		logger:     logger.Session("namespace-fairness"),
+		reconciler: reconciler,
+		limit:      rate.Limit(reconcilesPerSecond),
+		burst:      burst,
+		refill:     time.Duration(float64(burst) / reconcilesPerSecond * float64(time.Second)),
+		clock:      clck,
+		limiters:   map[string]*namespaceLimiter{},
+	}
+}
+
+func (f *NamespaceFairness) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	if delay := f.delay(request.Namespace); delay > 0 {
+		f.logger.Debug("namespace-throttled", lager.Data{"request": request, "delay": delay.String()})
+
+		return reconcile.Result{RequeueAfter: delay}, nil
+	}
+
+	return f.reconciler.Reconcile(ctx, request)
+}
+
+// delay takes a token of the namespace and returns zero when there is one,
+// or how long until there is otherwise.
+func (f *NamespaceFairness) delay(namespace string) time.Duration {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	now := f.clock.Now()
+	f.prune(now)
+
+	limiter, ok := f.limiters[namespace]
+	if !ok {
+		limiter = &namespaceLimiter{limiter: rate.NewLimiter(f.limit, f.burst)}
+		f.limiters[namespace] = limiter
+	}
+
+	if limiter.limiter.AllowN(now, 1) {
+		limiter.idleAt = now.Add(f.refill)
+
+		return 0
+	}
+
+	reservation := limiter.limiter.ReserveN(now, 1)
+	defer reservation.CancelAt(now)
+
+	return reservation.DelayFrom(now)
+}
+
+// prune forgets the limiters of the namespaces whose queue has drained: a
+// full limiter behaves as a new one. It sweeps at most once per refill.
+func (f *NamespaceFairness) prune(now time.Time) {
+	if now.Before(f.nextPrune) {
+		return
+	}
+
+	for namespace, limiter := range f.limiters {
+		if !now.Before(limiter.idleAt) {
+			delete(f.limiters, namespace)
+		}
+	}
+
+	f.nextPrune = now.Add(f.refill)
+}
+
+// Namespaces returns the number of namespaces being rate limited.
+func (f *NamespaceFairness) Namespaces() int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return len(f.limiters)
+}
@@ -0,0 +1,93 @@
+package reconciler_test
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/eirini-controller/k8s/reconciler"
+	"code.cloudfoundry.org/eirini-controller/tests"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	clock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("NamespaceFairness", func() {
+	var (
+		reconciled []reconcile.Request
+		fakeClock  *clock.FakePassiveClock
+		fairness   *reconciler.NamespaceFairness
+	)
+
+	request := func(namespace string) reconcile.Request {
+		return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: "the-name"}}
+	}
+
+	reconcileIn := func(namespace string) reconcile.Result {
+		result, err := fairness.Reconcile(context.Background(), request(namespace))
+		Expect(err).NotTo(HaveOccurred())
+
+		return result
+	}
+
+	BeforeEach(func() {
+		reconciled = nil
+		fakeClock = clock.NewFakePassiveClock(time.Now())
+		inner := reconcile.Func(func(_ context.Context, request reconcile.Request) (reconcile.Result, error) {
+			reconciled = append(reconciled, request)
+
+			return reconcile.Result{}, nil
+		})
+
+		fairness = reconciler.NewNamespaceFairness(tests.NewTestLogger("namespace-fairness"), inner, 2, 2, fakeClock)
+	})
+
+	It("reconciles requests within the namespace rate", func() {
+		Expect(reconcileIn("busy")).To(Equal(reconcile.Result{}))
+		Expect(reconcileIn("busy")).To(Equal(reconcile.Result{}))
+		Expect(reconciled).To(HaveLen(2))
+	})
+
+	It("requeues requests over the namespace rate", func() {
+		reconcileIn("busy")
+		reconcileIn("busy")
+
+		Expect(reconcileIn("busy").RequeueAfter).To(BeNumerically("~", 500*time.Millisecond, time.Millisecond))
+		Expect(reconciled).To(HaveLen(2))
+	})
+
+	It("does not consume tokens for requeued requests", func() {
+		reconcileIn("busy")
+		reconcileIn("busy")
+		reconcileIn("busy")
+		reconcileIn("busy")
+
+		fakeClock.SetTime(fakeClock.Now().Add(500 * time.Millisecond))
+		Expect(reconcileIn("busy")).To(Equal(reconcile.Result{}))
+		Expect(reconciled).To(HaveLen(3))
+	})
+
+	It("keeps reconciling the other namespaces", func() {
+		reconcileIn("busy")
+		reconcileIn("busy")
+		reconcileIn("busy")
+
+		Expect(reconcileIn("quiet")).To(Equal(reconcile.Result{}))
+		Expect(reconciled).To(ConsistOf(request("busy"), request("busy"), request("quiet")))
+	})
+
+	It("forgets the namespaces once their queue has drained", func() {
+		reconcileIn("busy")
+		reconcileIn("quiet")
+		Expect(fairness.Namespaces()).To(Equal(2))
+
+		fakeClock.SetTime(fakeClock.Now().Add(time.Second))
+		reconcileIn("busy")
+		Expect(fairness.Namespaces()).To(Equal(1))
+
+		fakeClock.SetTime(fakeClock.Now().Add(time.Second))
+		reconcileIn("other")
+		Expect(fairness.Namespaces()).To(Equal(1))
+	})
+})
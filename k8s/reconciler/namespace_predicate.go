@@ -0,0 +1,33 @@
+package reconciler
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// NamespaceMatcher tells whether the controller serves the workloads of a
+// namespace, e.g. because it is selected or belongs to a held shard.
+type NamespaceMatcher interface {
+	Includes(ctx context.Context, namespace string) (bool, error)
+}
+
+// NewNamespacePredicate filters out the events of objects living in
+// namespaces the controller does not serve. Namespaces that cannot be
+// checked are left out until the next event or resync.
+func NewNamespacePredicate(logger lager.Logger, namespaceMatcher NamespaceMatcher) predicate.Predicate {
+	logger = logger.Session("namespace-predicate")
+
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		included, err := namespaceMatcher.Includes(context.Background(), obj.GetNamespace())
+		if err != nil {
+			logger.Error("failed-to-check-namespace", err, lager.Data{"namespace": obj.GetNamespace()})
+
+			return false
+		}
+
+		return included
+	})
+}
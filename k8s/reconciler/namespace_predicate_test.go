@@ -0,0 +1,73 @@
+package reconciler_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/eirini-controller/k8s"
+	"code.cloudfoundry.org/eirini-controller/k8s/k8sfakes"
+	"code.cloudfoundry.org/eirini-controller/k8s/reconciler"
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"code.cloudfoundry.org/eirini-controller/tests"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+var _ = Describe("NamespacePredicate", func() {
+	var (
+		k8sClient   *k8sfakes.FakeClient
+		nsLabels    map[string]string
+		lrp         *eiriniv1.LRP
+		nsPredicate predicate.Predicate
+	)
+
+	BeforeEach(func() {
+		k8sClient = new(k8sfakes.FakeClient)
+		nsLabels = map[string]string{"eirini.cloudfoundry.org/workloads": "true"}
+		k8sClient.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			Expect(key).To(Equal(client.ObjectKey{Name: "the-namespace"}))
+			obj.(*corev1.Namespace).Labels = nsLabels
+
+			return nil
+		}
+
+		lrp = &eiriniv1.LRP{ObjectMeta: metav1.ObjectMeta{Namespace: "the-namespace", Name: "the-lrp"}}
+
+		namespaceFilter, err := k8s.NewNamespaceFilter(k8sClient, nil, "eirini.cloudfoundry.org/workloads=true")
+		Expect(err).NotTo(HaveOccurred())
+
+		nsPredicate = reconciler.NewNamespacePredicate(tests.NewTestLogger("namespace-predicate"), namespaceFilter)
+	})
+
+	It("accepts the events of objects in selected namespaces", func() {
+		Expect(nsPredicate.Create(event.CreateEvent{Object: lrp})).To(BeTrue())
+		Expect(nsPredicate.Update(event.UpdateEvent{ObjectOld: lrp, ObjectNew: lrp})).To(BeTrue())
+	})
+
+	When("the namespace does not match the selector", func() {
+		BeforeEach(func() {
+			nsLabels = map[string]string{"eirini.cloudfoundry.org/workloads": "false"}
+		})
+
+		It("rejects the events", func() {
+			Expect(nsPredicate.Create(event.CreateEvent{Object: lrp})).To(BeFalse())
+			Expect(nsPredicate.Delete(event.DeleteEvent{Object: lrp})).To(BeFalse())
+		})
+	})
+
+	When("getting the namespace fails", func() {
+		BeforeEach(func() {
+			k8sClient.GetStub = nil
+			k8sClient.GetReturns(errors.New("boom"))
+		})
+
+		It("rejects the events", func() {
+			Expect(nsPredicate.Create(event.CreateEvent{Object: lrp})).To(BeFalse())
+		})
+	})
+})
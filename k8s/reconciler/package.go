@@ -6,4 +6,8 @@ const (
 	IndexEventInvolvedObjectName = ".index.eventOwner.name"
 	IndexEventInvolvedObjectKind = ".index.eventOwner.kind"
 	IndexEventReason             = ".index.reason"
+
+	// LabelInstanceIndex labels the crash events with the index of the
+	// crashed instance
+	LabelInstanceIndex = "korifi.cloudfoundry.org/instance-index"
 )
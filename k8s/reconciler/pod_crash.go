@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/eirini-controller/k8s/stset"
+	"code.cloudfoundry.org/eirini-controller/tracing"
 	"code.cloudfoundry.org/lager"
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
@@ -20,7 +21,6 @@ import (
 const (
 	eiriniControllerSource = "eirini-controller"
 	crashEventType         = "Warning"
-	labelInstanceIndex     = "korifi.cloudfoundry.org/instance-index"
 	action                 = "crashing"
 	lrpKind                = "LRP"
 	statefulSetKind        = "StatefulSet"
@@ -72,6 +72,14 @@ func (r PodCrash) Reconcile(ctx context.Context, request reconcile.Request) (rec
 		return reconcile.Result{}, errors.Wrap(err, "failed to get pod")
 	}
 
+	ctx, span := tracing.Start(ctx, "reconciler.PodCrash.Reconcile", pod)
+	result, err := r.reconcilePod(ctx, logger, pod)
+	tracing.End(span, err)
+
+	return result, err
+}
+
+func (r PodCrash) reconcilePod(ctx context.Context, logger lager.Logger, pod *corev1.Pod) (reconcile.Result, error) {
 	crashEvent := r.crashEventGenerator.Generate(ctx, pod, logger)
 	if crashEvent == nil {
 		return reconcile.Result{}, nil
@@ -104,14 +112,14 @@ func (r PodCrash) Reconcile(ctx context.Context, request reconcile.Request) (rec
 		return reconcile.Result{}, nil //nolint:nilerr
 	}
 
-	kubeEvent, err := r.getByInstanceAndReason(ctx, request.Namespace, lrpRef, crashEvent.Index, failureReason(crashEvent))
+	kubeEvent, err := r.getByInstanceAndReason(ctx, pod.Namespace, lrpRef, crashEvent.Index, failureReason(crashEvent))
 	if err != nil {
 		logger.Error("failed-to-get-existing-event", err)
 
 		return reconcile.Result{}, errors.Wrap(err, "failed to get existing event")
 	}
 
-	err = r.setEvent(ctx, logger, kubeEvent, lrpRef, crashEvent, request.Namespace)
+	err = r.setEvent(ctx, logger, kubeEvent, lrpRef, crashEvent, pod.Namespace)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -126,7 +134,7 @@ func (r PodCrash) getByInstanceAndReason(ctx context.Context, namespace string,
 
 	err := r.client.List(ctx, kubeEvents,
 		client.MatchingLabels{
-			labelInstanceIndex: strconv.Itoa(instanceIndex),
+			LabelInstanceIndex: strconv.Itoa(instanceIndex),
 		},
 		client.InNamespace(namespace),
 		client.MatchingFields{
@@ -182,7 +190,7 @@ func (r PodCrash) createEvent(ctx context.Context, logger lager.Logger, ownerRef
 			Namespace:    namespace,
 			GenerateName: fmt.Sprintf("%s-", crashEvent.Instance),
 			Labels: map[string]string{
-				labelInstanceIndex: strconv.Itoa(crashEvent.Index),
+				LabelInstanceIndex: strconv.Itoa(crashEvent.Index),
 			},
 			Annotations: map[string]string{
 				stset.AnnotationProcessGUID: crashEvent.ProcessGUID,
@@ -3,10 +3,13 @@ package reconciler
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"time"
 
+	"code.cloudfoundry.org/eirini-controller/k8s"
 	"code.cloudfoundry.org/eirini-controller/k8s/utils"
 	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"code.cloudfoundry.org/eirini-controller/tracing"
 	"code.cloudfoundry.org/lager"
 	exterrors "github.com/pkg/errors"
 	batchv1 "k8s.io/api/batch/v1"
@@ -22,7 +25,7 @@ type Task struct {
 	client       client.Client
 	desirer      TaskDesirer
 	statusGetter TaskStatusGetter
-	ttlSeconds   int
+	ttlSeconds   int64
 }
 
 //counterfeiter:generate . TaskDesirer
@@ -48,10 +51,16 @@ func NewTask(logger lager.Logger,
 		client:       client,
 		desirer:      desirer,
 		statusGetter: statusGetter,
-		ttlSeconds:   ttlSeconds,
+		ttlSeconds:   int64(ttlSeconds),
 	}
 }
 
+// SetTTLSeconds changes how long completed tasks are kept when their
+// namespace does not set it.
+func (t *Task) SetTTLSeconds(ttlSeconds int) {
+	atomic.StoreInt64(&t.ttlSeconds, int64(ttlSeconds))
+}
+
 func (t *Task) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	logger := t.logger.Session("reconcile-task", lager.Data{"request": request})
 	logger.Debug("start")
@@ -71,6 +80,14 @@ func (t *Task) Reconcile(ctx context.Context, request reconcile.Request) (reconc
 		return reconcile.Result{}, fmt.Errorf("could not fetch task: %w", err)
 	}
 
+	ctx, span := tracing.StartFromObject(ctx, "reconciler.Task.Reconcile", task)
+	result, err := t.reconcileTask(ctx, logger, task)
+	tracing.End(span, err)
+
+	return result, err
+}
+
+func (t *Task) reconcileTask(ctx context.Context, logger lager.Logger, task *eiriniv1.Task) (reconcile.Result, error) {
 	if taskHasCompleted(task) {
 		logger.Debug("handling-task-completion")
 
@@ -79,7 +96,7 @@ func (t *Task) Reconcile(ctx context.Context, request reconcile.Request) (reconc
 
 	job := &batchv1.Job{}
 
-	err = t.client.Get(ctx, client.ObjectKey{Namespace: task.Namespace, Name: utils.GetJobName(task)}, job)
+	err := t.client.Get(ctx, client.ObjectKey{Namespace: task.Namespace, Name: utils.GetJobName(task)}, job)
 	if errors.IsNotFound(err) {
 		logger.Debug("desiring-task")
 
@@ -101,7 +118,14 @@ func (t *Task) Reconcile(ctx context.Context, request reconcile.Request) (reconc
 	if taskHasCompleted(task) {
 		logger.Debug("queueing-deletion")
 
-		return reconcile.Result{RequeueAfter: time.Duration(t.ttlSeconds) * time.Second}, nil
+		ttl, err := t.ttl(ctx, task.Namespace)
+		if err != nil {
+			logger.Error("get-ttl-failed", err)
+
+			return reconcile.Result{}, err
+		}
+
+		return reconcile.Result{RequeueAfter: ttl}, nil
 	}
 
 	return reconcile.Result{}, nil
@@ -140,7 +164,14 @@ func (t *Task) updateTaskStatus(ctx context.Context, task *eiriniv1.Task, job *b
 }
 
 func (t *Task) handleExpiredTask(ctx context.Context, logger lager.Logger, task *eiriniv1.Task) (reconcile.Result, error) {
-	if t.taskHasExpired(task) {
+	ttl, err := t.ttl(ctx, task.Namespace)
+	if err != nil {
+		logger.Error("get-ttl-failed", err)
+
+		return reconcile.Result{}, err
+	}
+
+	if taskHasExpired(task, ttl) {
 		logger.Debug("deleting-expired-job")
 
 		err := t.client.DeleteAllOf(ctx, &batchv1.Job{}, client.InNamespace(task.Namespace), client.MatchingFields{"metadata.name": utils.GetJobName(task)})
@@ -159,8 +190,23 @@ func taskHasCompleted(task *eiriniv1.Task) bool {
 		meta.IsStatusConditionTrue(task.Status.Conditions, eiriniv1.TaskFailedConditionType)
 }
 
-func (t *Task) taskHasExpired(task *eiriniv1.Task) bool {
-	ttlExpire := metav1.NewTime(time.Now().Add(-time.Duration(t.ttlSeconds) * time.Second))
+// ttl returns how long completed tasks of the namespace are kept, as set by
+// its WorkloadDefaults or the controller configuration.
+func (t *Task) ttl(ctx context.Context, namespace string) (time.Duration, error) {
+	defaults, err := k8s.GetWorkloadDefaults(ctx, t.client, namespace)
+	if err != nil {
+		return 0, err
+	}
+
+	if defaults.TaskTTLSeconds != nil {
+		return time.Duration(*defaults.TaskTTLSeconds) * time.Second, nil
+	}
+
+	return time.Duration(atomic.LoadInt64(&t.ttlSeconds)) * time.Second, nil
+}
+
+func taskHasExpired(task *eiriniv1.Task, ttl time.Duration) bool {
+	ttlExpire := metav1.NewTime(time.Now().Add(-ttl))
 
 	condition := meta.FindStatusCondition(task.Status.Conditions, eiriniv1.TaskSucceededConditionType)
 	if condition != nil {
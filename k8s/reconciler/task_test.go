@@ -24,18 +24,19 @@ import (
 
 var _ = Describe("Task", func() {
 	var (
-		taskReconciler  *reconciler.Task
-		reconcileResult reconcile.Result
-		reconcileErr    error
-		getTaskErr      error
-		getJobErr       error
-		namespacedName  types.NamespacedName
-		task            *eiriniv1.Task
-		ttlSeconds      int
-		k8sClient       *k8sfakes.FakeClient
-		statusWriter    *k8sfakes.FakeStatusWriter
-		desirer         *reconcilerfakes.FakeTaskDesirer
-		statusGetter    *reconcilerfakes.FakeTaskStatusGetter
+		taskReconciler   *reconciler.Task
+		reconcileResult  reconcile.Result
+		reconcileErr     error
+		getTaskErr       error
+		getJobErr        error
+		namespacedName   types.NamespacedName
+		task             *eiriniv1.Task
+		ttlSeconds       int
+		workloadDefaults *eiriniv1.WorkloadDefaults
+		k8sClient        *k8sfakes.FakeClient
+		statusWriter     *k8sfakes.FakeStatusWriter
+		desirer          *reconcilerfakes.FakeTaskDesirer
+		statusGetter     *reconcilerfakes.FakeTaskStatusGetter
 	)
 
 	BeforeEach(func() {
@@ -44,6 +45,7 @@ var _ = Describe("Task", func() {
 		k8sClient.StatusReturns(statusWriter)
 
 		getTaskErr = nil
+		workloadDefaults = nil
 		getJobErr = k8serrors.NewNotFound(schema.GroupResource{}, "not found")
 		k8sClient.GetStub = func(_ context.Context, _ types.NamespacedName, o client.Object) error {
 			taskPtr, ok := o.(*eiriniv1.Task)
@@ -66,6 +68,16 @@ var _ = Describe("Task", func() {
 				return nil
 			}
 
+			defaultsPtr, ok := o.(*eiriniv1.WorkloadDefaults)
+			if ok {
+				if workloadDefaults == nil {
+					return k8serrors.NewNotFound(schema.GroupResource{}, "default")
+				}
+				workloadDefaults.DeepCopyInto(defaultsPtr)
+
+				return nil
+			}
+
 			Fail(fmt.Sprintf("Unsupported object: %v", o))
 
 			return nil
@@ -258,6 +270,20 @@ var _ = Describe("Task", func() {
 				Expect(k8sClient.DeleteAllOfCallCount()).To(Equal(1))
 			})
 
+			When("the namespace workload defaults set a longer ttl", func() {
+				BeforeEach(func() {
+					ttl := int32(3600)
+					workloadDefaults = &eiriniv1.WorkloadDefaults{
+						Spec: eiriniv1.WorkloadDefaultsSpec{TaskTTLSeconds: &ttl},
+					}
+				})
+
+				It("keeps the task", func() {
+					Expect(reconcileErr).NotTo(HaveOccurred())
+					Expect(k8sClient.DeleteAllOfCallCount()).To(BeZero())
+				})
+			})
+
 			When("deleting the task fails", func() {
 				BeforeEach(func() {
 					k8sClient.DeleteAllOfReturns(errors.New("boom"))
@@ -347,6 +373,29 @@ var _ = Describe("Task", func() {
 			It("requeues the event after the ttl", func() {
 				Expect(reconcileResult.RequeueAfter).To(Equal(time.Duration(ttlSeconds) * time.Second))
 			})
+
+			When("the namespace workload defaults set a ttl", func() {
+				BeforeEach(func() {
+					ttl := int32(300)
+					workloadDefaults = &eiriniv1.WorkloadDefaults{
+						Spec: eiriniv1.WorkloadDefaultsSpec{TaskTTLSeconds: &ttl},
+					}
+				})
+
+				It("requeues the event after that ttl", func() {
+					Expect(reconcileResult.RequeueAfter).To(Equal(5 * time.Minute))
+				})
+			})
+
+			When("the ttl is changed", func() {
+				BeforeEach(func() {
+					taskReconciler.SetTTLSeconds(60)
+				})
+
+				It("requeues the event after the new ttl", func() {
+					Expect(reconcileResult.RequeueAfter).To(Equal(time.Minute))
+				})
+			})
 		})
 
 		When("updating the task status returns an error", func() {
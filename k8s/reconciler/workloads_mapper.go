@@ -0,0 +1,130 @@
+package reconciler
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/eirini-controller/k8s"
+	"code.cloudfoundry.org/eirini-controller/k8s/stset"
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"code.cloudfoundry.org/lager"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// WorkloadsMapper maps changes affecting a whole namespace, such as its
+// WorkloadDefaults or labels, to requests for all the workloads of the
+// namespace.
+type WorkloadsMapper struct {
+	logger          lager.Logger
+	client          client.Client
+	namespaceFilter *k8s.NamespaceFilter
+	newList         func() client.ObjectList
+}
+
+func NewLRPsMapper(logger lager.Logger, k8sClient client.Client, namespaceFilter *k8s.NamespaceFilter) *WorkloadsMapper {
+	return &WorkloadsMapper{
+		logger:          logger.Session("lrps-mapper"),
+		client:          k8sClient,
+		namespaceFilter: namespaceFilter,
+		newList:         func() client.ObjectList { return &eiriniv1.LRPList{} },
+	}
+}
+
+func NewTasksMapper(logger lager.Logger, k8sClient client.Client, namespaceFilter *k8s.NamespaceFilter) *WorkloadsMapper {
+	return &WorkloadsMapper{
+		logger:          logger.Session("tasks-mapper"),
+		client:          k8sClient,
+		namespaceFilter: namespaceFilter,
+		newList:         func() client.ObjectList { return &eiriniv1.TaskList{} },
+	}
+}
+
+// MapWorkloadDefaults requests the workloads of the namespace of the
+// WorkloadDefaults, so that their disruption budgets and TTLs follow the new
+// defaults. Pod settings are not changed on existing workloads.
+func (m *WorkloadsMapper) MapWorkloadDefaults(obj client.Object) []reconcile.Request {
+	if obj.GetName() != eiriniv1.WorkloadDefaultsName {
+		return nil
+	}
+
+	return m.requestsFor(obj.GetNamespace())
+}
+
+// MapNamespace requests the workloads of a namespace the controller serves,
+// so that they are picked up as soon as the namespace is selected.
+func (m *WorkloadsMapper) MapNamespace(obj client.Object) []reconcile.Request {
+	namespace, ok := obj.(*corev1.Namespace)
+	if !ok || !m.namespaceFilter.IncludesNamespace(namespace) {
+		return nil
+	}
+
+	return m.requestsFor(namespace.Name)
+}
+
+// MapAppPod requests the LRP an app pod is an instance of, so that its
+// instance statuses follow the pods. It only finds LRPs, and requests nothing
+// for pods of other workloads.
+func (m *WorkloadsMapper) MapAppPod(obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+	if labels[stset.LabelSourceType] != stset.AppSourceType {
+		return nil
+	}
+
+	logger := m.logger.Session("map-app-pod", lager.Data{"namespace": obj.GetNamespace(), "name": obj.GetName()})
+
+	list := m.newList()
+	if err := m.client.List(context.Background(), list, client.InNamespace(obj.GetNamespace())); err != nil {
+		logger.Error("failed-to-list-workloads", err)
+
+		return nil
+	}
+
+	lrps, ok := list.(*eiriniv1.LRPList)
+	if !ok {
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+
+	for i := range lrps.Items {
+		lrp := &lrps.Items[i]
+		if lrp.Spec.GUID == labels[stset.LabelGUID] && lrp.Spec.Version == labels[stset.LabelVersion] {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(lrp)})
+		}
+	}
+
+	return requests
+}
+
+func (m *WorkloadsMapper) requestsFor(namespace string) []reconcile.Request {
+	logger := m.logger.Session("map", lager.Data{"namespace": namespace})
+
+	list := m.newList()
+	if err := m.client.List(context.Background(), list, client.InNamespace(namespace)); err != nil {
+		logger.Error("failed-to-list-workloads", err)
+
+		return nil
+	}
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		logger.Error("failed-to-extract-workloads", err)
+
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+
+	for _, item := range items {
+		workload, ok := item.(client.Object)
+		if !ok {
+			continue
+		}
+
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(workload)})
+	}
+
+	return requests
+}
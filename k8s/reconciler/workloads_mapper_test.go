@@ -0,0 +1,204 @@
+package reconciler_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/eirini-controller/k8s"
+	"code.cloudfoundry.org/eirini-controller/k8s/k8sfakes"
+	"code.cloudfoundry.org/eirini-controller/k8s/reconciler"
+	"code.cloudfoundry.org/eirini-controller/k8s/stset"
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"code.cloudfoundry.org/eirini-controller/tests"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("WorkloadsMapper", func() {
+	var (
+		k8sClient       *k8sfakes.FakeClient
+		namespaceFilter *k8s.NamespaceFilter
+		defaults        *eiriniv1.WorkloadDefaults
+		requests        []reconcile.Request
+	)
+
+	BeforeEach(func() {
+		k8sClient = new(k8sfakes.FakeClient)
+
+		var err error
+		namespaceFilter, err = k8s.NewNamespaceFilter(k8sClient, nil, "eirini.cloudfoundry.org/workloads=true")
+		Expect(err).NotTo(HaveOccurred())
+
+		defaults = &eiriniv1.WorkloadDefaults{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "the-namespace", Name: eiriniv1.WorkloadDefaultsName},
+		}
+	})
+
+	Describe("LRPs", func() {
+		BeforeEach(func() {
+			k8sClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+				list.(*eiriniv1.LRPList).Items = []eiriniv1.LRP{
+					{ObjectMeta: metav1.ObjectMeta{Namespace: "the-namespace", Name: "lrp-1"}},
+					{ObjectMeta: metav1.ObjectMeta{Namespace: "the-namespace", Name: "lrp-2"}},
+				}
+
+				return nil
+			}
+		})
+
+		JustBeforeEach(func() {
+			requests = reconciler.NewLRPsMapper(tests.NewTestLogger("mapper"), k8sClient, namespaceFilter).MapWorkloadDefaults(defaults)
+		})
+
+		It("requests the reconciliation of the LRPs in the namespace", func() {
+			Expect(requests).To(ConsistOf(
+				reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "the-namespace", Name: "lrp-1"}},
+				reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "the-namespace", Name: "lrp-2"}},
+			))
+
+			Expect(k8sClient.ListCallCount()).To(Equal(1))
+			_, _, opts := k8sClient.ListArgsForCall(0)
+			Expect(opts).To(ConsistOf(client.InNamespace("the-namespace")))
+		})
+
+		When("the WorkloadDefaults has another name", func() {
+			BeforeEach(func() {
+				defaults.Name = "not-honoured"
+			})
+
+			It("ignores it", func() {
+				Expect(requests).To(BeEmpty())
+				Expect(k8sClient.ListCallCount()).To(BeZero())
+			})
+		})
+
+		When("listing the LRPs fails", func() {
+			BeforeEach(func() {
+				k8sClient.ListStub = nil
+				k8sClient.ListReturns(errors.New("boom"))
+			})
+
+			It("requests nothing", func() {
+				Expect(requests).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("MapNamespace", func() {
+		var namespace *corev1.Namespace
+
+		BeforeEach(func() {
+			k8sClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+				list.(*eiriniv1.LRPList).Items = []eiriniv1.LRP{
+					{ObjectMeta: metav1.ObjectMeta{Namespace: "the-namespace", Name: "lrp-1"}},
+				}
+
+				return nil
+			}
+
+			namespace = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "the-namespace",
+				Labels: map[string]string{"eirini.cloudfoundry.org/workloads": "true"},
+			}}
+		})
+
+		JustBeforeEach(func() {
+			requests = reconciler.NewLRPsMapper(tests.NewTestLogger("mapper"), k8sClient, namespaceFilter).MapNamespace(namespace)
+		})
+
+		It("requests the reconciliation of the LRPs in the selected namespace", func() {
+			Expect(requests).To(ConsistOf(
+				reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "the-namespace", Name: "lrp-1"}},
+			))
+			_, _, opts := k8sClient.ListArgsForCall(0)
+			Expect(opts).To(ConsistOf(client.InNamespace("the-namespace")))
+		})
+
+		When("the namespace is not selected", func() {
+			BeforeEach(func() {
+				namespace.Labels = nil
+			})
+
+			It("ignores it", func() {
+				Expect(requests).To(BeEmpty())
+				Expect(k8sClient.ListCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("MapAppPod", func() {
+		var pod *corev1.Pod
+
+		BeforeEach(func() {
+			k8sClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+				list.(*eiriniv1.LRPList).Items = []eiriniv1.LRP{
+					{ObjectMeta: metav1.ObjectMeta{Namespace: "the-namespace", Name: "lrp-1"}, Spec: eiriniv1.LRPSpec{GUID: "guid", Version: "v1"}},
+					{ObjectMeta: metav1.ObjectMeta{Namespace: "the-namespace", Name: "lrp-2"}, Spec: eiriniv1.LRPSpec{GUID: "guid", Version: "v2"}},
+					{ObjectMeta: metav1.ObjectMeta{Namespace: "the-namespace", Name: "lrp-3"}, Spec: eiriniv1.LRPSpec{GUID: "other", Version: "v1"}},
+				}
+
+				return nil
+			}
+
+			pod = &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Namespace: "the-namespace",
+				Name:      "lrp-1-abcde-0",
+				Labels: map[string]string{
+					stset.LabelSourceType: stset.AppSourceType,
+					stset.LabelGUID:       "guid",
+					stset.LabelVersion:    "v1",
+				},
+			}}
+		})
+
+		JustBeforeEach(func() {
+			requests = reconciler.NewLRPsMapper(tests.NewTestLogger("mapper"), k8sClient, namespaceFilter).MapAppPod(pod)
+		})
+
+		It("requests the reconciliation of the LRP of the pod", func() {
+			Expect(requests).To(ConsistOf(
+				reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "the-namespace", Name: "lrp-1"}},
+			))
+			_, _, opts := k8sClient.ListArgsForCall(0)
+			Expect(opts).To(ConsistOf(client.InNamespace("the-namespace")))
+		})
+
+		When("the pod is not an app pod", func() {
+			BeforeEach(func() {
+				pod.Labels[stset.LabelSourceType] = "TASK"
+			})
+
+			It("ignores it", func() {
+				Expect(requests).To(BeEmpty())
+				Expect(k8sClient.ListCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("Tasks", func() {
+		BeforeEach(func() {
+			k8sClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+				list.(*eiriniv1.TaskList).Items = []eiriniv1.Task{
+					{ObjectMeta: metav1.ObjectMeta{Namespace: "the-namespace", Name: "task-1"}},
+				}
+
+				return nil
+			}
+		})
+
+		JustBeforeEach(func() {
+			requests = reconciler.NewTasksMapper(tests.NewTestLogger("mapper"), k8sClient, namespaceFilter).MapWorkloadDefaults(defaults)
+		})
+
+		It("requests the reconciliation of the tasks in the namespace", func() {
+			Expect(requests).To(ConsistOf(
+				reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "the-namespace", Name: "task-1"}},
+			))
+		})
+	})
+})
@@ -1,6 +1,19 @@
 package k8s
 
-import corev1 "k8s.io/api/core/v1"
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	tmpVolumeName = "eirini-tmp"
+	tmpMountPath  = "/tmp"
+)
 
 func toPtr(b bool) *bool {
 	return &b
@@ -18,3 +31,196 @@ func ContainerSecurityContext() *corev1.SecurityContext {
 		},
 	}
 }
+
+// SecurityPolicy applies the configured security profile to LRP and task
+// pods. Namespace profiles override the default profile field by field.
+type SecurityPolicy struct {
+	defaultProfile    eirinictrl.SecurityProfile
+	namespaceProfiles map[string]eirinictrl.SecurityProfile
+}
+
+func NewSecurityPolicy(defaultProfile eirinictrl.SecurityProfile, namespaceProfiles map[string]eirinictrl.SecurityProfile) (*SecurityPolicy, error) {
+	if err := ValidateSecurityProfile(defaultProfile); err != nil {
+		return nil, fmt.Errorf("invalid security profile: %w", err)
+	}
+
+	for namespace, profile := range namespaceProfiles {
+		if err := ValidateSecurityProfile(profile); err != nil {
+			return nil, fmt.Errorf("invalid security profile for namespace %q: %w", namespace, err)
+		}
+	}
+
+	return &SecurityPolicy{
+		defaultProfile:    defaultProfile,
+		namespaceProfiles: namespaceProfiles,
+	}, nil
+}
+
+// Apply sets the pod and container security contexts of the template. All
+// of its containers get the same context. A read-only root filesystem comes
+// with a writable emptyDir mounted at /tmp. The override, usually from the
+// namespace WorkloadDefaults, takes precedence over the configuration.
+func (p *SecurityPolicy) Apply(namespace string, template *corev1.PodTemplateSpec, override *eiriniv1.SecurityProfile) {
+	profile := p.profileFor(namespace)
+
+	if override != nil {
+		profile = mergeProfiles(profile, eirinictrl.SecurityProfile{
+			RunAsUser:              override.RunAsUser,
+			RunAsGroup:             override.RunAsGroup,
+			FSGroup:                override.FSGroup,
+			ReadOnlyRootFilesystem: override.ReadOnlyRootFilesystem,
+			AppArmorProfile:        override.AppArmorProfile,
+			SeccompProfile:         override.SeccompProfile,
+		})
+	}
+
+	template.Spec.SecurityContext = &corev1.PodSecurityContext{
+		RunAsUser:  profile.RunAsUser,
+		RunAsGroup: profile.RunAsGroup,
+		FSGroup:    profile.FSGroup,
+	}
+
+	readOnlyRootFilesystem := profile.ReadOnlyRootFilesystem != nil && *profile.ReadOnlyRootFilesystem
+	if readOnlyRootFilesystem {
+		template.Spec.Volumes = append(template.Spec.Volumes, corev1.Volume{
+			Name: tmpVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+	}
+
+	appArmorAnnotations := map[string]string{}
+
+	for i := range template.Spec.Containers {
+		container := &template.Spec.Containers[i]
+
+		container.SecurityContext = ContainerSecurityContext()
+		container.SecurityContext.SeccompProfile = seccompProfile(profile.SeccompProfile)
+
+		if readOnlyRootFilesystem {
+			container.SecurityContext.ReadOnlyRootFilesystem = toPtr(true)
+
+			if !hasMountAt(container.VolumeMounts, tmpMountPath) {
+				container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+					Name:      tmpVolumeName,
+					MountPath: tmpMountPath,
+				})
+			}
+		}
+
+		if profile.AppArmorProfile != "" {
+			appArmorAnnotations[corev1.AppArmorBetaContainerAnnotationKeyPrefix+container.Name] = profile.AppArmorProfile
+		}
+	}
+
+	if len(appArmorAnnotations) == 0 {
+		return
+	}
+
+	// The template annotations may be shared with the owning workload, which
+	// must not get the pod-only AppArmor annotations.
+	annotations := map[string]string{}
+	for k, v := range template.Annotations {
+		annotations[k] = v
+	}
+
+	for k, v := range appArmorAnnotations {
+		annotations[k] = v
+	}
+
+	template.Annotations = annotations
+}
+
+func (p *SecurityPolicy) profileFor(namespace string) eirinictrl.SecurityProfile {
+	override, ok := p.namespaceProfiles[namespace]
+	if !ok {
+		return p.defaultProfile
+	}
+
+	return mergeProfiles(p.defaultProfile, override)
+}
+
+func mergeProfiles(profile, override eirinictrl.SecurityProfile) eirinictrl.SecurityProfile {
+	if override.RunAsUser != nil {
+		profile.RunAsUser = override.RunAsUser
+	}
+
+	if override.RunAsGroup != nil {
+		profile.RunAsGroup = override.RunAsGroup
+	}
+
+	if override.FSGroup != nil {
+		profile.FSGroup = override.FSGroup
+	}
+
+	if override.ReadOnlyRootFilesystem != nil {
+		profile.ReadOnlyRootFilesystem = override.ReadOnlyRootFilesystem
+	}
+
+	if override.AppArmorProfile != "" {
+		profile.AppArmorProfile = override.AppArmorProfile
+	}
+
+	if override.SeccompProfile != "" {
+		profile.SeccompProfile = override.SeccompProfile
+	}
+
+	return profile
+}
+
+func seccompProfile(profile string) *corev1.SeccompProfile {
+	if name := strings.TrimPrefix(profile, corev1.SeccompLocalhostProfileNamePrefix); name != profile {
+		return &corev1.SeccompProfile{
+			Type:             corev1.SeccompProfileTypeLocalhost,
+			LocalhostProfile: &name,
+		}
+	}
+
+	return &corev1.SeccompProfile{
+		Type: corev1.SeccompProfileTypeRuntimeDefault,
+	}
+}
+
+func hasMountAt(mounts []corev1.VolumeMount, path string) bool {
+	for _, mount := range mounts {
+		if mount.MountPath == path {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidateSecurityProfile only accepts the runtime default or localhost
+// profiles: workloads must never run unconfined. It also rejects root and
+// negative ids.
+func ValidateSecurityProfile(profile eirinictrl.SecurityProfile) error {
+	for _, id := range []*int64{profile.RunAsUser, profile.RunAsGroup, profile.FSGroup} {
+		if id != nil && *id < 0 {
+			return fmt.Errorf("ids must not be negative, got %d", *id)
+		}
+	}
+
+	if profile.RunAsUser != nil && *profile.RunAsUser == 0 {
+		return errors.New("run_as_user must not be root")
+	}
+
+	if !isRuntimeOrLocalhostProfile(profile.AppArmorProfile, corev1.AppArmorBetaProfileRuntimeDefault, corev1.AppArmorBetaProfileNamePrefix) {
+		return fmt.Errorf("unsupported apparmor profile %q", profile.AppArmorProfile)
+	}
+
+	if !isRuntimeOrLocalhostProfile(profile.SeccompProfile, corev1.SeccompProfileRuntimeDefault, corev1.SeccompLocalhostProfileNamePrefix) {
+		return fmt.Errorf("unsupported seccomp profile %q", profile.SeccompProfile)
+	}
+
+	return nil
+}
+
+func isRuntimeOrLocalhostProfile(profile, runtimeDefault, localhostPrefix string) bool {
+	if profile == "" || profile == runtimeDefault {
+		return true
+	}
+
+	return strings.HasPrefix(profile, localhostPrefix) && len(profile) > len(localhostPrefix)
+}
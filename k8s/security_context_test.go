@@ -0,0 +1,111 @@
+package k8s_test
+
+import (
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	. "code.cloudfoundry.org/eirini-controller/k8s"
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	v1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("SecurityPolicy", func() {
+	var (
+		profile           eirinictrl.SecurityProfile
+		namespaceProfiles map[string]eirinictrl.SecurityProfile
+		override          *eiriniv1.SecurityProfile
+		template          *v1.PodTemplateSpec
+		policyErr         error
+	)
+
+	BeforeEach(func() {
+		profile = eirinictrl.SecurityProfile{}
+		namespaceProfiles = nil
+		override = nil
+		template = &v1.PodTemplateSpec{
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{{Name: "opi"}},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		var policy *SecurityPolicy
+
+		policy, policyErr = NewSecurityPolicy(profile, namespaceProfiles)
+		if policyErr == nil {
+			policy.Apply("the-namespace", template, override)
+		}
+	})
+
+	It("sets the restricted container security context", func() {
+		Expect(policyErr).NotTo(HaveOccurred())
+		Expect(template.Spec.Containers[0].SecurityContext).To(Equal(ContainerSecurityContext()))
+		Expect(template.Spec.Volumes).To(BeEmpty())
+		Expect(template.Annotations).To(BeEmpty())
+	})
+
+	When("a container already mounts a volume at /tmp", func() {
+		BeforeEach(func() {
+			readOnly := true
+			profile.ReadOnlyRootFilesystem = &readOnly
+			template.Spec.Containers[0].VolumeMounts = []v1.VolumeMount{{Name: "my-tmp", MountPath: "/tmp"}}
+		})
+
+		It("keeps the existing mount", func() {
+			Expect(template.Spec.Containers[0].VolumeMounts).To(ConsistOf(v1.VolumeMount{Name: "my-tmp", MountPath: "/tmp"}))
+		})
+	})
+
+	When("the namespace profile disables the read-only root filesystem", func() {
+		BeforeEach(func() {
+			readOnly, writable := true, false
+			profile.ReadOnlyRootFilesystem = &readOnly
+			namespaceProfiles = map[string]eirinictrl.SecurityProfile{
+				"the-namespace": {ReadOnlyRootFilesystem: &writable},
+			}
+		})
+
+		It("keeps the root filesystem writable", func() {
+			Expect(template.Spec.Containers[0].SecurityContext.ReadOnlyRootFilesystem).To(BeNil())
+			Expect(template.Spec.Volumes).To(BeEmpty())
+		})
+	})
+
+	When("the workload defaults override the profile", func() {
+		BeforeEach(func() {
+			readOnly := true
+			profile.ReadOnlyRootFilesystem = &readOnly
+			profile.RunAsUser = int64Ptr(1000)
+			namespaceProfiles = map[string]eirinictrl.SecurityProfile{
+				"the-namespace": {RunAsUser: int64Ptr(2000), RunAsGroup: int64Ptr(2000)},
+			}
+			override = &eiriniv1.SecurityProfile{RunAsUser: int64Ptr(3000)}
+		})
+
+		It("takes precedence over the configured profiles field by field", func() {
+			Expect(template.Spec.SecurityContext.RunAsUser).To(PointTo(BeEquivalentTo(3000)))
+			Expect(template.Spec.SecurityContext.RunAsGroup).To(PointTo(BeEquivalentTo(2000)))
+			Expect(template.Spec.Containers[0].SecurityContext.ReadOnlyRootFilesystem).To(PointTo(BeTrue()))
+		})
+	})
+
+	DescribeTable("rejecting invalid profiles",
+		func(invalid eirinictrl.SecurityProfile, message string) {
+			_, err := NewSecurityPolicy(invalid, nil)
+			Expect(err).To(MatchError(ContainSubstring(message)))
+
+			_, err = NewSecurityPolicy(eirinictrl.SecurityProfile{}, map[string]eirinictrl.SecurityProfile{"ns": invalid})
+			Expect(err).To(MatchError(ContainSubstring(`namespace "ns"`)))
+		},
+		Entry("root user", eirinictrl.SecurityProfile{RunAsUser: int64Ptr(0)}, "must not be root"),
+		Entry("negative group", eirinictrl.SecurityProfile{RunAsGroup: int64Ptr(-1)}, "must not be negative"),
+		Entry("unconfined seccomp", eirinictrl.SecurityProfile{SeccompProfile: "unconfined"}, `unsupported seccomp profile "unconfined"`),
+		Entry("unnamed localhost apparmor", eirinictrl.SecurityProfile{AppArmorProfile: "localhost/"}, `unsupported apparmor profile "localhost/"`),
+	)
+})
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
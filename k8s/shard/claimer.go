@@ -0,0 +1,358 @@
+package shard
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"sync"
+	"time"
+
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/lager"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coordinationv1 "k8s.io/client-go/kubernetes/typed/coordination/v1"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// LabelShard assigns a namespace to a shard explicitly, overriding the
+// shard derived from its name.
+const LabelShard = "eirini.cloudfoundry.org/shard"
+
+const defaultLeaseDuration = 15 * time.Second
+
+// Index returns the shard of a namespace: the one in its shard label when
+// valid, or the one its name hashes to.
+func Index(namespace string, labels map[string]string, shards int) int {
+	if index, err := strconv.Atoi(labels[LabelShard]); err == nil && index >= 0 && index < shards {
+		return index
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(namespace))
+
+	return int(hash.Sum32() % uint32(shards))
+}
+
+// Claimer holds the leases of up to its share of the shards, and takes over
+// the shards whose holder stops renewing their lease. Without an explicit
+// maximum, it also takes over shards beyond its fair share once no other
+// replica has taken them, so that the shards of a replica which goes away
+// stay reconciled. Namespaces of newly held shards are sent to its sources,
+// so that their workloads are reconciled by every controller.
+type Claimer struct {
+	logger         lager.Logger
+	leases         coordinationv1.LeasesGetter
+	reader         client.Reader
+	name           string
+	identity       string
+	config         eirinictrl.Sharding
+	leaseDuration  time.Duration
+	started        time.Time
+	sourcesMux     sync.Mutex
+	sources        []chan event.GenericEvent
+	ownedShardsMux sync.Mutex
+	ownedShards    map[int]bool
+}
+
+// NewClaimer creates a claimer for the shards of the named component, whose
+// leases are called <name>-shard-<index>.
+func NewClaimer(
+	logger lager.Logger,
+	leases coordinationv1.LeasesGetter,
+	reader client.Reader,
+	name string,
+	identity string,
+	config eirinictrl.Sharding,
+) (*Claimer, error) {
+	if config.Shards < 1 {
+		return nil, errors.Errorf("invalid number of shards %d", config.Shards)
+	}
+
+	if config.LeaseNamespace == "" {
+		return nil, errors.New("missing shard lease namespace")
+	}
+
+	leaseDuration := defaultLeaseDuration
+	if config.LeaseDurationSeconds > 0 {
+		leaseDuration = time.Duration(config.LeaseDurationSeconds) * time.Second
+	}
+
+	return &Claimer{
+		logger:        logger.Session("shard-claimer", lager.Data{"name": name, "identity": identity}),
+		leases:        leases,
+		reader:        reader,
+		name:          name,
+		identity:      identity,
+		config:        config,
+		leaseDuration: leaseDuration,
+		ownedShards:   map[int]bool{},
+	}, nil
+}
+
+// Start contends for every shard until the context is done. The leases held
+// are released on the way out, so that other replicas take over at once.
+func (c *Claimer) Start(ctx context.Context) error {
+	var wg sync.WaitGroup
+
+	c.started = time.Now()
+
+	for shard := 0; shard < c.config.Shards; shard++ {
+		wg.Add(1)
+
+		go func(shard int) {
+			defer wg.Done()
+			c.contend(ctx, shard)
+		}(shard)
+	}
+
+	wg.Wait()
+
+	return nil
+}
+
+// NeedLeaderElection lets every replica run its claimer.
+func (c *Claimer) NeedLeaderElection() bool {
+	return false
+}
+
+// Source emits the namespaces of the shards as they are acquired. Each
+// controller needs a source of its own.
+func (c *Claimer) Source() source.Source {
+	events := make(chan event.GenericEvent, c.config.Shards)
+
+	c.sourcesMux.Lock()
+	defer c.sourcesMux.Unlock()
+
+	c.sources = append(c.sources, events)
+
+	return &source.Channel{Source: events}
+}
+
+// MaxShards returns the number of shards the claimer contends for: the
+// configured maximum, or else a fair share of the shards among the replicas.
+// Without a configured maximum, orphaned shards are held beyond it.
+func (c *Claimer) MaxShards() int {
+	if c.config.MaxShardsPerReplica > 0 {
+		return c.config.MaxShardsPerReplica
+	}
+
+	replicas := c.config.Replicas
+	if replicas < 1 {
+		replicas = 1
+	}
+
+	return (c.config.Shards + replicas - 1) / replicas
+}
+
+// Includes tells whether the namespace belongs to a shard held by the
+// claimer.
+func (c *Claimer) Includes(ctx context.Context, namespace string) (bool, error) {
+	ns := &corev1.Namespace{}
+
+	err := c.reader.Get(ctx, client.ObjectKey{Name: namespace}, ns)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return false, errors.Wrap(err, "failed to get namespace")
+	}
+
+	return c.owns(Index(namespace, ns.Labels, c.config.Shards)), nil
+}
+
+// OwnedShards returns the shards currently held.
+func (c *Claimer) OwnedShards() []int {
+	c.ownedShardsMux.Lock()
+	defer c.ownedShardsMux.Unlock()
+
+	shards := []int{}
+
+	for shard := 0; shard < c.config.Shards; shard++ {
+		if c.ownedShards[shard] {
+			shards = append(shards, shard)
+		}
+	}
+
+	return shards
+}
+
+func (c *Claimer) contend(ctx context.Context, shard int) {
+	logger := c.logger.Session("contend", lager.Data{"shard": shard})
+	retryPeriod := c.leaseDuration / 5
+
+	for ctx.Err() == nil {
+		overflow := !c.hasCapacity()
+		if overflow && (c.config.MaxShardsPerReplica > 0 || !c.orphaned(ctx, shard)) {
+			sleep(ctx, retryPeriod)
+
+			continue
+		}
+
+		shardCtx, cancel := context.WithCancel(ctx)
+
+		elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+			Lock: &resourcelock.LeaseLock{
+				LeaseMeta: metav1.ObjectMeta{
+					Namespace: c.config.LeaseNamespace,
+					Name:      c.leaseName(shard),
+				},
+				Client:     c.leases,
+				LockConfig: resourcelock.ResourceLockConfig{Identity: c.identity},
+			},
+			LeaseDuration:   c.leaseDuration,
+			RenewDeadline:   c.leaseDuration * 2 / 3,
+			RetryPeriod:     retryPeriod,
+			ReleaseOnCancel: true,
+			Name:            c.name,
+			Callbacks: leaderelection.LeaderCallbacks{
+				OnStartedLeading: func(leadingCtx context.Context) {
+					if !c.acquire(shard, overflow) {
+						logger.Debug("over-capacity")
+						cancel()
+
+						return
+					}
+
+					logger.Info("acquired")
+					c.announce(leadingCtx, shard)
+				},
+				OnStoppedLeading: func() {
+					if c.release(shard) {
+						logger.Info("released")
+					}
+				},
+			},
+		})
+		if err != nil {
+			cancel()
+			logger.Error("failed-to-create-elector", err)
+
+			return
+		}
+
+		c.run(shardCtx, cancel, elector, shard, overflow)
+		cancel()
+		sleep(ctx, retryPeriod)
+	}
+}
+
+// run holds the lease of the shard until the context is done. Contending for
+// an orphaned shard beyond the fair share is given up unless it is acquired
+// within a lease duration, as another replica then took it over: the replicas
+// below their share should take it first when that replica releases it.
+func (c *Claimer) run(ctx context.Context, cancel context.CancelFunc, elector *leaderelection.LeaderElector, shard int, overflow bool) {
+	if overflow {
+		giveUp := time.AfterFunc(c.leaseDuration, func() {
+			if !c.owns(shard) {
+				cancel()
+			}
+		})
+		defer giveUp.Stop()
+	}
+
+	elector.Run(ctx)
+}
+
+func (c *Claimer) hasCapacity() bool {
+	c.ownedShardsMux.Lock()
+	defer c.ownedShardsMux.Unlock()
+
+	return len(c.ownedShards) < c.MaxShards()
+}
+
+// orphaned tells whether the lease of a shard has not been renewed for twice
+// its duration, leaving the replicas below their share the time to take it
+// over first. A lease not created yet counts from the start of the claimer.
+func (c *Claimer) orphaned(ctx context.Context, shard int) bool {
+	since := c.started
+
+	lease, err := c.leases.Leases(c.config.LeaseNamespace).Get(ctx, c.leaseName(shard), metav1.GetOptions{})
+
+	switch {
+	case k8serrors.IsNotFound(err):
+	case err != nil:
+		c.logger.Error("failed-to-get-lease", err, lager.Data{"shard": shard})
+
+		return false
+	case lease.Spec.RenewTime != nil:
+		since = lease.Spec.RenewTime.Time
+	}
+
+	return time.Since(since) > 2*c.leaseDuration
+}
+
+func (c *Claimer) leaseName(shard int) string {
+	return fmt.Sprintf("%s-shard-%d", c.name, shard)
+}
+
+func (c *Claimer) acquire(shard int, overflow bool) bool {
+	c.ownedShardsMux.Lock()
+	defer c.ownedShardsMux.Unlock()
+
+	if !overflow && len(c.ownedShards) >= c.MaxShards() {
+		return false
+	}
+
+	c.ownedShards[shard] = true
+
+	return true
+}
+
+func (c *Claimer) release(shard int) bool {
+	c.ownedShardsMux.Lock()
+	defer c.ownedShardsMux.Unlock()
+
+	owned := c.ownedShards[shard]
+	delete(c.ownedShards, shard)
+
+	return owned
+}
+
+func (c *Claimer) owns(shard int) bool {
+	c.ownedShardsMux.Lock()
+	defer c.ownedShardsMux.Unlock()
+
+	return c.ownedShards[shard]
+}
+
+// announce emits the namespaces of a newly held shard, as their workloads
+// may have changed while no replica was reconciling them.
+func (c *Claimer) announce(ctx context.Context, shard int) {
+	namespaces := &corev1.NamespaceList{}
+	if err := c.reader.List(ctx, namespaces); err != nil {
+		c.logger.Error("failed-to-list-namespaces", err, lager.Data{"shard": shard})
+
+		return
+	}
+
+	c.sourcesMux.Lock()
+	sources := c.sources
+	c.sourcesMux.Unlock()
+
+	for i := range namespaces.Items {
+		namespace := &namespaces.Items[i]
+		if Index(namespace.Name, namespace.Labels, c.config.Shards) != shard {
+			continue
+		}
+
+		for _, events := range sources {
+			select {
+			case events <- event.GenericEvent{Object: namespace}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+func sleep(ctx context.Context, duration time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(duration):
+	}
+}
@@ -0,0 +1,228 @@
+package shard_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/k8s/k8sfakes"
+	"code.cloudfoundry.org/eirini-controller/k8s/shard"
+	"code.cloudfoundry.org/eirini-controller/tests"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+)
+
+var _ = Describe("Index", func() {
+	It("spreads namespaces by hash", func() {
+		shards := map[int]bool{}
+
+		for i := 0; i < 100; i++ {
+			index := shard.Index(fmt.Sprintf("namespace-%d", i), nil, 4)
+			Expect(index).To(BeNumerically(">=", 0))
+			Expect(index).To(BeNumerically("<", 4))
+			shards[index] = true
+		}
+
+		Expect(shards).To(HaveLen(4))
+		Expect(shard.Index("namespace-1", nil, 4)).To(Equal(shard.Index("namespace-1", nil, 4)))
+	})
+
+	It("honours a valid shard label", func() {
+		Expect(shard.Index("namespace-1", map[string]string{shard.LabelShard: "3"}, 4)).To(Equal(3))
+	})
+
+	It("ignores an out of range shard label", func() {
+		Expect(shard.Index("namespace-1", map[string]string{shard.LabelShard: "4"}, 4)).To(Equal(shard.Index("namespace-1", nil, 4)))
+	})
+})
+
+var _ = Describe("Claimer", func() {
+	var (
+		clientset *fake.Clientset
+		reader    *k8sfakes.FakeClient
+		config    eirinictrl.Sharding
+		ctx       context.Context
+		cancel    context.CancelFunc
+	)
+
+	BeforeEach(func() {
+		clientset = fake.NewSimpleClientset()
+		reader = new(k8sfakes.FakeClient)
+		reader.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			list.(*corev1.NamespaceList).Items = []corev1.Namespace{
+				{ObjectMeta: metav1.ObjectMeta{Name: "shard-0", Labels: map[string]string{shard.LabelShard: "0"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "shard-1", Labels: map[string]string{shard.LabelShard: "1"}}},
+			}
+
+			return nil
+		}
+		config = eirinictrl.Sharding{
+			Enabled:              true,
+			Shards:               2,
+			LeaseNamespace:       "eirini-controller",
+			LeaseDurationSeconds: 1,
+		}
+		ctx, cancel = context.WithCancel(context.Background())
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	newClaimer := func(identity string) *shard.Claimer {
+		claimer, err := shard.NewClaimer(tests.NewTestLogger("claimer"), clientset.CoordinationV1(), reader, "eirini-test", identity, config)
+		Expect(err).NotTo(HaveOccurred())
+
+		return claimer
+	}
+
+	start := func(ctx context.Context, claimer *shard.Claimer) chan struct{} {
+		done := make(chan struct{})
+
+		go func() {
+			defer GinkgoRecover()
+			defer close(done)
+			Expect(claimer.Start(ctx)).To(Succeed())
+		}()
+
+		return done
+	}
+
+	It("rejects invalid configurations", func() {
+		config.Shards = 0
+		_, err := shard.NewClaimer(tests.NewTestLogger("claimer"), clientset.CoordinationV1(), reader, "eirini-test", "replica", config)
+		Expect(err).To(MatchError(ContainSubstring("invalid number of shards")))
+	})
+
+	It("holds all shards when alone", func() {
+		claimer := newClaimer("replica-1")
+		start(ctx, claimer)
+
+		Eventually(claimer.OwnedShards).Should(ConsistOf(0, 1))
+
+		lease, err := clientset.CoordinationV1().Leases("eirini-controller").Get(ctx, "eirini-test-shard-1", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*lease.Spec.HolderIdentity).To(Equal("replica-1"))
+	})
+
+	It("includes the namespaces of the held shards", func() {
+		reader.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			obj.(*corev1.Namespace).Labels = map[string]string{shard.LabelShard: key.Name[len(key.Name)-1:]}
+
+			return nil
+		}
+		config.MaxShardsPerReplica = 1
+		claimer := newClaimer("replica-1")
+		start(ctx, claimer)
+
+		Eventually(claimer.OwnedShards).Should(HaveLen(1))
+		owned := claimer.OwnedShards()[0]
+
+		included, err := claimer.Includes(ctx, fmt.Sprintf("shard-%d", owned))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(included).To(BeTrue())
+
+		included, err = claimer.Includes(ctx, fmt.Sprintf("shard-%d", 1-owned))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(included).To(BeFalse())
+	})
+
+	It("caps the shards to a fair share among the replicas by default", func() {
+		config.Shards = 3
+		config.Replicas = 2
+		reader.ListReturns(nil)
+		claimer := newClaimer("replica-1")
+		Expect(claimer.MaxShards()).To(Equal(2))
+		start(ctx, claimer)
+
+		Eventually(claimer.OwnedShards).Should(HaveLen(2))
+		Consistently(claimer.OwnedShards, "500ms").Should(HaveLen(2))
+	})
+
+	It("takes over the shards of a replica which stops renewing their leases beyond its fair share", func() {
+		config.Replicas = 2
+		var stopRenewing int32
+		clientset.PrependReactor("update", "leases", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			lease := action.(k8stesting.UpdateAction).GetObject().(*coordinationv1.Lease)
+			if lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity == "replica-1" && atomic.LoadInt32(&stopRenewing) == 1 {
+				return true, nil, errors.New("replica-1 is gone")
+			}
+
+			return false, nil, nil
+		})
+
+		first := newClaimer("replica-1")
+		start(ctx, first)
+		Eventually(first.OwnedShards).Should(HaveLen(1))
+
+		second := newClaimer("replica-2")
+		start(ctx, second)
+		Eventually(second.OwnedShards, "5s").Should(HaveLen(1))
+		Consistently(second.OwnedShards, "500ms").Should(HaveLen(1))
+
+		atomic.StoreInt32(&stopRenewing, 1)
+		Eventually(first.OwnedShards, "5s").Should(BeEmpty())
+		Eventually(second.OwnedShards, "10s").Should(ConsistOf(0, 1))
+	})
+
+	It("sends the namespaces of acquired shards to every source", func() {
+		claimer := newClaimer("replica-1")
+		watch := func() chan event.GenericEvent {
+			events := make(chan event.GenericEvent, 2)
+			src := claimer.Source()
+			_, err := inject.StopChannelInto(ctx.Done(), src)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(src.Start(ctx, handler.Funcs{GenericFunc: func(e event.GenericEvent, _ workqueue.RateLimitingInterface) {
+				events <- e
+			}}, nil)).To(Succeed())
+
+			return events
+		}
+		first := watch()
+		second := watch()
+		start(ctx, claimer)
+
+		Eventually(first).Should(Receive())
+		Eventually(second).Should(Receive())
+	})
+
+	When("replicas are capped", func() {
+		BeforeEach(func() {
+			config.MaxShardsPerReplica = 1
+		})
+
+		It("shares the shards and takes over those of a stopped replica", func() {
+			firstCtx, stopFirst := context.WithCancel(ctx)
+			first := newClaimer("replica-1")
+			firstDone := start(firstCtx, first)
+			Eventually(first.OwnedShards).Should(HaveLen(1))
+
+			second := newClaimer("replica-2")
+			start(ctx, second)
+			Eventually(second.OwnedShards).Should(HaveLen(1))
+			Expect(second.OwnedShards()).NotTo(Equal(first.OwnedShards()))
+
+			config.MaxShardsPerReplica = 2
+			third := newClaimer("replica-3")
+			start(ctx, third)
+			Consistently(third.OwnedShards, "500ms").Should(BeEmpty())
+
+			stopFirst()
+			Eventually(firstDone).Should(BeClosed())
+			Eventually(third.OwnedShards, "5s").Should(HaveLen(1))
+		})
+	})
+})
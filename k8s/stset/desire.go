@@ -4,9 +4,11 @@ import (
 	"context"
 	"time"
 
+	"code.cloudfoundry.org/eirini-controller/k8s"
 	"code.cloudfoundry.org/eirini-controller/k8s/utils"
 	"code.cloudfoundry.org/eirini-controller/k8s/utils/dockerutils"
 	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"code.cloudfoundry.org/eirini-controller/tracing"
 	"code.cloudfoundry.org/eirini-controller/util"
 	"code.cloudfoundry.org/lager"
 	"github.com/hashicorp/go-multierror"
@@ -26,7 +28,7 @@ import (
 //counterfeiter:generate . PodDisruptionBudgetUpdater
 
 type LRPToStatefulSetConverter interface {
-	Convert(statefulSetName string, lrp *eiriniv1.LRP, privateRegistrySecret *corev1.Secret) (*appsv1.StatefulSet, error)
+	Convert(statefulSetName string, lrp *eiriniv1.LRP, privateRegistrySecret *corev1.Secret, defaults *eiriniv1.WorkloadDefaultsSpec) (*appsv1.StatefulSet, error)
 }
 
 type PodDisruptionBudgetUpdater interface {
@@ -37,6 +39,7 @@ type Desirer struct {
 	logger                     lager.Logger
 	lrpToStatefulSetConverter  LRPToStatefulSetConverter
 	podDisruptionBudgetCreator PodDisruptionBudgetUpdater
+	workloadIdentity           *k8s.WorkloadIdentity
 	scheme                     *runtime.Scheme
 	client                     client.Client
 }
@@ -45,6 +48,7 @@ func NewDesirer(
 	logger lager.Logger,
 	lrpToStatefulSetConverter LRPToStatefulSetConverter,
 	podDisruptionBudgetCreator PodDisruptionBudgetUpdater,
+	workloadIdentity *k8s.WorkloadIdentity,
 	client client.Client,
 	scheme *runtime.Scheme,
 ) *Desirer {
@@ -52,12 +56,21 @@ func NewDesirer(
 		logger:                     logger,
 		lrpToStatefulSetConverter:  lrpToStatefulSetConverter,
 		podDisruptionBudgetCreator: podDisruptionBudgetCreator,
+		workloadIdentity:           workloadIdentity,
 		client:                     client,
 		scheme:                     scheme,
 	}
 }
 
 func (d *Desirer) Desire(ctx context.Context, lrp *eiriniv1.LRP) error {
+	ctx, span := tracing.Start(ctx, "stset.Desirer.Desire", lrp)
+	err := d.desire(ctx, lrp)
+	tracing.End(span, err)
+
+	return err
+}
+
+func (d *Desirer) desire(ctx context.Context, lrp *eiriniv1.LRP) error {
 	logger := d.logger.Session("desire", lager.Data{"guid": lrp.Spec.GUID, "version": lrp.Spec.Version, "namespace": lrp.Namespace})
 
 	statefulSetName, err := utils.GetStatefulsetName(lrp)
@@ -65,12 +78,25 @@ func (d *Desirer) Desire(ctx context.Context, lrp *eiriniv1.LRP) error {
 		return err
 	}
 
+	if err = d.workloadIdentity.EnsureServiceAccount(ctx, d.client, k8s.LRPApp(lrp), lrp, d.scheme); err != nil {
+		logger.Error("failed-to-ensure-service-account", err)
+
+		return errors.Wrap(err, "failed to ensure service account")
+	}
+
+	defaults, err := k8s.GetWorkloadDefaults(ctx, d.client, lrp.Namespace)
+	if err != nil {
+		logger.Error("failed-to-get-workload-defaults", err)
+
+		return err
+	}
+
 	privateRegistrySecret, err := d.createRegistryCredsSecretIfRequired(ctx, lrp)
 	if err != nil {
 		return err
 	}
 
-	st, err := d.lrpToStatefulSetConverter.Convert(statefulSetName, lrp, privateRegistrySecret)
+	st, err := d.lrpToStatefulSetConverter.Convert(statefulSetName, lrp, privateRegistrySecret, defaults)
 	if err != nil {
 		return err
 	}
@@ -5,6 +5,8 @@ import (
 	"encoding/base64"
 	"fmt"
 
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/k8s"
 	"code.cloudfoundry.org/eirini-controller/k8s/k8sfakes"
 	"code.cloudfoundry.org/eirini-controller/k8s/stset"
 	"code.cloudfoundry.org/eirini-controller/k8s/stset/stsetfakes"
@@ -29,6 +31,7 @@ var _ = Describe("Desirer", func() {
 		client                     *k8sfakes.FakeClient
 		lrpToStatefulSetConverter  *stsetfakes.FakeLRPToStatefulSetConverter
 		podDisruptionBudgetUpdater *stsetfakes.FakePodDisruptionBudgetUpdater
+		identityConfig             eirinictrl.WorkloadIdentity
 		lrp                        *eiriniv1.LRP
 		desirer                    *stset.Desirer
 		desireErr                  error
@@ -38,7 +41,7 @@ var _ = Describe("Desirer", func() {
 		logger = tests.NewTestLogger("statefulset-desirer")
 		client = new(k8sfakes.FakeClient)
 		lrpToStatefulSetConverter = new(stsetfakes.FakeLRPToStatefulSetConverter)
-		lrpToStatefulSetConverter.ConvertStub = func(statefulSetName string, lrp *eiriniv1.LRP, _ *corev1.Secret) (*appsv1.StatefulSet, error) {
+		lrpToStatefulSetConverter.ConvertStub = func(statefulSetName string, lrp *eiriniv1.LRP, _ *corev1.Secret, _ *eiriniv1.WorkloadDefaultsSpec) (*appsv1.StatefulSet, error) {
 			return &appsv1.StatefulSet{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: statefulSetName,
@@ -48,10 +51,14 @@ var _ = Describe("Desirer", func() {
 
 		podDisruptionBudgetUpdater = new(stsetfakes.FakePodDisruptionBudgetUpdater)
 		lrp = createLRP("the-namespace", "Baldur")
-		desirer = stset.NewDesirer(logger, lrpToStatefulSetConverter, podDisruptionBudgetUpdater, client, eirinischeme.Scheme)
+		identityConfig = eirinictrl.WorkloadIdentity{}
 	})
 
 	JustBeforeEach(func() {
+		workloadIdentity, err := k8s.NewWorkloadIdentity("eirini", identityConfig)
+		Expect(err).NotTo(HaveOccurred())
+
+		desirer = stset.NewDesirer(logger, lrpToStatefulSetConverter, podDisruptionBudgetUpdater, workloadIdentity, client, eirinischeme.Scheme)
 		desireErr = desirer.Desire(ctx, lrp)
 	})
 
@@ -59,6 +66,39 @@ var _ = Describe("Desirer", func() {
 		Expect(desireErr).NotTo(HaveOccurred())
 	})
 
+	When("per-app service accounts are enabled", func() {
+		BeforeEach(func() {
+			identityConfig.PerAppServiceAccounts = true
+			identityConfig.ServiceAccountAnnotations = map[string]string{
+				"iam.gke.io/gcp-service-account": "{{ .AppGUID }}@project.iam.gserviceaccount.com",
+			}
+			client.GetReturns(k8serrors.NewNotFound(schema.GroupResource{Resource: "serviceaccounts"}, "eirini-app-premium_app_guid_1234"))
+		})
+
+		It("creates the app service account before the StatefulSet", func() {
+			Expect(client.CreateCallCount()).To(Equal(2))
+			_, obj, _ := client.CreateArgsForCall(0)
+			Expect(obj).To(BeAssignableToTypeOf(&corev1.ServiceAccount{}))
+			serviceAccount := obj.(*corev1.ServiceAccount)
+			Expect(serviceAccount.Namespace).To(Equal("the-namespace"))
+			Expect(serviceAccount.Annotations).To(HaveKeyWithValue("iam.gke.io/gcp-service-account", "premium_app_guid_1234@project.iam.gserviceaccount.com"))
+
+			_, obj, _ = client.CreateArgsForCall(1)
+			Expect(obj).To(BeAssignableToTypeOf(&appsv1.StatefulSet{}))
+		})
+
+		When("creating the service account fails", func() {
+			BeforeEach(func() {
+				client.CreateReturnsOnCall(0, errors.New("boom"))
+			})
+
+			It("does not create the StatefulSet", func() {
+				Expect(desireErr).To(MatchError(ContainSubstring("boom")))
+				Expect(client.CreateCallCount()).To(Equal(1))
+			})
+		})
+	})
+
 	It("creates the StatefulSet", func() {
 		Expect(client.CreateCallCount()).To(Equal(1))
 		_, obj, _ := client.CreateArgsForCall(0)
@@ -68,6 +108,28 @@ var _ = Describe("Desirer", func() {
 		Expect(statefulSet.Namespace).To(Equal("the-namespace"))
 	})
 
+	It("converts the LRP with the workload defaults of its namespace", func() {
+		Expect(client.GetCallCount()).To(Equal(1))
+		_, key, obj := client.GetArgsForCall(0)
+		Expect(key).To(Equal(k8sclient.ObjectKey{Namespace: "the-namespace", Name: eiriniv1.WorkloadDefaultsName}))
+		Expect(obj).To(BeAssignableToTypeOf(&eiriniv1.WorkloadDefaults{}))
+
+		Expect(lrpToStatefulSetConverter.ConvertCallCount()).To(Equal(1))
+		_, _, _, defaults := lrpToStatefulSetConverter.ConvertArgsForCall(0)
+		Expect(defaults).To(Equal(&eiriniv1.WorkloadDefaultsSpec{}))
+	})
+
+	When("getting the workload defaults fails", func() {
+		BeforeEach(func() {
+			client.GetReturns(errors.New("get-failed"))
+		})
+
+		It("does not create the StatefulSet", func() {
+			Expect(desireErr).To(MatchError(ContainSubstring("get-failed")))
+			Expect(client.CreateCallCount()).To(BeZero())
+		})
+	})
+
 	It("updates the pod disruption budget", func() {
 		Expect(podDisruptionBudgetUpdater.UpdateCallCount()).To(Equal(1))
 		_, actualStatefulSet, actualLRP := podDisruptionBudgetUpdater.UpdateArgsForCall(0)
@@ -168,7 +230,7 @@ var _ = Describe("Desirer", func() {
 
 		It("uses that secret when converting to statefulset", func() {
 			Expect(lrpToStatefulSetConverter.ConvertCallCount()).To(Equal(1))
-			_, _, actualRegistrySecret := lrpToStatefulSetConverter.ConvertArgsForCall(0)
+			_, _, actualRegistrySecret, _ := lrpToStatefulSetConverter.ConvertArgsForCall(0)
 			Expect(actualRegistrySecret.Name).To(Equal("private-registry-1234"))
 			Expect(actualRegistrySecret.Namespace).To(Equal("the-namespace"))
 		})
@@ -2,11 +2,14 @@ package stset
 
 import (
 	"fmt"
+	"sort"
+	"sync"
 
 	eirinictrl "code.cloudfoundry.org/eirini-controller"
 	"code.cloudfoundry.org/eirini-controller/k8s"
 	"code.cloudfoundry.org/eirini-controller/k8s/utils"
 	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -15,35 +18,56 @@ import (
 
 //counterfeiter:generate . ProbeCreator
 
-const PodAffinityTermWeight = 100
+// TopologySpreadKeys are the topology domains LRP instances are spread
+// across, from the widest to the narrowest.
+var TopologySpreadKeys = []string{corev1.LabelTopologyZone, corev1.LabelHostname}
 
 type ProbeCreator func(lrp *eiriniv1.LRP) *corev1.Probe
 
 type LRPToStatefulSet struct {
-	applicationServiceAccount         string
+	workloadIdentity                  *k8s.WorkloadIdentity
 	registrySecretName                string
 	allowAutomountServiceAccountToken bool
+	topologySpreadPolicyMutex         sync.RWMutex
+	topologySpreadPolicy              string
+	placementTags                     map[string]eirinictrl.PlacementTag
+	securityPolicy                    *k8s.SecurityPolicy
 	livenessProbeCreator              ProbeCreator
 	readinessProbeCreator             ProbeCreator
+	startupProbeCreator               ProbeCreator
 }
 
 func NewLRPToStatefulSetConverter(
-	applicationServiceAccount string,
+	workloadIdentity *k8s.WorkloadIdentity,
 	registrySecretName string,
 	allowAutomountServiceAccountToken bool,
+	topologySpreadPolicy string,
+	placementTags map[string]eirinictrl.PlacementTag,
+	securityPolicy *k8s.SecurityPolicy,
 	livenessProbeCreator ProbeCreator,
 	readinessProbeCreator ProbeCreator,
+	startupProbeCreator ProbeCreator,
 ) *LRPToStatefulSet {
 	return &LRPToStatefulSet{
-		applicationServiceAccount:         applicationServiceAccount,
+		workloadIdentity:                  workloadIdentity,
 		registrySecretName:                registrySecretName,
 		allowAutomountServiceAccountToken: allowAutomountServiceAccountToken,
+		topologySpreadPolicy:              topologySpreadPolicy,
+		placementTags:                     placementTags,
+		securityPolicy:                    securityPolicy,
 		livenessProbeCreator:              livenessProbeCreator,
 		readinessProbeCreator:             readinessProbeCreator,
+		startupProbeCreator:               startupProbeCreator,
 	}
 }
 
-func (c *LRPToStatefulSet) Convert(statefulSetName string, lrp *eiriniv1.LRP, privateRegistrySecret *corev1.Secret) (*appsv1.StatefulSet, error) {
+func (c *LRPToStatefulSet) Convert(statefulSetName string, lrp *eiriniv1.LRP, privateRegistrySecret *corev1.Secret, defaults *eiriniv1.WorkloadDefaultsSpec) (*appsv1.StatefulSet, error) {
+	if defaults == nil {
+		defaults = &eiriniv1.WorkloadDefaultsSpec{}
+	}
+
+	lrp = k8s.ApplyLRPDefaults(lrp, defaults)
+
 	envs := utils.MapToEnvVar(lrp.Spec.Env)
 	envs = append(envs, lrp.Spec.Environment...)
 	fieldEnvs := []corev1.EnvVar{
@@ -90,9 +114,10 @@ func (c *LRPToStatefulSet) Convert(statefulSetName string, lrp *eiriniv1.LRP, pr
 
 	livenessProbe := c.livenessProbeCreator(lrp)
 	readinessProbe := c.readinessProbeCreator(lrp)
+	startupProbe := c.startupProbeCreator(lrp)
 
 	volumes, volumeMounts := getVolumeSpecs(lrp.Spec.VolumeMounts)
-	imagePullSecrets := c.calculateImagePullSecrets(privateRegistrySecret)
+	imagePullSecrets := c.calculateImagePullSecrets(lrp, privateRegistrySecret, defaults)
 
 	containers := []corev1.Container{
 		{
@@ -102,10 +127,10 @@ func (c *LRPToStatefulSet) Convert(statefulSetName string, lrp *eiriniv1.LRP, pr
 			Command:         lrp.Spec.Command,
 			Env:             envs,
 			Ports:           ports,
-			SecurityContext: k8s.ContainerSecurityContext(),
 			Resources:       getContainerResources(lrp.Spec.CPUWeight, lrp.Spec.MemoryMB, lrp.Spec.DiskMB),
 			LivenessProbe:   livenessProbe,
 			ReadinessProbe:  readinessProbe,
+			StartupProbe:    startupProbe,
 			VolumeMounts:    volumeMounts,
 		},
 	}
@@ -121,36 +146,32 @@ func (c *LRPToStatefulSet) Convert(statefulSetName string, lrp *eiriniv1.LRP, pr
 			Replicas:            int32ptr(lrp.Spec.Instances),
 			Template: corev1.PodTemplateSpec{
 				Spec: corev1.PodSpec{
-					Containers:         containers,
-					ImagePullSecrets:   imagePullSecrets,
-					ServiceAccountName: c.applicationServiceAccount,
-					Volumes:            volumes,
+					Containers:       containers,
+					ImagePullSecrets: imagePullSecrets,
+					Volumes:          volumes,
 				},
 			},
 		},
 	}
 
-	if !c.allowAutomountServiceAccountToken {
+	if !k8s.AllowAutomountServiceAccountToken(c.allowAutomountServiceAccountToken, defaults) {
 		automountServiceAccountToken := false
 		statefulSet.Spec.Template.Spec.AutomountServiceAccountToken = &automountServiceAccountToken
 	}
 
 	statefulSet.Spec.Selector = StatefulSetLabelSelector(lrp)
 
-	statefulSet.Spec.Template.Spec.Affinity = &corev1.Affinity{
-		PodAntiAffinity: &corev1.PodAntiAffinity{
-			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
-				{
-					Weight: PodAffinityTermWeight,
-					PodAffinityTerm: corev1.PodAffinityTerm{
-						TopologyKey: corev1.LabelHostname,
-						LabelSelector: &metav1.LabelSelector{
-							MatchExpressions: toLabelSelectorRequirements(statefulSet.Spec.Selector),
-						},
-					},
-				},
-			},
-		},
+	statefulSet.Spec.Template.Spec.TopologySpreadConstraints = c.topologySpreadConstraints(lrp, statefulSet.Spec.Selector)
+
+	if err := k8s.ApplyPlacementTags(&statefulSet.Spec.Template.Spec, lrp.Spec.PlacementTags, c.placementTags); err != nil {
+		return nil, errors.Wrap(err, "failed to apply the placement tags")
+	}
+
+	app := k8s.LRPApp(lrp)
+	app.ApplicationServiceAccount = defaults.ApplicationServiceAccount
+
+	if err := c.workloadIdentity.Apply(&statefulSet.Spec.Template.Spec, app); err != nil {
+		return nil, errors.Wrap(err, "failed to set the service account")
 	}
 
 	labels := map[string]string{
@@ -186,12 +207,56 @@ func (c *LRPToStatefulSet) Convert(statefulSetName string, lrp *eiriniv1.LRP, pr
 	statefulSet.Annotations = annotations
 	statefulSet.Spec.Template.Annotations = annotations
 
+	k8s.ApplyPodMetadataDefaults(&statefulSet.Spec.Template, defaults)
+	c.securityPolicy.Apply(lrp.Namespace, &statefulSet.Spec.Template, defaults.SecurityProfile)
+
 	return statefulSet, nil
 }
 
-func (c *LRPToStatefulSet) calculateImagePullSecrets(privateRegistrySecret *corev1.Secret) []corev1.LocalObjectReference {
+// SetTopologySpreadPolicy changes the policy of the LRPs that do not set
+// their own.
+func (c *LRPToStatefulSet) SetTopologySpreadPolicy(policy string) {
+	c.topologySpreadPolicyMutex.Lock()
+	defer c.topologySpreadPolicyMutex.Unlock()
+
+	c.topologySpreadPolicy = policy
+}
+
+// topologySpreadConstraints spreads the instances evenly across zones and
+// nodes. The soft policy only prefers an even spread, while the hard one
+// leaves instances pending rather than unbalancing it.
+func (c *LRPToStatefulSet) topologySpreadConstraints(lrp *eiriniv1.LRP, selector *metav1.LabelSelector) []corev1.TopologySpreadConstraint {
+	policy := lrp.Spec.TopologySpreadPolicy
+	if policy == "" {
+		c.topologySpreadPolicyMutex.RLock()
+		policy = c.topologySpreadPolicy
+		c.topologySpreadPolicyMutex.RUnlock()
+	}
+
+	whenUnsatisfiable := corev1.ScheduleAnyway
+	if policy == eiriniv1.TopologySpreadPolicyHard {
+		whenUnsatisfiable = corev1.DoNotSchedule
+	}
+
+	constraints := []corev1.TopologySpreadConstraint{}
+
+	for _, topologyKey := range TopologySpreadKeys {
+		constraints = append(constraints, corev1.TopologySpreadConstraint{
+			MaxSkew:           1,
+			TopologyKey:       topologyKey,
+			WhenUnsatisfiable: whenUnsatisfiable,
+			LabelSelector: &metav1.LabelSelector{
+				MatchExpressions: toLabelSelectorRequirements(selector),
+			},
+		})
+	}
+
+	return constraints
+}
+
+func (c *LRPToStatefulSet) calculateImagePullSecrets(lrp *eiriniv1.LRP, privateRegistrySecret *corev1.Secret, defaults *eiriniv1.WorkloadDefaultsSpec) []corev1.LocalObjectReference {
 	imagePullSecrets := []corev1.LocalObjectReference{
-		{Name: c.registrySecretName},
+		{Name: k8s.RegistrySecretName(c.registrySecretName, defaults)},
 	}
 
 	if privateRegistrySecret != nil {
@@ -200,7 +265,7 @@ func (c *LRPToStatefulSet) calculateImagePullSecrets(privateRegistrySecret *core
 		})
 	}
 
-	return imagePullSecrets
+	return append(imagePullSecrets, lrp.Spec.ImagePullSecrets...)
 }
 
 func getVolumeSpecs(lrpVolumeMounts []eiriniv1.VolumeMount) ([]corev1.Volume, []corev1.VolumeMount) {
@@ -261,12 +326,15 @@ func getSidecarContainers(lrp *eiriniv1.LRP) []corev1.Container {
 	containers := []corev1.Container{}
 
 	for _, s := range lrp.Spec.Sidecars {
+		s := s
 		c := corev1.Container{
-			Name:      s.Name,
-			Command:   s.Command,
-			Image:     lrp.Spec.Image,
-			Env:       utils.MapToEnvVar(s.Env),
-			Resources: getContainerResources(lrp.Spec.CPUWeight, s.MemoryMB, lrp.Spec.DiskMB),
+			Name:           s.Name,
+			Command:        s.Command,
+			Image:          lrp.Spec.Image,
+			Env:            utils.MapToEnvVar(s.Env),
+			Resources:      getContainerResources(lrp.Spec.CPUWeight, s.MemoryMB, lrp.Spec.DiskMB),
+			LivenessProbe:  k8s.CreateSidecarLivenessProbe(&s),
+			ReadinessProbe: k8s.CreateSidecarReadinessProbe(&s),
 		}
 		containers = append(containers, c)
 	}
@@ -280,6 +348,8 @@ func int32ptr(i int) *int32 {
 	return &u
 }
 
+// toLabelSelectorRequirements returns the requirements sorted by key, so
+// that converting the same LRP always gives the same StatefulSet.
 func toLabelSelectorRequirements(selector *metav1.LabelSelector) []metav1.LabelSelectorRequirement {
 	labels := selector.MatchLabels
 	reqs := make([]metav1.LabelSelectorRequirement, 0, len(labels))
@@ -292,6 +362,10 @@ func toLabelSelectorRequirements(selector *metav1.LabelSelector) []metav1.LabelS
 		})
 	}
 
+	sort.Slice(reqs, func(i, j int) bool {
+		return reqs[i].Key < reqs[j].Key
+	})
+
 	return reqs
 }
 
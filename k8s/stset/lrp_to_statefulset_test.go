@@ -2,6 +2,7 @@ package stset_test
 
 import (
 	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/k8s"
 	"code.cloudfoundry.org/eirini-controller/k8s/stset"
 	"code.cloudfoundry.org/eirini-controller/k8s/stset/stsetfakes"
 	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
@@ -17,19 +18,43 @@ import (
 var _ = Describe("LRP to StatefulSet Converter", func() {
 	var (
 		allowAutomountServiceAccountToken bool
+		topologySpreadPolicy              string
+		changedTopologySpreadPolicy       string
+		placementTags                     map[string]eirinictrl.PlacementTag
+		securityProfile                   eirinictrl.SecurityProfile
+		namespaceSecurityProfiles         map[string]eirinictrl.SecurityProfile
+		identityConfig                    eirinictrl.WorkloadIdentity
+		defaults                          *eiriniv1.WorkloadDefaultsSpec
 		livenessProbeCreator              *stsetfakes.FakeProbeCreator
 		readinessProbeCreator             *stsetfakes.FakeProbeCreator
+		startupProbeCreator               *stsetfakes.FakeProbeCreator
 		lrp                               *eiriniv1.LRP
 		statefulSet                       *appsv1.StatefulSet
 		privateRegistrySecret             *corev1.Secret
 		livenessProbe                     *corev1.Probe
 		readinessProbe                    *corev1.Probe
+		startupProbe                      *corev1.Probe
 	)
 
 	BeforeEach(func() {
 		allowAutomountServiceAccountToken = false
+		topologySpreadPolicy = ""
+		changedTopologySpreadPolicy = ""
+		securityProfile = eirinictrl.SecurityProfile{}
+		namespaceSecurityProfiles = nil
+		identityConfig = eirinictrl.WorkloadIdentity{}
+		defaults = nil
+		placementTags = map[string]eirinictrl.PlacementTag{
+			"isolated": {
+				NodeSelector: map[string]string{"segment": "isolated"},
+				Tolerations: []eirinictrl.Toleration{
+					{Key: "segment", Operator: "Equal", Value: "isolated", Effect: "NoSchedule"},
+				},
+			},
+		}
 		livenessProbeCreator = new(stsetfakes.FakeProbeCreator)
 		readinessProbeCreator = new(stsetfakes.FakeProbeCreator)
+		startupProbeCreator = new(stsetfakes.FakeProbeCreator)
 		lrp = createLRP("the-namespace", "Baldur")
 		privateRegistrySecret = nil
 
@@ -38,13 +63,25 @@ var _ = Describe("LRP to StatefulSet Converter", func() {
 
 		readinessProbe = &corev1.Probe{}
 		readinessProbeCreator.Returns(readinessProbe)
+
+		startupProbe = &corev1.Probe{}
+		startupProbeCreator.Returns(startupProbe)
 	})
 
 	JustBeforeEach(func() {
-		converter := stset.NewLRPToStatefulSetConverter("eirini", "secret-name", allowAutomountServiceAccountToken, livenessProbeCreator.Spy, readinessProbeCreator.Spy)
+		securityPolicy, err := k8s.NewSecurityPolicy(securityProfile, namespaceSecurityProfiles)
+		Expect(err).NotTo(HaveOccurred())
 
-		var err error
-		statefulSet, err = converter.Convert("Baldur", lrp, privateRegistrySecret)
+		workloadIdentity, err := k8s.NewWorkloadIdentity("eirini", identityConfig)
+		Expect(err).NotTo(HaveOccurred())
+
+		converter := stset.NewLRPToStatefulSetConverter(workloadIdentity, "secret-name", allowAutomountServiceAccountToken, topologySpreadPolicy, placementTags, securityPolicy, livenessProbeCreator.Spy, readinessProbeCreator.Spy, startupProbeCreator.Spy)
+
+		if changedTopologySpreadPolicy != "" {
+			converter.SetTopologySpreadPolicy(changedTopologySpreadPolicy)
+		}
+
+		statefulSet, err = converter.Convert("Baldur", lrp, privateRegistrySecret, defaults)
 		Expect(err).NotTo(HaveOccurred())
 	})
 
@@ -56,6 +93,10 @@ var _ = Describe("LRP to StatefulSet Converter", func() {
 		Expect(readinessProbeCreator.CallCount()).To(Equal(1))
 	})
 
+	It("should create a startup probe", func() {
+		Expect(startupProbeCreator.CallCount()).To(Equal(1))
+	})
+
 	DescribeTable("Statefulset Annotations",
 		func(annotationName, expectedValue string) {
 			Expect(statefulSet.Annotations).To(HaveKeyWithValue(annotationName, expectedValue))
@@ -102,6 +143,10 @@ var _ = Describe("LRP to StatefulSet Converter", func() {
 		Expect(statefulSet.Spec.Template.Spec.Containers[0].ReadinessProbe).To(Equal(readinessProbe))
 	})
 
+	It("should set the startup probe", func() {
+		Expect(statefulSet.Spec.Template.Spec.Containers[0].StartupProbe).To(Equal(startupProbe))
+	})
+
 	It("should not automount service account token", func() {
 		f := false
 		Expect(statefulSet.Spec.Template.Spec.AutomountServiceAccountToken).To(Equal(&f))
@@ -184,35 +229,140 @@ var _ = Describe("LRP to StatefulSet Converter", func() {
 		Expect(statefulSet.Spec.Template.Annotations["prometheus.io/scrape"]).To(Equal("secret-value"))
 	})
 
-	It("should set soft inter-pod anti-affinity", func() {
-		podAntiAffinity := statefulSet.Spec.Template.Spec.Affinity.PodAntiAffinity
-		Expect(podAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution).To(BeEmpty())
-		Expect(podAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(HaveLen(1))
+	It("should softly spread the instances across zones and nodes", func() {
+		constraints := statefulSet.Spec.Template.Spec.TopologySpreadConstraints
+		Expect(constraints).To(HaveLen(2))
+		Expect(constraints[0].TopologyKey).To(Equal("topology.kubernetes.io/zone"))
+		Expect(constraints[1].TopologyKey).To(Equal("kubernetes.io/hostname"))
+
+		for _, constraint := range constraints {
+			Expect(constraint.MaxSkew).To(Equal(int32(1)))
+			Expect(constraint.WhenUnsatisfiable).To(Equal(corev1.ScheduleAnyway))
+			Expect(constraint.LabelSelector.MatchExpressions).To(ConsistOf(
+				metav1.LabelSelectorRequirement{
+					Key:      stset.LabelGUID,
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   []string{"guid_1234"},
+				},
+				metav1.LabelSelectorRequirement{
+					Key:      stset.LabelVersion,
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   []string{"version_1234"},
+				},
+				metav1.LabelSelectorRequirement{
+					Key:      stset.LabelSourceType,
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   []string{"APP"},
+				},
+			))
+		}
+	})
 
-		weightedTerm := podAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0]
-		Expect(weightedTerm.Weight).To(Equal(int32(100)))
-		Expect(weightedTerm.PodAffinityTerm.TopologyKey).To(Equal("kubernetes.io/hostname"))
-		Expect(weightedTerm.PodAffinityTerm.LabelSelector.MatchExpressions).To(ConsistOf(
-			metav1.LabelSelectorRequirement{
-				Key:      stset.LabelGUID,
-				Operator: metav1.LabelSelectorOpIn,
-				Values:   []string{"guid_1234"},
-			},
-			metav1.LabelSelectorRequirement{
-				Key:      stset.LabelVersion,
-				Operator: metav1.LabelSelectorOpIn,
-				Values:   []string{"version_1234"},
-			},
-			metav1.LabelSelectorRequirement{
-				Key:      stset.LabelSourceType,
-				Operator: metav1.LabelSelectorOpIn,
-				Values:   []string{"APP"},
-			},
-		))
+	It("should sort the spread label selector requirements by key", func() {
+		for _, constraint := range statefulSet.Spec.Template.Spec.TopologySpreadConstraints {
+			keys := []string{}
+			for _, requirement := range constraint.LabelSelector.MatchExpressions {
+				keys = append(keys, requirement.Key)
+			}
+
+			Expect(keys).To(Equal([]string{stset.LabelGUID, stset.LabelSourceType, stset.LabelVersion}))
+		}
+	})
+
+	It("should not set a pod anti-affinity", func() {
+		Expect(statefulSet.Spec.Template.Spec.Affinity).To(BeNil())
+	})
+
+	When("the topology spread policy is hard", func() {
+		BeforeEach(func() {
+			topologySpreadPolicy = eiriniv1.TopologySpreadPolicyHard
+		})
+
+		It("does not schedule instances that would unbalance the spread", func() {
+			for _, constraint := range statefulSet.Spec.Template.Spec.TopologySpreadConstraints {
+				Expect(constraint.WhenUnsatisfiable).To(Equal(corev1.DoNotSchedule))
+			}
+		})
+
+		When("the LRP overrides it", func() {
+			BeforeEach(func() {
+				lrp.Spec.TopologySpreadPolicy = eiriniv1.TopologySpreadPolicySoft
+			})
+
+			It("uses the LRP policy", func() {
+				for _, constraint := range statefulSet.Spec.Template.Spec.TopologySpreadConstraints {
+					Expect(constraint.WhenUnsatisfiable).To(Equal(corev1.ScheduleAnyway))
+				}
+			})
+		})
+
+		When("the policy is changed", func() {
+			BeforeEach(func() {
+				changedTopologySpreadPolicy = eiriniv1.TopologySpreadPolicySoft
+			})
+
+			It("uses the new policy", func() {
+				for _, constraint := range statefulSet.Spec.Template.Spec.TopologySpreadConstraints {
+					Expect(constraint.WhenUnsatisfiable).To(Equal(corev1.ScheduleAnyway))
+				}
+			})
+		})
+	})
+
+	It("should not constrain the nodes", func() {
+		Expect(statefulSet.Spec.Template.Spec.NodeSelector).To(BeEmpty())
+		Expect(statefulSet.Spec.Template.Spec.Tolerations).To(BeEmpty())
+	})
+
+	When("the LRP has placement tags", func() {
+		BeforeEach(func() {
+			lrp.Spec.PlacementTags = []string{"isolated"}
+		})
+
+		It("schedules the instances on the nodes of the isolation segment", func() {
+			Expect(statefulSet.Spec.Template.Spec.NodeSelector).To(Equal(map[string]string{"segment": "isolated"}))
+			Expect(statefulSet.Spec.Template.Spec.Tolerations).To(ConsistOf(corev1.Toleration{
+				Key:      "segment",
+				Operator: corev1.TolerationOpEqual,
+				Value:    "isolated",
+				Effect:   corev1.TaintEffectNoSchedule,
+			}))
+		})
 	})
 
 	It("should set application service account", func() {
 		Expect(statefulSet.Spec.Template.Spec.ServiceAccountName).To(Equal("eirini"))
+		Expect(statefulSet.Spec.Template.Spec.Volumes).NotTo(ContainElement(HaveField("Name", k8s.ServiceAccountTokenVolumeName)))
+	})
+
+	When("per-app service accounts are enabled", func() {
+		BeforeEach(func() {
+			identityConfig.PerAppServiceAccounts = true
+			lrp.Spec.Sidecars = []eiriniv1.Sidecar{{Name: "the-sidecar", Command: []string{"sleep"}}}
+		})
+
+		It("runs the instances as the app service account", func() {
+			Expect(statefulSet.Spec.Template.Spec.ServiceAccountName).To(Equal("eirini-app-premium-app-guid-1234"))
+		})
+
+		It("projects the service account token into every container", func() {
+			Expect(statefulSet.Spec.Template.Spec.Volumes).To(ContainElement(HaveField("Name", k8s.ServiceAccountTokenVolumeName)))
+
+			for _, container := range statefulSet.Spec.Template.Spec.Containers {
+				Expect(container.VolumeMounts).To(ContainElement(HaveField("MountPath", k8s.ServiceAccountTokenMountPath)))
+			}
+		})
+
+		When("the LRP asks for its own service account", func() {
+			BeforeEach(func() {
+				lrp.Spec.ServiceAccountName = "my-identity"
+				identityConfig.AllowedServiceAccounts = []string{"my-identity"}
+			})
+
+			It("runs the instances as that service account", func() {
+				Expect(statefulSet.Spec.Template.Spec.ServiceAccountName).To(Equal("my-identity"))
+			})
+		})
 	})
 
 	It("should set the container environment variables", func() {
@@ -242,6 +392,117 @@ var _ = Describe("LRP to StatefulSet Converter", func() {
 		Expect(securityContext.SeccompProfile.Type).To(Equal(corev1.SeccompProfileTypeRuntimeDefault))
 	})
 
+	When("a security profile is configured", func() {
+		BeforeEach(func() {
+			uid, gid := int64(2000), int64(3000)
+			readOnly := true
+			securityProfile = eirinictrl.SecurityProfile{
+				RunAsUser:              &uid,
+				RunAsGroup:             &gid,
+				FSGroup:                &gid,
+				ReadOnlyRootFilesystem: &readOnly,
+				AppArmorProfile:        "localhost/eirini-apps",
+				SeccompProfile:         "localhost/profiles/eirini.json",
+			}
+			lrp.Spec.Sidecars = []eiriniv1.Sidecar{{Name: "the-sidecar", Command: []string{"sleep"}}}
+		})
+
+		It("sets the pod security context", func() {
+			podSecurityContext := statefulSet.Spec.Template.Spec.SecurityContext
+			Expect(podSecurityContext.RunAsUser).To(PointTo(BeEquivalentTo(2000)))
+			Expect(podSecurityContext.RunAsGroup).To(PointTo(BeEquivalentTo(3000)))
+			Expect(podSecurityContext.FSGroup).To(PointTo(BeEquivalentTo(3000)))
+		})
+
+		It("applies the profile to the application and sidecar containers", func() {
+			containers := statefulSet.Spec.Template.Spec.Containers
+			Expect(containers).To(HaveLen(2))
+
+			for _, container := range containers {
+				Expect(container.SecurityContext.ReadOnlyRootFilesystem).To(PointTo(BeTrue()))
+				Expect(container.SecurityContext.SeccompProfile.Type).To(Equal(corev1.SeccompProfileTypeLocalhost))
+				Expect(container.SecurityContext.SeccompProfile.LocalhostProfile).To(PointTo(Equal("profiles/eirini.json")))
+				Expect(container.VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: "eirini-tmp", MountPath: "/tmp"}))
+				Expect(statefulSet.Spec.Template.Annotations).To(HaveKeyWithValue(
+					corev1.AppArmorBetaContainerAnnotationKeyPrefix+container.Name, "localhost/eirini-apps",
+				))
+			}
+		})
+
+		It("mounts a writable /tmp", func() {
+			Expect(statefulSet.Spec.Template.Spec.Volumes).To(ContainElement(corev1.Volume{
+				Name:         "eirini-tmp",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			}))
+		})
+
+		It("does not annotate the statefulset with the AppArmor profiles", func() {
+			Expect(statefulSet.Annotations).NotTo(HaveKey(HavePrefix(corev1.AppArmorBetaContainerAnnotationKeyPrefix)))
+		})
+
+		When("the LRP namespace has its own profile", func() {
+			BeforeEach(func() {
+				uid := int64(4000)
+				namespaceSecurityProfiles = map[string]eirinictrl.SecurityProfile{
+					lrp.Namespace: {RunAsUser: &uid},
+				}
+			})
+
+			It("overrides the configured profile", func() {
+				podSecurityContext := statefulSet.Spec.Template.Spec.SecurityContext
+				Expect(podSecurityContext.RunAsUser).To(PointTo(BeEquivalentTo(4000)))
+				Expect(podSecurityContext.RunAsGroup).To(PointTo(BeEquivalentTo(3000)))
+			})
+		})
+	})
+
+	When("the namespace has workload defaults", func() {
+		BeforeEach(func() {
+			identityConfig.AllowedServiceAccounts = []string{"namespace-account"}
+			uid := int64(5000)
+			allowAutomount := true
+			defaults = &eiriniv1.WorkloadDefaultsSpec{
+				ApplicationServiceAccount:         "namespace-account",
+				RegistrySecretName:                "namespace-registry-secret",
+				AllowAutomountServiceAccountToken: &allowAutomount,
+				TopologySpreadPolicy:              eiriniv1.TopologySpreadPolicyHard,
+				PlacementTags:                     []string{"isolated"},
+				SecurityProfile:                   &eiriniv1.SecurityProfile{RunAsUser: &uid},
+				Resources:                         &eiriniv1.ResourceDefaults{MemoryMB: 256, CPUWeight: 10},
+				Labels:                            map[string]string{"team": "payments", stset.LabelGUID: "overridden"},
+				Annotations:                       map[string]string{"prometheus.io/scrape": "true", "prometheus.io/port": "9090"},
+			}
+			lrp.Spec.MemoryMB = 0
+			lrp.Spec.CPUWeight = 0
+		})
+
+		It("uses them instead of the controller configuration", func() {
+			podSpec := statefulSet.Spec.Template.Spec
+			Expect(podSpec.ServiceAccountName).To(Equal("namespace-account"))
+			Expect(podSpec.ImagePullSecrets).To(ContainElement(corev1.LocalObjectReference{Name: "namespace-registry-secret"}))
+			Expect(podSpec.ImagePullSecrets).NotTo(ContainElement(corev1.LocalObjectReference{Name: "secret-name"}))
+			Expect(podSpec.AutomountServiceAccountToken).To(BeNil())
+			Expect(podSpec.NodeSelector).To(Equal(map[string]string{"segment": "isolated"}))
+			Expect(podSpec.SecurityContext.RunAsUser).To(PointTo(BeEquivalentTo(5000)))
+			Expect(podSpec.TopologySpreadConstraints[0].WhenUnsatisfiable).To(Equal(corev1.DoNotSchedule))
+			Expect(podSpec.Containers[0].Resources.Limits.Memory().String()).To(Equal("256Mi"))
+		})
+
+		It("adds the default pod labels and annotations without replacing the controller ones", func() {
+			Expect(statefulSet.Spec.Template.Labels).To(HaveKeyWithValue("team", "payments"))
+			Expect(statefulSet.Spec.Template.Labels).To(HaveKeyWithValue(stset.LabelGUID, "guid_1234"))
+			Expect(statefulSet.Spec.Template.Annotations).To(HaveKeyWithValue("prometheus.io/port", "9090"))
+			Expect(statefulSet.Spec.Template.Annotations).To(HaveKeyWithValue("prometheus.io/scrape", "secret-value"))
+			Expect(statefulSet.Labels).NotTo(HaveKey("team"))
+			Expect(statefulSet.Annotations).NotTo(HaveKey("prometheus.io/port"))
+		})
+
+		It("does not change the LRP", func() {
+			Expect(lrp.Spec.MemoryMB).To(BeZero())
+			Expect(lrp.Spec.PlacementTags).To(BeEmpty())
+		})
+	})
+
 	When("the app has environment set", func() {
 		BeforeEach(func() {
 			lrp.Spec.Environment = []corev1.EnvVar{
@@ -295,6 +556,10 @@ var _ = Describe("LRP to StatefulSet Converter", func() {
 						"FOO": "BAZ",
 					},
 					MemoryMB: 102,
+					Health: &eiriniv1.Healthcheck{
+						Type:    "exec",
+						Command: []string{"check", "the second sidecar"},
+					},
 				},
 			}
 		})
@@ -319,6 +584,7 @@ var _ = Describe("LRP to StatefulSet Converter", func() {
 							corev1.ResourceCPU:    *resource.NewScaledQuantity(int64(lrp.Spec.CPUWeight), resource.Milli),
 						},
 					},
+					SecurityContext: k8s.ContainerSecurityContext(),
 				},
 				corev1.Container{
 					Name:    "second-sidecar",
@@ -335,6 +601,19 @@ var _ = Describe("LRP to StatefulSet Converter", func() {
 							corev1.ResourceCPU:    *resource.NewScaledQuantity(int64(lrp.Spec.CPUWeight), resource.Milli),
 						},
 					},
+					LivenessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							Exec: &corev1.ExecAction{Command: []string{"check", "the second sidecar"}},
+						},
+						FailureThreshold: 4,
+					},
+					ReadinessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							Exec: &corev1.ExecAction{Command: []string{"check", "the second sidecar"}},
+						},
+						FailureThreshold: 1,
+					},
+					SecurityContext: k8s.ContainerSecurityContext(),
 				},
 			))
 		})
@@ -370,4 +649,17 @@ var _ = Describe("LRP to StatefulSet Converter", func() {
 			Expect(secret.Name).To(Equal("private-registry-secret"))
 		})
 	})
+
+	When("the app references image pull secrets", func() {
+		BeforeEach(func() {
+			lrp.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: "my-registry-creds"}}
+		})
+
+		It("adds them to the pod image pull secrets", func() {
+			Expect(statefulSet.Spec.Template.Spec.ImagePullSecrets).To(ConsistOf(
+				corev1.LocalObjectReference{Name: "secret-name"},
+				corev1.LocalObjectReference{Name: "my-registry-creds"},
+			))
+		})
+	})
 })
@@ -2,6 +2,8 @@ package stset
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 
+import "code.cloudfoundry.org/eirini-controller/k8s"
+
 const (
 	AppSourceType = "APP"
 
@@ -22,7 +24,7 @@ const (
 	LabelSpaceGUID   = AnnotationSpaceGUID
 	LabelSpaceName   = AnnotationSpaceName
 	LabelVersion     = "korifi.cloudfoundry.org/version"
-	LabelAppGUID     = "korifi.cloudfoundry.org/app-guid"
+	LabelAppGUID     = k8s.LabelAppGUID
 	LabelProcessType = "korifi.cloudfoundry.org/process-type"
 	LabelSourceType  = "korifi.cloudfoundry.org/source-type"
 
@@ -11,12 +11,13 @@ import (
 )
 
 type FakeLRPToStatefulSetConverter struct {
-	ConvertStub        func(string, *v1a.LRP, *v1b.Secret) (*v1.StatefulSet, error)
+	ConvertStub        func(string, *v1a.LRP, *v1b.Secret, *v1a.WorkloadDefaultsSpec) (*v1.StatefulSet, error)
 	convertMutex       sync.RWMutex
 	convertArgsForCall []struct {
 		arg1 string
 		arg2 *v1a.LRP
 		arg3 *v1b.Secret
+		arg4 *v1a.WorkloadDefaultsSpec
 	}
 	convertReturns struct {
 		result1 *v1.StatefulSet
@@ -30,20 +31,21 @@ type FakeLRPToStatefulSetConverter struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeLRPToStatefulSetConverter) Convert(arg1 string, arg2 *v1a.LRP, arg3 *v1b.Secret) (*v1.StatefulSet, error) {
+func (fake *FakeLRPToStatefulSetConverter) Convert(arg1 string, arg2 *v1a.LRP, arg3 *v1b.Secret, arg4 *v1a.WorkloadDefaultsSpec) (*v1.StatefulSet, error) {
 	fake.convertMutex.Lock()
 	ret, specificReturn := fake.convertReturnsOnCall[len(fake.convertArgsForCall)]
 	fake.convertArgsForCall = append(fake.convertArgsForCall, struct {
 		arg1 string
 		arg2 *v1a.LRP
 		arg3 *v1b.Secret
-	}{arg1, arg2, arg3})
+		arg4 *v1a.WorkloadDefaultsSpec
+	}{arg1, arg2, arg3, arg4})
 	stub := fake.ConvertStub
 	fakeReturns := fake.convertReturns
-	fake.recordInvocation("Convert", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("Convert", []interface{}{arg1, arg2, arg3, arg4})
 	fake.convertMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -57,17 +59,17 @@ func (fake *FakeLRPToStatefulSetConverter) ConvertCallCount() int {
 	return len(fake.convertArgsForCall)
 }
 
-func (fake *FakeLRPToStatefulSetConverter) ConvertCalls(stub func(string, *v1a.LRP, *v1b.Secret) (*v1.StatefulSet, error)) {
+func (fake *FakeLRPToStatefulSetConverter) ConvertCalls(stub func(string, *v1a.LRP, *v1b.Secret, *v1a.WorkloadDefaultsSpec) (*v1.StatefulSet, error)) {
 	fake.convertMutex.Lock()
 	defer fake.convertMutex.Unlock()
 	fake.ConvertStub = stub
 }
 
-func (fake *FakeLRPToStatefulSetConverter) ConvertArgsForCall(i int) (string, *v1a.LRP, *v1b.Secret) {
+func (fake *FakeLRPToStatefulSetConverter) ConvertArgsForCall(i int) (string, *v1a.LRP, *v1b.Secret, *v1a.WorkloadDefaultsSpec) {
 	fake.convertMutex.RLock()
 	defer fake.convertMutex.RUnlock()
 	argsForCall := fake.convertArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeLRPToStatefulSetConverter) ConvertReturns(result1 *v1.StatefulSet, result2 error) {
@@ -29,20 +29,20 @@ func (u *Updater) Update(ctx context.Context, lrp *eiriniv1.LRP, stSet *appsv1.S
 
 	updatedStatefulSet, updated := u.getUpdatedStatefulSetObj(stSet, lrp.Spec.Instances, lrp.Spec.Image)
 
-	if !updated {
-		return nil
-	}
-
-	if err := u.client.Patch(ctx, updatedStatefulSet, client.MergeFrom(stSet)); err != nil {
-		logger.Error("failed-to-patch-statefulset", err, lager.Data{"namespace": stSet.Namespace})
+	if updated {
+		if err := u.client.Patch(ctx, updatedStatefulSet, client.MergeFrom(stSet)); err != nil {
+			logger.Error("failed-to-patch-statefulset", err, lager.Data{"namespace": stSet.Namespace})
 
-		return errors.Wrap(err, "failed to patch statefulset")
+			return errors.Wrap(err, "failed to patch statefulset")
+		}
 	}
 
+	// the disruption budget can change without the statefulset changing,
+	// e.g. when the LRP overrides the default policy
 	if err := u.pdbUpdater.Update(ctx, stSet, lrp); err != nil {
 		logger.Error("failed-to-update-disruption-budget", err, lager.Data{"namespace": stSet.Namespace})
 
-		return errors.Wrap(err, "failed to delete pod disruption budget")
+		return errors.Wrap(err, "failed to update pod disruption budget")
 	}
 
 	return nil
@@ -126,6 +126,21 @@ var _ = Describe("Update", func() {
 		})
 	})
 
+	When("neither the instance count nor the image change", func() {
+		BeforeEach(func() {
+			updatedLRP.Spec.Instances = 3
+			updatedLRP.Spec.Image = "old/image"
+		})
+
+		It("does not patch the statefulset", func() {
+			Expect(client.PatchCallCount()).To(BeZero())
+		})
+
+		It("still updates the pod disruption budget", func() {
+			Expect(pdbUpdater.UpdateCallCount()).To(Equal(1))
+		})
+	})
+
 	When("update fails", func() {
 		BeforeEach(func() {
 			client.PatchReturns(errors.New("boom"))
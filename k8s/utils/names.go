@@ -13,6 +13,8 @@ import (
 const (
 	sanitizedNameMaxLen    = 40
 	sanitizedJobNameMaxLen = 50
+
+	appServiceAccountPrefix = "eirini-app-"
 )
 
 func sanitizeName(name, fallback string) string {
@@ -60,3 +62,14 @@ func GetJobName(task *eiriniv1.Task) string {
 
 	return sanitizeNameWithMaxStringLen(sanitizedName, task.Spec.GUID, sanitizedJobNameMaxLen)
 }
+
+// GetAppServiceAccountName is the name of the service account shared by the
+// LRPs and tasks of an app.
+func GetAppServiceAccountName(appGUID string) (string, error) {
+	fallback, err := util.Hash(appGUID)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate hash")
+	}
+
+	return appServiceAccountPrefix + sanitizeName(appGUID, fallback), nil
+}
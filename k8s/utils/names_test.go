@@ -77,4 +77,20 @@ var _ = Describe("Names", func() {
 			})
 		})
 	})
+
+	Describe("GetAppServiceAccountName", func() {
+		It("prefixes the app guid", func() {
+			name, err := GetAppServiceAccountName("3e1c5b7a-94f4-4a7b-8e8e-0c4a9f2a1d6b")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(name).To(Equal("eirini-app-3e1c5b7a-94f4-4a7b-8e8e-0c4a9f2a1d6b"))
+		})
+
+		When("the app guid is not a valid name", func() {
+			It("uses its hash instead", func() {
+				name, err := GetAppServiceAccountName("My App!")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(name).To(MatchRegexp("^eirini-app-[0-9a-f]{10}$"))
+			})
+		})
+	})
 })
@@ -0,0 +1,179 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/eirini-controller/k8s"
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"code.cloudfoundry.org/lager"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	DefaultInstances = 1
+
+	// CPU weights are proportional to the memory of the workload, reaching
+	// the maximum weight at 8GiB
+	maxCPUWeight       = 100
+	minCPUWeight       = 1
+	maxCPUWeightMemory = 8192
+
+	// Task CPU requests are in millicores and follow the same curve,
+	// reaching a full core at 8GiB
+	maxTaskCPUMillis = 1000
+	minTaskCPUMillis = 10
+)
+
+type LRPDefaulter struct {
+	logger  lager.Logger
+	decoder *admission.Decoder
+	client  client.Client
+}
+
+func NewLRPDefaulter(logger lager.Logger, decoder *admission.Decoder, client client.Client) *LRPDefaulter {
+	return &LRPDefaulter{
+		logger:  logger,
+		decoder: decoder,
+		client:  client,
+	}
+}
+
+func (d *LRPDefaulter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	lrp := &eiriniv1.LRP{}
+
+	err := d.decoder.DecodeRaw(req.Object, lrp)
+	if err != nil {
+		return errorResponse("Error decoding object %s: %s", req.Object.String(), err.Error())
+	}
+
+	// instances is not omitempty, so zero instances cannot be told apart
+	// from a missing field once decoded
+	var rawSpec struct {
+		Spec struct {
+			Instances *int `json:"instances"`
+		} `json:"spec"`
+	}
+
+	if err = json.Unmarshal(req.Object.Raw, &rawSpec); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if rawSpec.Spec.Instances == nil {
+		lrp.Spec.Instances = DefaultInstances
+	}
+
+	defaults, err := k8s.GetWorkloadDefaults(ctx, d.client, req.Namespace)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	DefaultLRP(lrp, defaults)
+
+	return patchResponse(req, lrp)
+}
+
+type TaskDefaulter struct {
+	logger  lager.Logger
+	decoder *admission.Decoder
+	client  client.Client
+}
+
+func NewTaskDefaulter(logger lager.Logger, decoder *admission.Decoder, client client.Client) *TaskDefaulter {
+	return &TaskDefaulter{
+		logger:  logger,
+		decoder: decoder,
+		client:  client,
+	}
+}
+
+func (d *TaskDefaulter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	task := &eiriniv1.Task{}
+
+	err := d.decoder.DecodeRaw(req.Object, task)
+	if err != nil {
+		return errorResponse("Error decoding object %s: %s", req.Object.String(), err.Error())
+	}
+
+	defaults, err := k8s.GetWorkloadDefaults(ctx, d.client, req.Namespace)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	DefaultTask(task, defaults)
+
+	return patchResponse(req, task)
+}
+
+// DefaultLRP fills in the health check type and the CPU weight of the LRP.
+// The weight follows the memory the LRP gets once the WorkloadDefaults of
+// its namespace apply, unless those set a weight of their own. The other
+// namespace defaults are left to the controller, so that changing them
+// reaches existing LRPs.
+func DefaultLRP(lrp *eiriniv1.LRP, defaults *eiriniv1.WorkloadDefaultsSpec) {
+	defaultHealthcheck(&lrp.Spec.Health)
+
+	if lrp.Spec.CPUWeight == 0 {
+		defaulted := k8s.ApplyLRPDefaults(lrp, defaults)
+		lrp.Spec.CPUWeight = defaulted.Spec.CPUWeight
+
+		if lrp.Spec.CPUWeight == 0 {
+			lrp.Spec.CPUWeight = defaultCPUWeight(defaulted.Spec.MemoryMB)
+		}
+	}
+}
+
+// DefaultTask fills in the CPU of the task, following the memory it gets
+// once the WorkloadDefaults of its namespace apply.
+func DefaultTask(task *eiriniv1.Task, defaults *eiriniv1.WorkloadDefaultsSpec) {
+	if task.Spec.CPUMillis == 0 {
+		task.Spec.CPUMillis = defaultTaskCPUMillis(k8s.ApplyTaskDefaults(task, defaults).Spec.MemoryMB)
+	}
+}
+
+// defaultHealthcheck makes the process check that an empty type stands for
+// explicit, regardless of any port set on the health check
+func defaultHealthcheck(health *eiriniv1.Healthcheck) {
+	if health.Type == "" {
+		health.Type = eiriniv1.HealthcheckTypeProcess
+	}
+}
+
+func defaultCPUWeight(memoryMB int64) uint8 {
+	weight := memoryMB * maxCPUWeight / maxCPUWeightMemory
+
+	if weight < minCPUWeight {
+		return minCPUWeight
+	}
+
+	if weight > maxCPUWeight {
+		return maxCPUWeight
+	}
+
+	return uint8(weight)
+}
+
+func defaultTaskCPUMillis(memoryMB int64) int64 {
+	millis := memoryMB * maxTaskCPUMillis / maxCPUWeightMemory
+
+	if millis < minTaskCPUMillis {
+		return minTaskCPUMillis
+	}
+
+	if millis > maxTaskCPUMillis {
+		return maxTaskCPUMillis
+	}
+
+	return millis
+}
+
+func patchResponse(req admission.Request, obj interface{}) admission.Response {
+	marshaled, err := json.Marshal(obj)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
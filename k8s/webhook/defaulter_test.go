@@ -0,0 +1,312 @@
+package webhook_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/eirini-controller/k8s/k8sfakes"
+	"code.cloudfoundry.org/eirini-controller/k8s/webhook"
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"code.cloudfoundry.org/eirini-controller/tests"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gomodules.xyz/jsonpatch/v2"
+	admissionv1 "k8s.io/api/admission/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var _ = Describe("Defaulters", func() {
+	var (
+		decoder    *admission.Decoder
+		fakeClient *k8sfakes.FakeClient
+		defaults   *eiriniv1.WorkloadDefaultsSpec
+		object     runtime.RawExtension
+		resp       admission.Response
+	)
+
+	BeforeEach(func() {
+		var err error
+		decoder, err = admission.NewDecoder(scheme.Scheme)
+		Expect(err).NotTo(HaveOccurred())
+
+		defaults = nil
+		fakeClient = new(k8sfakes.FakeClient)
+		fakeClient.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			Expect(key).To(Equal(client.ObjectKey{Namespace: "the-namespace", Name: eiriniv1.WorkloadDefaultsName}))
+
+			if defaults == nil {
+				return k8serrors.NewNotFound(schema.GroupResource{}, eiriniv1.WorkloadDefaultsName)
+			}
+
+			obj.(*eiriniv1.WorkloadDefaults).Spec = *defaults //nolint:forcetypeassert
+
+			return nil
+		}
+	})
+
+	Describe("LRPDefaulter", func() {
+		var lrp *eiriniv1.LRP
+
+		BeforeEach(func() {
+			lrp = &eiriniv1.LRP{
+				Spec: eiriniv1.LRPSpec{
+					GUID:      "guid",
+					Image:     "eirini/dorini",
+					Instances: 2,
+					MemoryMB:  1024,
+					CPUWeight: 42,
+					Health: eiriniv1.Healthcheck{
+						Type: "http",
+						Port: 8080,
+					},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			if object.Raw == nil {
+				object = rawExt(lrp)
+			}
+
+			defaulter := webhook.NewLRPDefaulter(tests.NewTestLogger("lrp-defaulter"), decoder, fakeClient)
+			resp = defaulter.Handle(context.Background(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Namespace: "the-namespace",
+					Object:    object,
+				},
+			})
+		})
+
+		AfterEach(func() {
+			object = runtime.RawExtension{}
+		})
+
+		It("does not change a fully specified LRP", func() {
+			Expect(resp.Allowed).To(BeTrue())
+			Expect(resp.Patches).To(BeEmpty())
+		})
+
+		When("the health check type is not set", func() {
+			BeforeEach(func() {
+				lrp.Spec.Health.Type = ""
+			})
+
+			It("defaults it to process, even if a port is set", func() {
+				Expect(resp.Patches).To(ConsistOf(jsonpatch.Operation{
+					Operation: "replace",
+					Path:      "/spec/health/type",
+					Value:     "process",
+				}))
+			})
+		})
+
+		When("the CPU weight is not set", func() {
+			BeforeEach(func() {
+				lrp.Spec.CPUWeight = 0
+			})
+
+			It("defaults it proportionally to the memory", func() {
+				Expect(resp.Patches).To(ConsistOf(jsonpatch.Operation{
+					Operation: "replace",
+					Path:      "/spec/cpuWeight",
+					Value:     float64(12),
+				}))
+			})
+
+			When("the memory is very small", func() {
+				BeforeEach(func() {
+					lrp.Spec.MemoryMB = 8
+				})
+
+				It("uses the minimum weight", func() {
+					Expect(resp.Patches).To(ConsistOf(jsonpatch.Operation{
+						Operation: "replace",
+						Path:      "/spec/cpuWeight",
+						Value:     float64(1),
+					}))
+				})
+			})
+
+			When("the memory is very large", func() {
+				BeforeEach(func() {
+					lrp.Spec.MemoryMB = 65536
+				})
+
+				It("uses the maximum weight", func() {
+					Expect(resp.Patches).To(ConsistOf(jsonpatch.Operation{
+						Operation: "replace",
+						Path:      "/spec/cpuWeight",
+						Value:     float64(100),
+					}))
+				})
+			})
+
+			When("the namespace defaults the memory", func() {
+				BeforeEach(func() {
+					lrp.Spec.MemoryMB = 0
+					defaults = &eiriniv1.WorkloadDefaultsSpec{
+						Resources: &eiriniv1.ResourceDefaults{MemoryMB: 2048},
+					}
+				})
+
+				It("follows the default memory, leaving the memory to the controller", func() {
+					Expect(resp.Patches).To(ConsistOf(jsonpatch.Operation{
+						Operation: "replace",
+						Path:      "/spec/cpuWeight",
+						Value:     float64(25),
+					}))
+				})
+			})
+
+			When("the namespace defaults the CPU weight", func() {
+				BeforeEach(func() {
+					defaults = &eiriniv1.WorkloadDefaultsSpec{
+						Resources: &eiriniv1.ResourceDefaults{CPUWeight: 7},
+					}
+				})
+
+				It("uses it", func() {
+					Expect(resp.Patches).To(ConsistOf(jsonpatch.Operation{
+						Operation: "replace",
+						Path:      "/spec/cpuWeight",
+						Value:     float64(7),
+					}))
+				})
+			})
+		})
+
+		When("getting the namespace defaults fails", func() {
+			BeforeEach(func() {
+				fakeClient.GetStub = nil
+				fakeClient.GetReturns(errors.New("boom"))
+			})
+
+			It("errors", func() {
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Message).To(ContainSubstring("boom"))
+			})
+		})
+
+		When("the instance count is missing", func() {
+			BeforeEach(func() {
+				object = runtime.RawExtension{
+					Raw: []byte(`{"spec":{"GUID":"guid","image":"eirini/dorini","memoryMB":1024,"cpuWeight":42,"health":{"type":"process"}}}`),
+				}
+			})
+
+			It("defaults it", func() {
+				Expect(resp.Patches).To(ContainElement(jsonpatch.Operation{
+					Operation: "add",
+					Path:      "/spec/instances",
+					Value:     float64(1),
+				}))
+			})
+		})
+
+		When("the instance count is explicitly zero", func() {
+			BeforeEach(func() {
+				lrp.Spec.Instances = 0
+			})
+
+			It("keeps it", func() {
+				Expect(resp.Patches).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("TaskDefaulter", func() {
+		var task *eiriniv1.Task
+
+		BeforeEach(func() {
+			task = &eiriniv1.Task{
+				Spec: eiriniv1.TaskSpec{
+					GUID:      "guid",
+					Image:     "eirini/busybox",
+					MemoryMB:  2048,
+					CPUMillis: 30,
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			defaulter := webhook.NewTaskDefaulter(tests.NewTestLogger("task-defaulter"), decoder, fakeClient)
+			resp = defaulter.Handle(context.Background(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Namespace: "the-namespace",
+					Object:    rawExt(task),
+				},
+			})
+		})
+
+		It("does not change a fully specified task", func() {
+			Expect(resp.Allowed).To(BeTrue())
+			Expect(resp.Patches).To(BeEmpty())
+		})
+
+		When("the CPU is not set", func() {
+			BeforeEach(func() {
+				task.Spec.CPUMillis = 0
+			})
+
+			It("defaults it in millicores proportionally to the memory", func() {
+				Expect(resp.Patches).To(ConsistOf(jsonpatch.Operation{
+					Operation: "replace",
+					Path:      "/spec/cpuMillis",
+					Value:     float64(250),
+				}))
+			})
+
+			When("the memory is very small", func() {
+				BeforeEach(func() {
+					task.Spec.MemoryMB = 16
+				})
+
+				It("uses the minimum", func() {
+					Expect(resp.Patches).To(ConsistOf(jsonpatch.Operation{
+						Operation: "replace",
+						Path:      "/spec/cpuMillis",
+						Value:     float64(10),
+					}))
+				})
+			})
+
+			When("the memory is very large", func() {
+				BeforeEach(func() {
+					task.Spec.MemoryMB = 65536
+				})
+
+				It("requests at most a full core", func() {
+					Expect(resp.Patches).To(ConsistOf(jsonpatch.Operation{
+						Operation: "replace",
+						Path:      "/spec/cpuMillis",
+						Value:     float64(1000),
+					}))
+				})
+			})
+
+			When("the namespace defaults the memory", func() {
+				BeforeEach(func() {
+					task.Spec.MemoryMB = 0
+					defaults = &eiriniv1.WorkloadDefaultsSpec{
+						Resources: &eiriniv1.ResourceDefaults{MemoryMB: 4096},
+					}
+				})
+
+				It("follows the default memory", func() {
+					Expect(resp.Patches).To(ConsistOf(jsonpatch.Operation{
+						Operation: "replace",
+						Path:      "/spec/cpuMillis",
+						Value:     float64(500),
+					}))
+				})
+			})
+		})
+	})
+})
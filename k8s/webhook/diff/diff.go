@@ -3,7 +3,6 @@ package diff
 import (
 	"strings"
 
-	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 )
@@ -51,12 +50,17 @@ func appendIfMissing(slice []string, newElem string) []string {
 	return append(slice, newElem)
 }
 
-func CompareLRPSpecs(updatedLRPSpec, originalLRPSpec *eiriniv1.LRPSpec, ignoredFields ...string) string {
-	var reporter Reporter
+// Compare reports the paths of the fields that differ between updated and
+// original, skipping the ignored fields of T. T must be a struct type.
+func Compare[T any](updated, original *T, ignoredFields ...string) string {
+	var (
+		reporter Reporter
+		zero     T
+	)
 
-	ignoreOpts := cmpopts.IgnoreFields(eiriniv1.LRPSpec{}, ignoredFields...)
-	equal := cmp.Equal(updatedLRPSpec, originalLRPSpec, cmp.Reporter(&reporter), ignoreOpts)
+	ignoreOpts := cmpopts.IgnoreFields(zero, ignoredFields...)
 
+	equal := cmp.Equal(updated, original, cmp.Reporter(&reporter), ignoreOpts)
 	if !equal {
 		return reporter.String()
 	}
@@ -5,11 +5,14 @@ import (
 	"fmt"
 	"net/http"
 
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
 	"code.cloudfoundry.org/eirini-controller/k8s/webhook/diff"
 	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
 	"code.cloudfoundry.org/lager"
 	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
@@ -17,15 +20,25 @@ type LRPResourceValidator struct {
 	logger           lager.Logger
 	decoder          *admission.Decoder
 	lrpMutableFields []string
+	placementTags    map[string]eirinictrl.PlacementTag
+	workloadIdentity eirinictrl.WorkloadIdentity
 }
 
-func NewLRPResourceValidator(logger lager.Logger, decoder *admission.Decoder) *LRPResourceValidator {
+func NewLRPResourceValidator(
+	logger lager.Logger,
+	decoder *admission.Decoder,
+	placementTags map[string]eirinictrl.PlacementTag,
+	workloadIdentity eirinictrl.WorkloadIdentity,
+) *LRPResourceValidator {
 	return &LRPResourceValidator{
-		logger:  logger,
-		decoder: decoder,
+		logger:           logger,
+		decoder:          decoder,
+		placementTags:    placementTags,
+		workloadIdentity: workloadIdentity,
 		lrpMutableFields: []string{
 			"Image",
 			"Instances",
+			"DisruptionBudget",
 		},
 	}
 }
@@ -38,6 +51,18 @@ func (v *LRPResourceValidator) Handle(ctx context.Context, req admission.Request
 		return errorResponse("Error decoding object %s: %s", req.Object.String(), err.Error())
 	}
 
+	errs := validateLRPSpec(&updatedLRP.Spec, v.placementTags, v.workloadIdentity)
+	budgetPath := field.NewPath("spec", "disruptionBudget")
+
+	if req.Operation != admissionv1.Update {
+		errs = append(errs, validateEvictableDisruptionBudget(budgetPath, updatedLRP.Spec.DisruptionBudget, updatedLRP.Spec.Instances)...)
+		if len(errs) > 0 {
+			return errorResponse("Invalid LRP: %s", errs.ToAggregate().Error())
+		}
+
+		return admission.Allowed("")
+	}
+
 	originalLRP := &eiriniv1.LRP{}
 
 	err = v.decoder.DecodeRaw(req.OldObject, originalLRP)
@@ -45,7 +70,22 @@ func (v *LRPResourceValidator) Handle(ctx context.Context, req admission.Request
 		return errorResponse("Error decoding old object %s: %s", req.OldObject.String(), err.Error())
 	}
 
-	diffReport := diff.CompareLRPSpecs(&updatedLRP.Spec, &originalLRP.Spec, v.lrpMutableFields...)
+	// v2 cannot express the deprecated private registry, so writing the LRP
+	// through v2 would silently drop its credentials
+	if originalLRP.Spec.PrivateRegistry != nil && req.RequestKind != nil && req.RequestKind.Version != eiriniv1.SchemeGroupVersion.Version {
+		return errorResponse("LRP uses the deprecated privateRegistry, which %s cannot express: update it through %s, or move the credentials to an image pull secret",
+			req.RequestKind.Version, eiriniv1.SchemeGroupVersion.Version)
+	}
+
+	if !equality.Semantic.DeepEqual(updatedLRP.Spec.DisruptionBudget, originalLRP.Spec.DisruptionBudget) {
+		errs = append(errs, validateEvictableDisruptionBudget(budgetPath, updatedLRP.Spec.DisruptionBudget, updatedLRP.Spec.Instances)...)
+	}
+
+	if errs = newErrors(errs, validateLRPSpec(&originalLRP.Spec, v.placementTags, v.workloadIdentity)); len(errs) > 0 {
+		return errorResponse("Invalid LRP: %s", errs.ToAggregate().Error())
+	}
+
+	diffReport := diff.Compare(&updatedLRP.Spec, &originalLRP.Spec, v.lrpMutableFields...)
 	if diffReport != "" {
 		return errorResponse("Changing immutable fields not allowed: %s", diffReport)
 	}
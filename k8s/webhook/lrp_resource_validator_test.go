@@ -0,0 +1,524 @@
+package webhook_test
+
+import (
+	"context"
+
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/k8s/webhook"
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"code.cloudfoundry.org/eirini-controller/tests"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var _ = Describe("LRPResourceValidator", func() {
+	var (
+		validator   *webhook.LRPResourceValidator
+		lrp         *eiriniv1.LRP
+		originalLRP *eiriniv1.LRP
+		operation   admissionv1.Operation
+		resp        admission.Response
+	)
+
+	BeforeEach(func() {
+		decoder, err := admission.NewDecoder(scheme.Scheme)
+		Expect(err).NotTo(HaveOccurred())
+
+		validator = webhook.NewLRPResourceValidator(tests.NewTestLogger("lrp-resource-validator"), decoder, map[string]eirinictrl.PlacementTag{
+			"isolated": {NodeSelector: map[string]string{"segment": "isolated"}},
+			"gpu":      {NodeSelector: map[string]string{"gpu": "true"}},
+			"shared":   {NodeSelector: map[string]string{"segment": "shared"}},
+		}, eirinictrl.WorkloadIdentity{
+			PerAppServiceAccounts:  true,
+			AllowedServiceAccounts: []string{"my-identity"},
+		})
+
+		lrp = &eiriniv1.LRP{
+			Spec: eiriniv1.LRPSpec{
+				GUID:      "guid",
+				Image:     "eirini/dorini",
+				Instances: 1,
+				Ports:     []int32{8080},
+				Health: eiriniv1.Healthcheck{
+					Type: "port",
+					Port: 8080,
+				},
+			},
+		}
+		originalLRP = lrp.DeepCopy()
+		operation = admissionv1.Create
+	})
+
+	JustBeforeEach(func() {
+		req := admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: operation,
+				Object:    rawExt(lrp),
+			},
+		}
+
+		if operation == admissionv1.Update {
+			req.OldObject = rawExt(originalLRP)
+		}
+
+		resp = validator.Handle(context.Background(), req)
+	})
+
+	It("allows the creation", func() {
+		Expect(resp.Allowed).To(BeTrue())
+	})
+
+	DescribeTable("supported health check types",
+		func(health eiriniv1.Healthcheck) {
+			lrp.Spec.Health = health
+			resp = validator.Handle(context.Background(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Object:    rawExt(lrp),
+				},
+			})
+			Expect(resp.Allowed).To(BeTrue())
+		},
+		Entry("no type", eiriniv1.Healthcheck{}),
+		Entry("process", eiriniv1.Healthcheck{Type: "process"}),
+		Entry("port", eiriniv1.Healthcheck{Type: "port", Port: 8080}),
+		Entry("http", eiriniv1.Healthcheck{Type: "http", Port: 8080, Endpoint: "/health"}),
+		Entry("exec", eiriniv1.Healthcheck{Type: "exec", Command: []string{"true"}}),
+	)
+
+	When("the health check type is not supported", func() {
+		BeforeEach(func() {
+			lrp.Spec.Health.Type = "telepathy"
+		})
+
+		It("rejects the creation", func() {
+			ExpectBadRequestErrorResponse(resp, `Invalid LRP: spec.health: Invalid value: "telepathy": unsupported health check type "telepathy"`)
+		})
+	})
+
+	When("an exec health check has no command", func() {
+		BeforeEach(func() {
+			lrp.Spec.Health = eiriniv1.Healthcheck{Type: "exec"}
+		})
+
+		It("rejects the creation", func() {
+			ExpectBadRequestErrorResponse(resp, "exec health check requires a command")
+		})
+	})
+
+	When("the readiness health check type is not supported", func() {
+		BeforeEach(func() {
+			lrp.Spec.ReadinessHealth = &eiriniv1.Healthcheck{Type: "telepathy"}
+		})
+
+		It("rejects the creation", func() {
+			ExpectBadRequestErrorResponse(resp, `spec.readinessHealth: Invalid value: "telepathy": unsupported health check type "telepathy"`)
+		})
+	})
+
+	When("a sidecar health check type is not supported", func() {
+		BeforeEach(func() {
+			lrp.Spec.Sidecars = []eiriniv1.Sidecar{{
+				Name:    "the-sidecar",
+				Command: []string{"run"},
+				Health:  &eiriniv1.Healthcheck{Type: "telepathy"},
+			}}
+		})
+
+		It("rejects the creation", func() {
+			ExpectBadRequestErrorResponse(resp, `spec.sidecars[0].health: Invalid value: "telepathy": unsupported health check type "telepathy"`)
+		})
+	})
+
+	When("the health check port is not one of the LRP ports", func() {
+		BeforeEach(func() {
+			lrp.Spec.Health.Port = 9090
+		})
+
+		It("rejects the creation", func() {
+			ExpectBadRequestErrorResponse(resp, "spec.health.port: Invalid value: 9090: must be one of the LRP ports")
+		})
+	})
+
+	When("the readiness health check port is not one of the LRP ports", func() {
+		BeforeEach(func() {
+			lrp.Spec.ReadinessHealth = &eiriniv1.Healthcheck{Type: "http", Port: 9090}
+		})
+
+		It("rejects the creation", func() {
+			ExpectBadRequestErrorResponse(resp, "spec.readinessHealth.port: Invalid value: 9090: must be one of the LRP ports")
+		})
+	})
+
+	When("sidecar names are not unique", func() {
+		BeforeEach(func() {
+			lrp.Spec.Sidecars = []eiriniv1.Sidecar{
+				{Name: "the-sidecar", Command: []string{"run"}},
+				{Name: "the-sidecar", Command: []string{"walk"}},
+			}
+		})
+
+		It("rejects the creation", func() {
+			ExpectBadRequestErrorResponse(resp, `spec.sidecars[1].name: Duplicate value: "the-sidecar"`)
+		})
+	})
+
+	When("a sidecar is named after the application container", func() {
+		BeforeEach(func() {
+			lrp.Spec.Sidecars = []eiriniv1.Sidecar{
+				{Name: "opi", Command: []string{"run"}},
+			}
+		})
+
+		It("rejects the creation", func() {
+			ExpectBadRequestErrorResponse(resp, `spec.sidecars[0].name: Duplicate value: "opi"`)
+		})
+	})
+
+	When("an env var name is invalid", func() {
+		BeforeEach(func() {
+			lrp.Spec.Env = map[string]string{"1=FOO": "bar"}
+		})
+
+		It("rejects the creation", func() {
+			ExpectBadRequestErrorResponse(resp, `spec.env[1=FOO]: Invalid value: "1=FOO"`)
+		})
+	})
+
+	When("an environment variable name is invalid", func() {
+		BeforeEach(func() {
+			lrp.Spec.Environment = []corev1.EnvVar{{Name: "FOO BAR", Value: "baz"}}
+		})
+
+		It("rejects the creation", func() {
+			ExpectBadRequestErrorResponse(resp, `spec.environment[0].name: Invalid value: "FOO BAR"`)
+		})
+	})
+
+	When("a sidecar env var name is invalid", func() {
+		BeforeEach(func() {
+			lrp.Spec.Sidecars = []eiriniv1.Sidecar{
+				{Name: "the-sidecar", Command: []string{"run"}, Env: map[string]string{"": "bar"}},
+			}
+		})
+
+		It("rejects the creation", func() {
+			ExpectBadRequestErrorResponse(resp, `spec.sidecars[0].env[]: Invalid value: ""`)
+		})
+	})
+
+	When("the image reference is invalid", func() {
+		BeforeEach(func() {
+			lrp.Spec.Image = "eirini/Dorini:"
+		})
+
+		It("rejects the creation", func() {
+			ExpectBadRequestErrorResponse(resp, `spec.image: Invalid value: "eirini/Dorini:": is not a valid image reference`)
+		})
+	})
+
+	When("an image pull secret has no name", func() {
+		BeforeEach(func() {
+			lrp.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: ""}}
+		})
+
+		It("rejects the creation", func() {
+			ExpectBadRequestErrorResponse(resp, "spec.imagePullSecrets[0].name: Required value")
+		})
+	})
+
+	When("the disruption budget sets both a minimum and a maximum", func() {
+		BeforeEach(func() {
+			minAvailable, maxUnavailable := intstr.FromInt(1), intstr.FromInt(1)
+			lrp.Spec.DisruptionBudget = &eiriniv1.DisruptionBudget{MinAvailable: &minAvailable, MaxUnavailable: &maxUnavailable}
+		})
+
+		It("rejects the creation", func() {
+			ExpectBadRequestErrorResponse(resp, "spec.disruptionBudget: Forbidden: minAvailable and maxUnavailable cannot be both set")
+		})
+	})
+
+	When("the disruption budget is not a valid percentage", func() {
+		BeforeEach(func() {
+			maxUnavailable := intstr.FromString("half")
+			lrp.Spec.DisruptionBudget = &eiriniv1.DisruptionBudget{MaxUnavailable: &maxUnavailable}
+		})
+
+		It("rejects the creation", func() {
+			ExpectBadRequestErrorResponse(resp, `spec.disruptionBudget.maxUnavailable: Invalid value: "half"`)
+		})
+	})
+
+	DescribeTable("disruption budgets blocking every eviction",
+		func(budget eiriniv1.DisruptionBudget, message string) {
+			lrp.Spec.Instances = 3
+			lrp.Spec.DisruptionBudget = &budget
+			resp = validator.Handle(context.Background(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Object:    rawExt(lrp),
+				},
+			})
+			ExpectBadRequestErrorResponse(resp, message)
+		},
+		Entry("all instances available", eiriniv1.DisruptionBudget{MinAvailable: intOrStrPtr(intstr.FromString("100%"))},
+			`spec.disruptionBudget.minAvailable: Invalid value: "100%": must leave at least one instance that can be evicted`),
+		Entry("a percentage rounding up to all instances", eiriniv1.DisruptionBudget{MinAvailable: intOrStrPtr(intstr.FromString("70%"))},
+			`spec.disruptionBudget.minAvailable: Invalid value: "70%"`),
+		Entry("as many available as instances", eiriniv1.DisruptionBudget{MinAvailable: intOrStrPtr(intstr.FromInt(3))},
+			`spec.disruptionBudget.minAvailable: Invalid value: "3"`),
+		Entry("no instance unavailable", eiriniv1.DisruptionBudget{MaxUnavailable: intOrStrPtr(intstr.FromInt(0))},
+			`spec.disruptionBudget.maxUnavailable: Invalid value: "0": must allow at least one instance to be evicted`),
+		Entry("no percentage unavailable", eiriniv1.DisruptionBudget{MaxUnavailable: intOrStrPtr(intstr.FromString("0%"))},
+			`spec.disruptionBudget.maxUnavailable: Invalid value: "0%"`),
+	)
+
+	When("a single instance LRP keeps all instances available", func() {
+		BeforeEach(func() {
+			minAvailable := intstr.FromString("100%")
+			lrp.Spec.DisruptionBudget = &eiriniv1.DisruptionBudget{MinAvailable: &minAvailable}
+		})
+
+		It("allows the creation, as no disruption budget is created for it", func() {
+			Expect(resp.Allowed).To(BeTrue())
+		})
+	})
+
+	When("the topology spread policy is not supported", func() {
+		BeforeEach(func() {
+			lrp.Spec.TopologySpreadPolicy = "strict"
+		})
+
+		It("rejects the creation", func() {
+			ExpectBadRequestErrorResponse(resp, `spec.topologySpreadPolicy: Unsupported value: "strict": supported values: "soft", "hard"`)
+		})
+	})
+
+	When("it uses configured placement tags", func() {
+		BeforeEach(func() {
+			lrp.Spec.PlacementTags = []string{"isolated", "gpu"}
+		})
+
+		It("allows the creation", func() {
+			Expect(resp.Allowed).To(BeTrue())
+		})
+	})
+
+	When("the service account name is invalid", func() {
+		BeforeEach(func() {
+			lrp.Spec.ServiceAccountName = "My_Identity"
+		})
+
+		It("rejects the creation", func() {
+			ExpectBadRequestErrorResponse(resp, `spec.serviceAccountName: Invalid value: "My_Identity"`)
+		})
+	})
+
+	When("the service account is allowed", func() {
+		BeforeEach(func() {
+			lrp.Spec.ServiceAccountName = "my-identity"
+		})
+
+		It("allows it", func() {
+			Expect(resp.Allowed).To(BeTrue())
+		})
+	})
+
+	When("the service account is the per-app one of the app", func() {
+		BeforeEach(func() {
+			lrp.Spec.AppGUID = "app-guid"
+			lrp.Spec.ServiceAccountName = "eirini-app-app-guid"
+		})
+
+		It("allows it", func() {
+			Expect(resp.Allowed).To(BeTrue())
+		})
+	})
+
+	When("the service account is not allowed", func() {
+		BeforeEach(func() {
+			lrp.Spec.AppGUID = "app-guid"
+			lrp.Spec.ServiceAccountName = "eirini-app-other-app-guid"
+		})
+
+		It("rejects the creation", func() {
+			ExpectBadRequestErrorResponse(resp, `spec.serviceAccountName: Forbidden: service account "eirini-app-other-app-guid" is not in workload_identity.allowed_service_accounts`)
+		})
+	})
+
+	When("a placement tag is not configured", func() {
+		BeforeEach(func() {
+			lrp.Spec.PlacementTags = []string{"isolated", "windows"}
+		})
+
+		It("rejects the creation", func() {
+			ExpectBadRequestErrorResponse(resp, `spec.placementTags[1]: Unsupported value: "windows": supported values: "gpu", "isolated", "shared"`)
+		})
+	})
+
+	When("placement tags select different values of a node label", func() {
+		BeforeEach(func() {
+			lrp.Spec.PlacementTags = []string{"isolated", "shared"}
+		})
+
+		It("rejects the creation", func() {
+			ExpectBadRequestErrorResponse(resp, `placement tags "isolated" and "shared" select different values of node label "segment"`)
+		})
+	})
+
+	When("the instance count is negative", func() {
+		BeforeEach(func() {
+			lrp.Spec.Instances = -1
+		})
+
+		It("rejects the creation", func() {
+			ExpectBadRequestErrorResponse(resp, "spec.instances: Invalid value: -1: must not be negative")
+		})
+	})
+
+	When("the LRP is updated", func() {
+		BeforeEach(func() {
+			operation = admissionv1.Update
+		})
+
+		It("allows the update", func() {
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		When("the LRP is scaled down to its minimum of available instances", func() {
+			BeforeEach(func() {
+				minAvailable := intstr.FromInt(2)
+				originalLRP.Spec.Instances = 3
+				originalLRP.Spec.DisruptionBudget = &eiriniv1.DisruptionBudget{MinAvailable: &minAvailable}
+				lrp.Spec.Instances = 2
+				lrp.Spec.DisruptionBudget = originalLRP.Spec.DisruptionBudget
+			})
+
+			It("allows the update", func() {
+				Expect(resp.Allowed).To(BeTrue())
+			})
+		})
+
+		When("the disruption budget is changed to one blocking every eviction", func() {
+			BeforeEach(func() {
+				minAvailable := intstr.FromInt(3)
+				originalLRP.Spec.Instances = 3
+				lrp.Spec.Instances = 3
+				lrp.Spec.DisruptionBudget = &eiriniv1.DisruptionBudget{MinAvailable: &minAvailable}
+			})
+
+			It("rejects the update", func() {
+				ExpectBadRequestErrorResponse(resp, `spec.disruptionBudget.minAvailable: Invalid value: "3"`)
+			})
+		})
+
+		When("a mutable field is changed", func() {
+			BeforeEach(func() {
+				lrp.Spec.Instances = 3
+				lrp.Spec.Image = "eirini/notdora"
+				minAvailable := intstr.FromInt(2)
+				lrp.Spec.DisruptionBudget = &eiriniv1.DisruptionBudget{MinAvailable: &minAvailable}
+			})
+
+			It("allows the update", func() {
+				Expect(resp.Allowed).To(BeTrue())
+			})
+		})
+
+		When("an immutable field is changed", func() {
+			BeforeEach(func() {
+				lrp.Spec.MemoryMB = 512
+			})
+
+			It("rejects the update", func() {
+				ExpectBadRequestErrorResponse(resp, "Changing immutable fields not allowed: MemoryMB")
+			})
+		})
+
+		When("the health check type is changed to an unsupported one", func() {
+			BeforeEach(func() {
+				lrp.Spec.Health.Type = "telepathy"
+			})
+
+			It("rejects the update", func() {
+				ExpectBadRequestErrorResponse(resp, `unsupported health check type "telepathy"`)
+			})
+		})
+
+		When("the LRP uses a private registry", func() {
+			BeforeEach(func() {
+				originalLRP.Spec.PrivateRegistry = &eiriniv1.PrivateRegistry{Username: "user", Password: "pass"}
+				lrp.Spec.PrivateRegistry = originalLRP.Spec.PrivateRegistry
+			})
+
+			It("allows updates through v1", func() {
+				Expect(resp.Allowed).To(BeTrue())
+			})
+
+			When("it is updated through v2", func() {
+				BeforeEach(func() {
+					lrp.Spec.PrivateRegistry = nil
+				})
+
+				JustBeforeEach(func() {
+					resp = validator.Handle(context.Background(), admission.Request{
+						AdmissionRequest: admissionv1.AdmissionRequest{
+							Operation:   admissionv1.Update,
+							RequestKind: &metav1.GroupVersionKind{Group: "eirini.cloudfoundry.org", Version: "v2", Kind: "LRP"},
+							Object:      rawExt(lrp),
+							OldObject:   rawExt(originalLRP),
+						},
+					})
+				})
+
+				It("rejects the update", func() {
+					ExpectBadRequestErrorResponse(resp, "LRP uses the deprecated privateRegistry, which v2 cannot express")
+				})
+			})
+		})
+
+		When("the LRP was created before a validation rule existed", func() {
+			BeforeEach(func() {
+				originalLRP.Spec.PlacementTags = []string{"retired"}
+				lrp.Spec.PlacementTags = []string{"retired"}
+				lrp.Spec.Instances = 5
+			})
+
+			It("still allows scaling it", func() {
+				Expect(resp.Allowed).To(BeTrue())
+			})
+
+			When("the update introduces a new error", func() {
+				BeforeEach(func() {
+					lrp.Spec.Image = "eirini/not dora"
+				})
+
+				It("rejects the update with the new error only", func() {
+					ExpectBadRequestErrorResponse(resp, `Invalid LRP: spec.image: Invalid value: "eirini/not dora": is not a valid image reference`)
+					Expect(resp.Result.Message).NotTo(ContainSubstring("placementTags"))
+				})
+			})
+
+			When("the invalid field is changed to another invalid value", func() {
+				BeforeEach(func() {
+					lrp.Spec.Image = "eirini/not dora"
+					originalLRP.Spec.Image = "eirini/not dorini"
+				})
+
+				It("rejects the update", func() {
+					ExpectBadRequestErrorResponse(resp, `spec.image: Invalid value: "eirini/not dora"`)
+				})
+			})
+		})
+	})
+})
+
+func intOrStrPtr(value intstr.IntOrString) *intstr.IntOrString {
+	return &value
+}
@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"context"
+
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/k8s/webhook/diff"
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"code.cloudfoundry.org/lager"
+	admissionv1 "k8s.io/api/admission/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// TaskValidator validates the spec of new tasks and rejects changes to the
+// spec of existing ones, as they would never make it to the already created
+// job.
+type TaskValidator struct {
+	logger            lager.Logger
+	decoder           *admission.Decoder
+	placementTags     map[string]eirinictrl.PlacementTag
+	workloadIdentity  eirinictrl.WorkloadIdentity
+	taskMutableFields []string
+}
+
+func NewTaskValidator(
+	logger lager.Logger,
+	decoder *admission.Decoder,
+	placementTags map[string]eirinictrl.PlacementTag,
+	workloadIdentity eirinictrl.WorkloadIdentity,
+) *TaskValidator {
+	return &TaskValidator{
+		logger:           logger,
+		decoder:          decoder,
+		placementTags:    placementTags,
+		workloadIdentity: workloadIdentity,
+		// fields that control the lifecycle of the task, such as
+		// cancellation, are the only ones that may be listed here
+		taskMutableFields: []string{},
+	}
+}
+
+func (v *TaskValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	task := &eiriniv1.Task{}
+
+	err := v.decoder.DecodeRaw(req.Object, task)
+	if err != nil {
+		return errorResponse("Error decoding object %s: %s", req.Object.String(), err.Error())
+	}
+
+	errs := validateTaskSpec(&task.Spec, v.placementTags, v.workloadIdentity)
+
+	if req.Operation == admissionv1.Update {
+		originalTask := &eiriniv1.Task{}
+
+		err = v.decoder.DecodeRaw(req.OldObject, originalTask)
+		if err != nil {
+			return errorResponse("Error decoding old object %s: %s", req.OldObject.String(), err.Error())
+		}
+
+		diffReport := diff.Compare(&task.Spec, &originalTask.Spec, v.taskMutableFields...)
+		if diffReport != "" {
+			v.logger.Debug("task-spec-changed", lager.Data{"name": task.Name, "namespace": task.Namespace, "fields": diffReport})
+
+			return errorResponse("Changing immutable fields not allowed: %s", diffReport)
+		}
+
+		errs = newErrors(errs, validateTaskSpec(&originalTask.Spec, v.placementTags, v.workloadIdentity))
+	}
+
+	if len(errs) > 0 {
+		v.logger.Debug("invalid-task", lager.Data{"name": task.Name, "namespace": task.Namespace, "errors": errs.ToAggregate().Error()})
+
+		return errorResponse("Invalid Task: %s", errs.ToAggregate().Error())
+	}
+
+	return admission.Allowed("")
+}
@@ -0,0 +1,214 @@
+package webhook_test
+
+import (
+	"context"
+
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/k8s/webhook"
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"code.cloudfoundry.org/eirini-controller/tests"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var _ = Describe("TaskValidator", func() {
+	var (
+		validator    *webhook.TaskValidator
+		task         *eiriniv1.Task
+		originalTask *eiriniv1.Task
+		operation    admissionv1.Operation
+		resp         admission.Response
+	)
+
+	BeforeEach(func() {
+		decoder, err := admission.NewDecoder(scheme.Scheme)
+		Expect(err).NotTo(HaveOccurred())
+
+		validator = webhook.NewTaskValidator(tests.NewTestLogger("task-validator"), decoder, map[string]eirinictrl.PlacementTag{
+			"isolated": {NodeSelector: map[string]string{"segment": "isolated"}},
+			"gpu":      {NodeSelector: map[string]string{"gpu": "true"}},
+		}, eirinictrl.WorkloadIdentity{
+			PerAppServiceAccounts:  true,
+			AllowedServiceAccounts: []string{"my-identity"},
+		})
+
+		task = &eiriniv1.Task{
+			Spec: eiriniv1.TaskSpec{
+				GUID:    "guid",
+				Image:   "eirini/busybox",
+				Command: []string{"sh", "-c", "sleep 1"},
+				Env:     map[string]string{"FOO": "BAR"},
+			},
+		}
+		originalTask = task.DeepCopy()
+		operation = admissionv1.Create
+	})
+
+	JustBeforeEach(func() {
+		req := admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: operation,
+				Object:    rawExt(task),
+			},
+		}
+
+		if operation == admissionv1.Update {
+			req.OldObject = rawExt(originalTask)
+		}
+
+		resp = validator.Handle(context.Background(), req)
+	})
+
+	It("allows the task", func() {
+		Expect(resp.Allowed).To(BeTrue())
+	})
+
+	When("the image is missing", func() {
+		BeforeEach(func() {
+			task.Spec.Image = ""
+		})
+
+		It("rejects the task", func() {
+			ExpectBadRequestErrorResponse(resp, "Invalid Task: spec.image: Required value")
+		})
+	})
+
+	When("the image reference is invalid", func() {
+		BeforeEach(func() {
+			task.Spec.Image = "eirini/busy box"
+		})
+
+		It("rejects the task", func() {
+			ExpectBadRequestErrorResponse(resp, `spec.image: Invalid value: "eirini/busy box": is not a valid image reference`)
+		})
+	})
+
+	When("an env var name is invalid", func() {
+		BeforeEach(func() {
+			task.Spec.Environment = []corev1.EnvVar{{Name: "FOO=BAR"}}
+		})
+
+		It("rejects the task", func() {
+			ExpectBadRequestErrorResponse(resp, `spec.environment[0].name: Invalid value: "FOO=BAR"`)
+		})
+	})
+
+	When("it uses configured placement tags", func() {
+		BeforeEach(func() {
+			task.Spec.PlacementTags = []string{"isolated", "gpu"}
+		})
+
+		It("allows the task", func() {
+			Expect(resp.Allowed).To(BeTrue())
+		})
+	})
+
+	When("the service account name is invalid", func() {
+		BeforeEach(func() {
+			task.Spec.ServiceAccountName = "My_Identity"
+		})
+
+		It("rejects the task", func() {
+			ExpectBadRequestErrorResponse(resp, `spec.serviceAccountName: Invalid value: "My_Identity"`)
+		})
+	})
+
+	When("the service account is allowed", func() {
+		BeforeEach(func() {
+			task.Spec.ServiceAccountName = "my-identity"
+		})
+
+		It("allows it", func() {
+			Expect(resp.Allowed).To(BeTrue())
+		})
+	})
+
+	When("the service account is the per-app one of the app", func() {
+		BeforeEach(func() {
+			task.Spec.AppGUID = "app-guid"
+			task.Spec.ServiceAccountName = "eirini-app-app-guid"
+		})
+
+		It("allows it", func() {
+			Expect(resp.Allowed).To(BeTrue())
+		})
+	})
+
+	When("the service account is not allowed", func() {
+		BeforeEach(func() {
+			task.Spec.AppGUID = "app-guid"
+			task.Spec.ServiceAccountName = "eirini-app-other-app-guid"
+		})
+
+		It("rejects the task", func() {
+			ExpectBadRequestErrorResponse(resp, `spec.serviceAccountName: Forbidden: service account "eirini-app-other-app-guid" is not in workload_identity.allowed_service_accounts`)
+		})
+	})
+
+	When("a placement tag is not configured", func() {
+		BeforeEach(func() {
+			task.Spec.PlacementTags = []string{"isolated", "windows"}
+		})
+
+		It("rejects the task", func() {
+			ExpectBadRequestErrorResponse(resp, `spec.placementTags[1]: Unsupported value: "windows": supported values: "gpu", "isolated"`)
+		})
+	})
+
+	When("an image pull secret has no name", func() {
+		BeforeEach(func() {
+			task.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{}}
+		})
+
+		It("rejects the task", func() {
+			ExpectBadRequestErrorResponse(resp, "spec.imagePullSecrets[0].name: Required value")
+		})
+	})
+
+	When("the task is updated", func() {
+		BeforeEach(func() {
+			operation = admissionv1.Update
+		})
+
+		It("allows updates that do not change the spec", func() {
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		When("the metadata changes", func() {
+			BeforeEach(func() {
+				task.Labels = map[string]string{"foo": "bar"}
+			})
+
+			It("allows the update", func() {
+				Expect(resp.Allowed).To(BeTrue())
+			})
+		})
+
+		When("spec fields change", func() {
+			BeforeEach(func() {
+				task.Spec.Image = "eirini/notdora"
+				task.Spec.Command = []string{"sh", "-c", "sleep 2"}
+			})
+
+			It("rejects the update reporting the changed fields", func() {
+				ExpectBadRequestErrorResponse(resp, "Changing immutable fields not allowed: Image, Command")
+			})
+		})
+
+		When("the task was created before a validation rule existed", func() {
+			BeforeEach(func() {
+				originalTask.Spec.PlacementTags = []string{"retired"}
+				task.Spec.PlacementTags = []string{"retired"}
+				task.Labels = map[string]string{"foo": "bar"}
+			})
+
+			It("still allows changing its metadata", func() {
+				Expect(resp.Allowed).To(BeTrue())
+			})
+		})
+	})
+})
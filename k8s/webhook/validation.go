@@ -0,0 +1,304 @@
+package webhook
+
+import (
+	"fmt"
+	"sort"
+
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/k8s"
+	"code.cloudfoundry.org/eirini-controller/k8s/pdb"
+	"code.cloudfoundry.org/eirini-controller/k8s/stset"
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"code.cloudfoundry.org/eirini-controller/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func validateLRPSpec(spec *eiriniv1.LRPSpec, placementTags map[string]eirinictrl.PlacementTag, identity eirinictrl.WorkloadIdentity) field.ErrorList {
+	specPath := field.NewPath("spec")
+	errs := field.ErrorList{}
+
+	errs = append(errs, validateImage(specPath.Child("image"), spec.Image)...)
+	errs = append(errs, validateEnv(specPath, spec.Env, spec.Environment)...)
+
+	if spec.Instances < 0 {
+		errs = append(errs, field.Invalid(specPath.Child("instances"), spec.Instances, "must not be negative"))
+	}
+
+	errs = append(errs, validateHealthcheck(specPath.Child("health"), spec.Health, spec.Ports)...)
+
+	if spec.ReadinessHealth != nil {
+		errs = append(errs, validateHealthcheck(specPath.Child("readinessHealth"), *spec.ReadinessHealth, spec.Ports)...)
+	}
+
+	errs = append(errs, validateSidecars(specPath.Child("sidecars"), spec.Sidecars)...)
+	errs = append(errs, validateImagePullSecrets(specPath.Child("imagePullSecrets"), spec.ImagePullSecrets)...)
+
+	switch spec.TopologySpreadPolicy {
+	case "", eiriniv1.TopologySpreadPolicySoft, eiriniv1.TopologySpreadPolicyHard:
+	default:
+		errs = append(errs, field.NotSupported(specPath.Child("topologySpreadPolicy"), spec.TopologySpreadPolicy,
+			[]string{eiriniv1.TopologySpreadPolicySoft, eiriniv1.TopologySpreadPolicyHard}))
+	}
+
+	if spec.DisruptionBudget != nil {
+		errs = append(errs, validateDisruptionBudget(specPath.Child("disruptionBudget"), spec.DisruptionBudget)...)
+	}
+
+	errs = append(errs, validatePlacementTags(specPath.Child("placementTags"), spec.PlacementTags, placementTags)...)
+	errs = append(errs, validateServiceAccountName(specPath.Child("serviceAccountName"), spec.ServiceAccountName, spec.AppGUID, identity)...)
+
+	return errs
+}
+
+func validateTaskSpec(spec *eiriniv1.TaskSpec, placementTags map[string]eirinictrl.PlacementTag, identity eirinictrl.WorkloadIdentity) field.ErrorList {
+	specPath := field.NewPath("spec")
+	errs := field.ErrorList{}
+
+	errs = append(errs, validateImage(specPath.Child("image"), spec.Image)...)
+	errs = append(errs, validateEnv(specPath, spec.Env, spec.Environment)...)
+
+	errs = append(errs, validateImagePullSecrets(specPath.Child("imagePullSecrets"), spec.ImagePullSecrets)...)
+	errs = append(errs, validatePlacementTags(specPath.Child("placementTags"), spec.PlacementTags, placementTags)...)
+	errs = append(errs, validateServiceAccountName(specPath.Child("serviceAccountName"), spec.ServiceAccountName, spec.AppGUID, identity)...)
+
+	return errs
+}
+
+func validateImagePullSecrets(path *field.Path, secrets []corev1.LocalObjectReference) field.ErrorList {
+	errs := field.ErrorList{}
+
+	for i, secret := range secrets {
+		if secret.Name == "" {
+			errs = append(errs, field.Required(path.Index(i).Child("name"), ""))
+		}
+	}
+
+	return errs
+}
+
+func validateDisruptionBudget(path *field.Path, budget *eiriniv1.DisruptionBudget) field.ErrorList {
+	if budget.MinAvailable != nil && budget.MaxUnavailable != nil {
+		return field.ErrorList{field.Forbidden(path, "minAvailable and maxUnavailable cannot be both set")}
+	}
+
+	errs := field.ErrorList{}
+
+	if budget.MinAvailable != nil {
+		errs = append(errs, validateIntOrPercent(path.Child("minAvailable"), *budget.MinAvailable)...)
+	}
+
+	if budget.MaxUnavailable != nil {
+		errs = append(errs, validateIntOrPercent(path.Child("maxUnavailable"), *budget.MaxUnavailable)...)
+	}
+
+	return errs
+}
+
+// validateEvictableDisruptionBudget rejects budgets that never allow any
+// instance to be evicted, as they hang node drains. It is only run when the
+// budget is set, not when the LRP is scaled, as the pod disruption budget is
+// then lowered to allow an eviction instead. Disruption budgets are only
+// created for LRPs with more than one instance, so the others are not checked.
+func validateEvictableDisruptionBudget(path *field.Path, budget *eiriniv1.DisruptionBudget, instances int) field.ErrorList {
+	if budget == nil || instances <= 1 || !pdb.BlocksAllEvictions(budget.MinAvailable, budget.MaxUnavailable, instances) {
+		return nil
+	}
+
+	if budget.MinAvailable != nil {
+		return field.ErrorList{field.Invalid(path.Child("minAvailable"), budget.MinAvailable.String(),
+			"must leave at least one instance that can be evicted, or node drains hang")}
+	}
+
+	return field.ErrorList{field.Invalid(path.Child("maxUnavailable"), budget.MaxUnavailable.String(),
+		"must allow at least one instance to be evicted, or node drains hang")}
+}
+
+func validateIntOrPercent(path *field.Path, value intstr.IntOrString) field.ErrorList {
+	if value.Type == intstr.String {
+		errs := field.ErrorList{}
+
+		for _, msg := range validation.IsValidPercent(value.StrVal) {
+			errs = append(errs, field.Invalid(path, value.StrVal, msg))
+		}
+
+		return errs
+	}
+
+	if value.IntVal < 0 {
+		return field.ErrorList{field.Invalid(path, value.IntVal, "must not be negative")}
+	}
+
+	return nil
+}
+
+func validateImage(path *field.Path, image string) field.ErrorList {
+	if image == "" {
+		return field.ErrorList{field.Required(path, "")}
+	}
+
+	if !util.IsValidImageReference(image) {
+		return field.ErrorList{field.Invalid(path, image, "is not a valid image reference")}
+	}
+
+	return nil
+}
+
+func validateEnv(specPath *field.Path, env map[string]string, environment []corev1.EnvVar) field.ErrorList {
+	errs := field.ErrorList{}
+
+	for _, name := range sortedKeys(env) {
+		errs = append(errs, validateEnvVarName(specPath.Child("env").Key(name), name)...)
+	}
+
+	for i, envVar := range environment {
+		errs = append(errs, validateEnvVarName(specPath.Child("environment").Index(i).Child("name"), envVar.Name)...)
+	}
+
+	return errs
+}
+
+func validateEnvVarName(path *field.Path, name string) field.ErrorList {
+	errs := field.ErrorList{}
+
+	for _, msg := range validation.IsEnvVarName(name) {
+		errs = append(errs, field.Invalid(path, name, msg))
+	}
+
+	return errs
+}
+
+func validateHealthcheck(path *field.Path, health eiriniv1.Healthcheck, ports []int32) field.ErrorList {
+	if err := k8s.ValidateHealthcheck(health); err != nil {
+		return field.ErrorList{field.Invalid(path, health.Type, err.Error())}
+	}
+
+	if health.Type != eiriniv1.HealthcheckTypeHTTP && health.Type != eiriniv1.HealthcheckTypePort {
+		return nil
+	}
+
+	for _, port := range ports {
+		if port == health.Port {
+			return nil
+		}
+	}
+
+	return field.ErrorList{field.Invalid(path.Child("port"), health.Port, "must be one of the LRP ports")}
+}
+
+func validateSidecars(path *field.Path, sidecars []eiriniv1.Sidecar) field.ErrorList {
+	errs := field.ErrorList{}
+	names := map[string]bool{stset.ApplicationContainerName: true}
+
+	for i, sidecar := range sidecars {
+		sidecarPath := path.Index(i)
+
+		if names[sidecar.Name] {
+			errs = append(errs, field.Duplicate(sidecarPath.Child("name"), sidecar.Name))
+		}
+
+		names[sidecar.Name] = true
+
+		for _, name := range sortedKeys(sidecar.Env) {
+			errs = append(errs, validateEnvVarName(sidecarPath.Child("env").Key(name), name)...)
+		}
+
+		if sidecar.Health != nil {
+			if err := k8s.ValidateHealthcheck(*sidecar.Health); err != nil {
+				errs = append(errs, field.Invalid(sidecarPath.Child("health"), sidecar.Health.Type, err.Error()))
+			}
+		}
+	}
+
+	return errs
+}
+
+// validatePlacementTags rejects tags that are not configured, as workloads
+// carrying them would silently land outside their isolation segment.
+func validatePlacementTags(path *field.Path, tags []string, placementTags map[string]eirinictrl.PlacementTag) field.ErrorList {
+	errs := field.ErrorList{}
+
+	var knownTags []string
+
+	for i, tag := range tags {
+		if _, ok := placementTags[tag]; ok {
+			continue
+		}
+
+		if knownTags == nil {
+			knownTags = make([]string, 0, len(placementTags))
+			for name := range placementTags {
+				knownTags = append(knownTags, name)
+			}
+
+			sort.Strings(knownTags)
+		}
+
+		errs = append(errs, field.NotSupported(path.Index(i), tag, knownTags))
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	if err := k8s.ApplyPlacementTags(&corev1.PodSpec{}, tags, placementTags); err != nil {
+		return field.ErrorList{field.Invalid(path, tags, err.Error())}
+	}
+
+	return nil
+}
+
+// validateServiceAccountName only lets workloads run as the allowed service
+// accounts or the per-app one of their own app, rather than as any service
+// account of their namespace.
+func validateServiceAccountName(path *field.Path, name, appGUID string, identity eirinictrl.WorkloadIdentity) field.ErrorList {
+	errs := field.ErrorList{}
+
+	if name == "" {
+		return errs
+	}
+
+	for _, msg := range validation.IsDNS1123Subdomain(name) {
+		errs = append(errs, field.Invalid(path, name, msg))
+	}
+
+	if len(errs) == 0 && !k8s.IsServiceAccountAllowed(identity, name, appGUID) {
+		errs = append(errs, field.Forbidden(path, fmt.Sprintf("service account %q is not in workload_identity.allowed_service_accounts, nor the per-app service account of the app", name)))
+	}
+
+	return errs
+}
+
+// newErrors drops the errors the object already had before an update, so
+// that workloads created before a rule was introduced can still be scaled or
+// have their metadata changed. Errors are matched on field, type and value,
+// so changing an invalid field to another invalid value is still rejected
+func newErrors(errs, oldErrs field.ErrorList) field.ErrorList {
+	existing := map[string]bool{}
+	for _, err := range oldErrs {
+		existing[err.Error()] = true
+	}
+
+	result := field.ErrorList{}
+
+	for _, err := range errs {
+		if !existing[err.Error()] {
+			result = append(result, err)
+		}
+	}
+
+	return result
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
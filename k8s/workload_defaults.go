@@ -0,0 +1,126 @@
+package k8s
+
+import (
+	"context"
+
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetWorkloadDefaults returns the workload defaults of the namespace. A
+// namespace without WorkloadDefaults gets empty ones, which keep the
+// controller configuration.
+func GetWorkloadDefaults(ctx context.Context, c client.Client, namespace string) (*eiriniv1.WorkloadDefaultsSpec, error) {
+	defaults := &eiriniv1.WorkloadDefaults{}
+
+	err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: eiriniv1.WorkloadDefaultsName}, defaults)
+	if k8serrors.IsNotFound(err) {
+		return &eiriniv1.WorkloadDefaultsSpec{}, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get workload defaults")
+	}
+
+	return &defaults.Spec, nil
+}
+
+// ApplyLRPDefaults returns a copy of the LRP with the unset resources,
+// placement tags and topology spread policy taken from the defaults. The
+// resource defaults are those of the app container: sidecars keep their own.
+func ApplyLRPDefaults(lrp *eiriniv1.LRP, defaults *eiriniv1.WorkloadDefaultsSpec) *eiriniv1.LRP {
+	lrp = lrp.DeepCopy()
+
+	if len(lrp.Spec.PlacementTags) == 0 {
+		lrp.Spec.PlacementTags = defaults.PlacementTags
+	}
+
+	if lrp.Spec.TopologySpreadPolicy == "" {
+		lrp.Spec.TopologySpreadPolicy = defaults.TopologySpreadPolicy
+	}
+
+	if resources := defaults.Resources; resources != nil {
+		lrp.Spec.MemoryMB = defaultInt64(lrp.Spec.MemoryMB, resources.MemoryMB)
+		lrp.Spec.DiskMB = defaultInt64(lrp.Spec.DiskMB, resources.DiskMB)
+
+		if lrp.Spec.CPUWeight == 0 {
+			lrp.Spec.CPUWeight = resources.CPUWeight
+		}
+	}
+
+	return lrp
+}
+
+// ApplyTaskDefaults returns a copy of the task with the unset resources and
+// placement tags taken from the defaults.
+func ApplyTaskDefaults(task *eiriniv1.Task, defaults *eiriniv1.WorkloadDefaultsSpec) *eiriniv1.Task {
+	task = task.DeepCopy()
+
+	if len(task.Spec.PlacementTags) == 0 {
+		task.Spec.PlacementTags = defaults.PlacementTags
+	}
+
+	if resources := defaults.Resources; resources != nil {
+		task.Spec.MemoryMB = defaultInt64(task.Spec.MemoryMB, resources.MemoryMB)
+		task.Spec.DiskMB = defaultInt64(task.Spec.DiskMB, resources.DiskMB)
+	}
+
+	return task
+}
+
+// RegistrySecretName returns the registry secret of the defaults, falling
+// back to the configured one.
+func RegistrySecretName(configured string, defaults *eiriniv1.WorkloadDefaultsSpec) string {
+	if defaults.RegistrySecretName != "" {
+		return defaults.RegistrySecretName
+	}
+
+	return configured
+}
+
+// AllowAutomountServiceAccountToken returns the automount setting of the
+// defaults, falling back to the configured one.
+func AllowAutomountServiceAccountToken(configured bool, defaults *eiriniv1.WorkloadDefaultsSpec) bool {
+	if defaults.AllowAutomountServiceAccountToken != nil {
+		return *defaults.AllowAutomountServiceAccountToken
+	}
+
+	return configured
+}
+
+// ApplyPodMetadataDefaults adds the default labels and annotations to the
+// pod template, keeping the ones already set. The template metadata may be
+// shared with the owning workload, so it is copied rather than updated.
+func ApplyPodMetadataDefaults(template *corev1.PodTemplateSpec, defaults *eiriniv1.WorkloadDefaultsSpec) {
+	template.Labels = mergeMissing(template.Labels, defaults.Labels)
+	template.Annotations = mergeMissing(template.Annotations, defaults.Annotations)
+}
+
+func mergeMissing(values, defaults map[string]string) map[string]string {
+	if len(defaults) == 0 {
+		return values
+	}
+
+	merged := map[string]string{}
+
+	for k, v := range defaults {
+		merged[k] = v
+	}
+
+	for k, v := range values {
+		merged[k] = v
+	}
+
+	return merged
+}
+
+func defaultInt64(value, defaultValue int64) int64 {
+	if value == 0 {
+		return defaultValue
+	}
+
+	return value
+}
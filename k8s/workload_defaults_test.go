@@ -0,0 +1,92 @@
+package k8s_test
+
+import (
+	"context"
+	"errors"
+
+	. "code.cloudfoundry.org/eirini-controller/k8s"
+	"code.cloudfoundry.org/eirini-controller/k8s/k8sfakes"
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("WorkloadDefaults", func() {
+	Describe("GetWorkloadDefaults", func() {
+		var fakeClient *k8sfakes.FakeClient
+
+		BeforeEach(func() {
+			fakeClient = new(k8sfakes.FakeClient)
+		})
+
+		It("gets the default WorkloadDefaults of the namespace", func() {
+			fakeClient.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+				Expect(key).To(Equal(client.ObjectKey{Namespace: "the-namespace", Name: eiriniv1.WorkloadDefaultsName}))
+				obj.(*eiriniv1.WorkloadDefaults).Spec.RegistrySecretName = "the-secret"
+
+				return nil
+			}
+
+			defaults, err := GetWorkloadDefaults(context.Background(), fakeClient, "the-namespace")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(defaults.RegistrySecretName).To(Equal("the-secret"))
+		})
+
+		It("returns empty defaults when the namespace has none", func() {
+			fakeClient.GetReturns(k8serrors.NewNotFound(schema.GroupResource{}, eiriniv1.WorkloadDefaultsName))
+
+			defaults, err := GetWorkloadDefaults(context.Background(), fakeClient, "the-namespace")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(defaults).To(Equal(&eiriniv1.WorkloadDefaultsSpec{}))
+		})
+
+		It("returns an error when getting them fails", func() {
+			fakeClient.GetReturns(errors.New("boom"))
+
+			_, err := GetWorkloadDefaults(context.Background(), fakeClient, "the-namespace")
+			Expect(err).To(MatchError(ContainSubstring("boom")))
+		})
+	})
+
+	Describe("ApplyLRPDefaults", func() {
+		It("only fills in what the LRP does not set", func() {
+			lrp := &eiriniv1.LRP{Spec: eiriniv1.LRPSpec{
+				MemoryMB:      512,
+				PlacementTags: []string{"mine"},
+				Sidecars:      []eiriniv1.Sidecar{{Name: "sidecar"}},
+			}}
+
+			defaulted := ApplyLRPDefaults(lrp, &eiriniv1.WorkloadDefaultsSpec{
+				PlacementTags:        []string{"default"},
+				TopologySpreadPolicy: eiriniv1.TopologySpreadPolicyHard,
+				Resources:            &eiriniv1.ResourceDefaults{MemoryMB: 128, DiskMB: 256, CPUWeight: 5},
+			})
+
+			Expect(defaulted.Spec.MemoryMB).To(BeEquivalentTo(512))
+			Expect(defaulted.Spec.DiskMB).To(BeEquivalentTo(256))
+			Expect(defaulted.Spec.CPUWeight).To(BeEquivalentTo(5))
+			Expect(defaulted.Spec.PlacementTags).To(ConsistOf("mine"))
+			Expect(defaulted.Spec.TopologySpreadPolicy).To(Equal(eiriniv1.TopologySpreadPolicyHard))
+			Expect(defaulted.Spec.Sidecars[0].MemoryMB).To(BeZero())
+		})
+	})
+
+	Describe("ApplyPodMetadataDefaults", func() {
+		It("adds the missing labels without changing the original map", func() {
+			labels := map[string]string{"app": "dora"}
+			template := &v1.PodTemplateSpec{}
+			template.Labels = labels
+
+			ApplyPodMetadataDefaults(template, &eiriniv1.WorkloadDefaultsSpec{
+				Labels: map[string]string{"app": "other", "team": "payments"},
+			})
+
+			Expect(template.Labels).To(Equal(map[string]string{"app": "dora", "team": "payments"}))
+			Expect(labels).To(Equal(map[string]string{"app": "dora"}))
+		})
+	})
+})
@@ -16,6 +16,8 @@ const (
 	EnvCFInstancePorts      = "CF_INSTANCE_PORTS"
 
 	EiriniCertsDir = "/etc/eirini/certs"
+
+	DefaultLeaderElectionID = "eirini-controller-leader"
 )
 
 var ErrNotFound = errors.New("not found")
@@ -29,18 +31,199 @@ type ControllerConfig struct {
 	RegistrySecretName                      string `yaml:"registry_secret_name"`
 	UnsafeAllowAutomountServiceAccountToken bool   `yaml:"unsafe_allow_automount_service_account_token"`
 	DefaultMinAvailableInstances            string `yaml:"default_min_available_instances"`
+	TopologySpreadPolicy                    string `yaml:"topology_spread_policy"`
+
+	PlacementTags map[string]PlacementTag `yaml:"placement_tags"`
+
+	SecurityProfile           SecurityProfile            `yaml:"security_profile"`
+	NamespaceSecurityProfiles map[string]SecurityProfile `yaml:"namespace_security_profiles"`
+
+	WorkloadIdentity WorkloadIdentity `yaml:"workload_identity"`
 
 	WorkloadsNamespace string
 
+	// WorkloadsNamespaces restricts the controller to the LRPs and tasks of
+	// the listed namespaces, and WorkloadsNamespaceSelector to the ones of
+	// the namespaces whose labels match it. The controller serves all the
+	// namespaces when neither is set.
+	WorkloadsNamespaces        []string `yaml:"workloads_namespaces"`
+	WorkloadsNamespaceSelector string   `yaml:"workloads_namespace_selector"`
+
+	Sharding Sharding `yaml:"sharding"`
+
+	Reconcilers Reconcilers `yaml:"reconcilers"`
+
 	PrometheusPort int `yaml:"prometheus_port"`
+	// PrometheusInstancesBySpace exports the LRP instance gauges per space
+	// rather than per process, bounding their cardinality.
+	PrometheusInstancesBySpace bool `yaml:"prometheus_instances_by_space"`
+
+	// HealthProbePort is the port of the /healthz and /readyz endpoints.
+	// When set to 0, the probe endpoints are disabled.
+	HealthProbePort int `yaml:"health_probe_port"`
+
 	TaskTTLSeconds int `yaml:"task_ttl_seconds"`
 
+	Tracing Tracing `yaml:"tracing"`
+
+	Logging Logging `yaml:"logging"`
+
 	LeaderElectionID        string
 	LeaderElectionNamespace string
 
 	WebhookPort int32 `yaml:"webhook_port"`
+
+	// Reloader notifies the config reloads. It is set by the controller
+	// rather than read from the config file, and may be nil.
+	Reloader Reloader `yaml:"-"`
+}
+
+// Reloader calls its callbacks with the new config when the config file
+// changes. Only TaskTTLSeconds, DefaultMinAvailableInstances,
+// TopologySpreadPolicy and the logging levels are applied by the
+// controller, the other settings need a restart.
+type Reloader interface {
+	OnReload(callback func(ControllerConfig))
+}
+
+// PlacementTag maps a placement tag, such as the name of a CF isolation
+// segment, to the nodes that run the workloads carrying it.
+type PlacementTag struct {
+	NodeSelector map[string]string         `yaml:"node_selector"`
+	Tolerations  []Toleration              `yaml:"tolerations"`
+	NodeAffinity []NodeSelectorRequirement `yaml:"node_affinity"`
+}
+
+type Toleration struct {
+	Key      string `yaml:"key"`
+	Operator string `yaml:"operator"`
+	Value    string `yaml:"value"`
+	Effect   string `yaml:"effect"`
+}
+
+type NodeSelectorRequirement struct {
+	Key      string   `yaml:"key"`
+	Operator string   `yaml:"operator"`
+	Values   []string `yaml:"values"`
+}
+
+// SecurityProfile configures the security context of LRP and task pods.
+// Profiles are written as in the Kubernetes annotations: "runtime/default"
+// or "localhost/<name>". Unset fields keep the image or runtime defaults.
+type SecurityProfile struct {
+	RunAsUser              *int64 `yaml:"run_as_user"`
+	RunAsGroup             *int64 `yaml:"run_as_group"`
+	FSGroup                *int64 `yaml:"fs_group"`
+	ReadOnlyRootFilesystem *bool  `yaml:"read_only_root_filesystem"`
+	AppArmorProfile        string `yaml:"apparmor_profile"`
+	SeccompProfile         string `yaml:"seccomp_profile"`
+}
+
+// WorkloadIdentity configures the service accounts of apps that need their
+// own identity. Annotations are Go templates over the app and namespace,
+// e.g. "{{ .AppGUID }}@project.iam.gserviceaccount.com".
+// AllowedServiceAccounts are the service accounts LRPs and tasks may ask for
+// by name, besides the per-app one of their own app.
+type WorkloadIdentity struct {
+	PerAppServiceAccounts     bool              `yaml:"per_app_service_accounts"`
+	AllowedServiceAccounts    []string          `yaml:"allowed_service_accounts"`
+	ServiceAccountAnnotations map[string]string `yaml:"service_account_annotations"`
+	TokenAudience             string            `yaml:"token_audience"`
+	TokenExpirationSeconds    int64             `yaml:"token_expiration_seconds"`
+}
+
+// Sharding spreads the workloads across the controller replicas. Namespaces
+// are split into shards by hash, or by their shard label, and each replica
+// reconciles the namespaces of the shards whose lease it holds.
+type Sharding struct {
+	Enabled bool `yaml:"enabled"`
+	Shards  int  `yaml:"shards"`
+	// Replicas is the number of controller replicas sharing the shards.
+	Replicas int `yaml:"replicas"`
+	// MaxShardsPerReplica caps the shards held by a replica, so that new
+	// replicas get some. Zero caps them to a fair share, the shards divided
+	// by the replicas, rounded up, beyond which a replica only takes over
+	// the shards no other replica has taken once their holder went away.
+	MaxShardsPerReplica  int    `yaml:"max_shards_per_replica"`
+	LeaseNamespace       string `yaml:"lease_namespace"`
+	LeaseDurationSeconds int    `yaml:"lease_duration_seconds"`
+}
+
+// Reconcilers tunes the work queues of the LRP, Task and PodCrash
+// controllers independently.
+type Reconcilers struct {
+	LRP      ReconcilerConfig `yaml:"lrp"`
+	Task     ReconcilerConfig `yaml:"task"`
+	PodCrash ReconcilerConfig `yaml:"pod_crash"`
+}
+
+// ReconcilerConfig tunes the work queue of a controller. Zero values keep
+// the controller-runtime defaults: a single worker, and failed reconciles
+// retried after 5ms doubling up to 1000s.
+type ReconcilerConfig struct {
+	MaxConcurrentReconciles          int `yaml:"max_concurrent_reconciles"`
+	RateLimiterBaseDelayMilliseconds int `yaml:"rate_limiter_base_delay_milliseconds"`
+	RateLimiterMaxDelaySeconds       int `yaml:"rate_limiter_max_delay_seconds"`
+	// ConflictRequeueDelayMilliseconds requeues reconciles failing on a
+	// conflicting update after a fixed delay instead of backing off.
+	ConflictRequeueDelayMilliseconds int `yaml:"conflict_requeue_delay_milliseconds"`
+	// NamespaceReconcilesPerSecond caps the reconciles of each namespace,
+	// with bursts of NamespaceBurst, so that a busy namespace cannot starve
+	// the others. Zero disables the cap.
+	NamespaceReconcilesPerSecond float64 `yaml:"namespace_reconciles_per_second"`
+	NamespaceBurst               int     `yaml:"namespace_burst"`
+}
+
+// Tracing exports OpenTelemetry spans of the reconciles, webhooks and
+// Kubernetes API calls to an OTLP/HTTP collector.
+type Tracing struct {
+	Enabled bool `yaml:"enabled"`
+	// Endpoint is the host:port of the collector. When empty, the standard
+	// OTEL_EXPORTER_OTLP_ENDPOINT environment variable or localhost:4318 is
+	// used.
+	Endpoint string `yaml:"endpoint"`
+	Insecure bool   `yaml:"insecure"`
+	// SampleRatio is the ratio of new traces sampled, all of them when
+	// unset. Webhook traces continued from the annotations of an LRP or task
+	// keep their own sampling decision.
+	SampleRatio *float64 `yaml:"sample_ratio"`
+}
+
+// Logging configures the format and levels of the controller logs.
+type Logging struct {
+	// Format is either "pretty" (the default), with human readable
+	// timestamps and levels, or "json", lager's compact format.
+	Format string `yaml:"format"`
+	// Level is the minimum level logged: debug, info (the default), error
+	// or fatal.
+	Level string `yaml:"level"`
+	// Components overrides the level of the named sessions and their
+	// children, e.g. "lrp-reconciler" or "controller-runtime".
+	Components map[string]string `yaml:"components"`
+	// Port serves the /log-level endpoint changing the levels on localhost
+	// only, to be reached through kubectl port-forward. When set to 0, the
+	// levels can only be read, on the Prometheus port.
+	Port int `yaml:"port"`
 }
 
 type KubeConfig struct {
 	ConfigPath string `yaml:"kube_config_path"`
 }
+
+// Namespaces returns the namespaces the controller is restricted to, if any.
+func (c ControllerConfig) Namespaces() []string {
+	namespaces := []string{}
+	seen := map[string]bool{}
+
+	for _, namespace := range append([]string{c.WorkloadsNamespace}, c.WorkloadsNamespaces...) {
+		if namespace == "" || seen[namespace] {
+			continue
+		}
+
+		seen[namespace] = true
+
+		namespaces = append(namespaces, namespace)
+	}
+
+	return namespaces
+}
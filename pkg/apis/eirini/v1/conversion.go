@@ -0,0 +1,8 @@
+package v1
+
+// v1 is the storage version of the eirini API and the hub every other
+// version converts to and from.
+
+func (*LRP) Hub() {}
+
+func (*Task) Hub() {}
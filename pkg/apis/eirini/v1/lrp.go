@@ -4,6 +4,7 @@ package v1
 import (
 	corev1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // LRP describes an Long Running Process
@@ -11,6 +12,7 @@ import (
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:resource:shortName=lrp
+// +kubebuilder:storageversion
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:JSONPath=.spec.instances,type=integer,name=Replicas
 // +kubebuilder:printcolumn:JSONPath=.status.replicas,type=integer,name=Ready
@@ -35,15 +37,23 @@ type LRPSpec struct {
 	SpaceName   string `json:"spaceName"`
 	SpaceGUID   string `json:"spaceGUID"`
 	// +kubebuilder:validation:Required
-	Image           string           `json:"image"`
-	Command         []string         `json:"command,omitempty"`
-	Sidecars        []Sidecar        `json:"sidecars,omitempty"`
+	Image    string    `json:"image"`
+	Command  []string  `json:"command,omitempty"`
+	Sidecars []Sidecar `json:"sidecars,omitempty"`
+	// deprecated: PrivateRegistry is deprecated. Use ImagePullSecrets instead
 	PrivateRegistry *PrivateRegistry `json:"privateRegistry,omitempty"`
+	// ImagePullSecrets name the secrets holding the credentials of private
+	// registries. v2 replaces PrivateRegistry with them, and v1 is the
+	// storage version, so LRPs written through v2 would lose them otherwise
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
 	// deprecated: Env is deprecated. Use Environment instead
 	Env         map[string]string `json:"env,omitempty"`
 	Environment []corev1.EnvVar   `json:"environment,omitempty"`
 	Health      Healthcheck       `json:"health"`
-	Ports       []int32           `json:"ports,omitempty"`
+	// ReadinessHealth is the check deciding whether an instance should
+	// receive traffic. When not set, Health is used instead
+	ReadinessHealth *Healthcheck `json:"readinessHealth,omitempty"`
+	Ports           []int32      `json:"ports,omitempty"`
 	// +kubebuilder:default:=1
 	Instances int   `json:"instances"`
 	MemoryMB  int64 `json:"memoryMB"`
@@ -54,10 +64,57 @@ type LRPSpec struct {
 	CPUWeight              uint8             `json:"cpuWeight"`
 	VolumeMounts           []VolumeMount     `json:"volumeMounts,omitempty"`
 	UserDefinedAnnotations map[string]string `json:"userDefinedAnnotations,omitempty"`
+	// DisruptionBudget overrides the disruption budget the controller
+	// configures by default for LRPs with more than one instance
+	DisruptionBudget *DisruptionBudget `json:"disruptionBudget,omitempty"`
+	// TopologySpreadPolicy overrides how strictly the controller spreads the
+	// instances across zones and nodes. When not set, the controller default
+	// is used. The hard policy requires the nodes to have the
+	// topology.kubernetes.io/zone label, or the instances stay pending
+	// +kubebuilder:validation:Enum=soft;hard
+	TopologySpreadPolicy string `json:"topologySpreadPolicy,omitempty"`
+	// PlacementTags restrict the nodes the instances run on, e.g. to the
+	// ones of a CF isolation segment. Each tag must be configured in the
+	// controller
+	PlacementTags []string `json:"placementTags,omitempty"`
+	// ServiceAccountName is the service account the instances run as,
+	// giving the app its own workload identity. When empty, the controller
+	// runs them as its per-app or shared application service account. It
+	// must be one of the service accounts allowed by the controller, or the
+	// per-app one of the app
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
 }
 
 type LRPStatus struct {
-	Replicas int32 `json:"replicas"`
+	Replicas  int32               `json:"replicas"`
+	Instances []LRPInstanceStatus `json:"instances,omitempty"`
+}
+
+const (
+	InstanceStateStarting = "starting"
+	InstanceStateRunning  = "running"
+	InstanceStateUnready  = "unready"
+	InstanceStateCrashed  = "crashed"
+)
+
+const (
+	// TopologySpreadPolicySoft prefers spreading the instances across zones
+	// and nodes, but still schedules them when that is not possible
+	TopologySpreadPolicySoft = "soft"
+	// TopologySpreadPolicyHard does not schedule instances that would
+	// unbalance the spread across zones and nodes
+	TopologySpreadPolicyHard = "hard"
+)
+
+type LRPInstanceStatus struct {
+	Index   int    `json:"index"`
+	PodName string `json:"podName"`
+	// +kubebuilder:validation:Enum=starting;running;unready;crashed
+	State string `json:"state"`
+	// Node is the name of the node the instance is scheduled on
+	Node string `json:"node,omitempty"`
+	// Zone is the topology.kubernetes.io/zone label of that node
+	Zone string `json:"zone,omitempty"`
 }
 
 type Route struct {
@@ -72,6 +129,7 @@ type Sidecar struct {
 	Command  []string          `json:"command"`
 	MemoryMB int64             `json:"memoryMB"`
 	Env      map[string]string `json:"env,omitempty"`
+	Health   *Healthcheck      `json:"health,omitempty"`
 }
 
 type PrivateRegistry struct {
@@ -79,17 +137,46 @@ type PrivateRegistry struct {
 	Password string `json:"password"`
 }
 
+// DisruptionBudget sets either the minimum number of available instances or
+// the maximum number of unavailable ones during voluntary disruptions, as a
+// number or a percentage of the instances
+type DisruptionBudget struct {
+	MinAvailable   *intstr.IntOrString `json:"minAvailable,omitempty"`
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
 type VolumeMount struct {
 	MountPath string `json:"mountPath"`
 	ClaimName string `json:"claimName"`
 }
 
+const (
+	HealthcheckTypeHTTP    = "http"
+	HealthcheckTypePort    = "port"
+	HealthcheckTypeProcess = "process"
+	HealthcheckTypeExec    = "exec"
+)
+
 type Healthcheck struct {
+	// Type is one of http, port, process or exec. An empty type is the
+	// same as process: no probe is run against the container
 	Type     string `json:"type"`
 	Port     int32  `json:"port"`
 	Endpoint string `json:"endpoint"`
+	// Command is the command run inside the container by exec health checks
+	Command []string `json:"command,omitempty"`
+	// deprecated: TimeoutMs is deprecated. Use StartupTimeoutMs instead
 	// +kubebuilder:validation:Format:=uint8
 	TimeoutMs uint `json:"timeoutMs"`
+	// StartupTimeoutMs is the time the app has to pass its first health
+	// check before it is considered crashed
+	StartupTimeoutMs uint `json:"startupTimeoutMs,omitempty"`
+	// IntervalMs is the time between two consecutive health checks once
+	// the app has started
+	IntervalMs uint `json:"intervalMs,omitempty"`
+	// InvocationTimeoutMs is the time a single health check has to
+	// respond before it is considered failed
+	InvocationTimeoutMs uint `json:"invocationTimeoutMs,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -32,6 +32,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&LRPList{},
 		&Task{},
 		&TaskList{},
+		&WorkloadDefaults{},
+		&WorkloadDefaultsList{},
 	)
 
 	meta_v1.AddToGroupVersion(scheme, SchemeGroupVersion)
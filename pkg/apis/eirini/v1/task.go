@@ -8,6 +8,7 @@ import (
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:resource:shortName=task
+// +kubebuilder:storageversion
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:JSONPath=.status.execution_status,type=string,name=State
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
@@ -42,6 +43,15 @@ type TaskSpec struct {
 	MemoryMB  int64    `json:"memoryMB"`
 	DiskMB    int64    `json:"diskMB"`
 	CPUMillis int64    `json:"cpuMillis"`
+	// PlacementTags restrict the nodes the task runs on, e.g. to the ones of
+	// a CF isolation segment. Each tag must be configured in the controller
+	PlacementTags []string `json:"placementTags,omitempty"`
+	// ServiceAccountName is the service account the task runs as, giving it
+	// its own workload identity. When empty, the controller runs it as its
+	// per-app or shared application service account. It must be one of the
+	// service accounts allowed by the controller, or the per-app one of the
+	// app
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -0,0 +1,95 @@
+package v1
+
+import (
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// WorkloadDefaultsName is the name of the WorkloadDefaults honoured in each
+// namespace. Objects with other names are ignored.
+const WorkloadDefaultsName = "default"
+
+// WorkloadDefaults overrides the controller configuration for the LRPs and
+// tasks of its namespace. Disruption budgets and task TTLs are updated in
+// place. Pod settings, such as the service account, registry secret,
+// placement, security profile and resources, only apply to the workloads
+// desired afterwards: existing StatefulSets are left alone rather than
+// restarting every app of the namespace, and pick them up with the next
+// version of their LRP.
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName=wd
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type WorkloadDefaults struct {
+	meta_v1.TypeMeta   `json:",inline"`
+	meta_v1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec WorkloadDefaultsSpec `json:"spec"`
+}
+
+type WorkloadDefaultsSpec struct {
+	// ApplicationServiceAccount replaces the service account of the apps
+	// that do not have their own. It must be one of the service accounts
+	// allowed by the controller
+	ApplicationServiceAccount string `json:"applicationServiceAccount,omitempty"`
+	// RegistrySecretName replaces the secret used to pull app images
+	RegistrySecretName                string `json:"registrySecretName,omitempty"`
+	AllowAutomountServiceAccountToken *bool  `json:"allowAutomountServiceAccountToken,omitempty"`
+	// DefaultMinAvailableInstances is the number or percentage of LRP
+	// instances kept available during disruptions, unless the LRP sets its
+	// own disruption budget
+	// +kubebuilder:validation:XIntOrString
+	DefaultMinAvailableInstances *intstr.IntOrString `json:"defaultMinAvailableInstances,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	TaskTTLSeconds *int32 `json:"taskTTLSeconds,omitempty"`
+	// +kubebuilder:validation:Enum=soft;hard
+	TopologySpreadPolicy string `json:"topologySpreadPolicy,omitempty"`
+	// PlacementTags apply to the LRPs and tasks without placement tags.
+	// Workloads fail to be scheduled while a tag is not configured in the
+	// controller
+	PlacementTags   []string          `json:"placementTags,omitempty"`
+	SecurityProfile *SecurityProfile  `json:"securityProfile,omitempty"`
+	Resources       *ResourceDefaults `json:"resources,omitempty"`
+	// Labels and Annotations are added to the pods. They never replace the
+	// ones set by the controller or the LRP
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// SecurityProfile overrides the security profile of the controller
+// configuration field by field.
+type SecurityProfile struct {
+	// +kubebuilder:validation:Minimum=1
+	RunAsUser *int64 `json:"runAsUser,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	RunAsGroup *int64 `json:"runAsGroup,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	FSGroup                *int64 `json:"fsGroup,omitempty"`
+	ReadOnlyRootFilesystem *bool  `json:"readOnlyRootFilesystem,omitempty"`
+	// +kubebuilder:validation:Pattern=`^(runtime/default|localhost/.+)$`
+	AppArmorProfile string `json:"appArmorProfile,omitempty"`
+	// +kubebuilder:validation:Pattern=`^(runtime/default|localhost/.+)$`
+	SeccompProfile string `json:"seccompProfile,omitempty"`
+}
+
+// ResourceDefaults apply to the app containers of the LRPs and tasks that
+// do not request the resource. Sidecars keep their own.
+type ResourceDefaults struct {
+	// +kubebuilder:validation:Minimum=0
+	MemoryMB int64 `json:"memoryMB,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	DiskMB int64 `json:"diskMB,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=255
+	CPUWeight uint8 `json:"cpuWeight,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type WorkloadDefaultsList struct {
+	meta_v1.TypeMeta `json:",inline"`
+	meta_v1.ListMeta `json:"metadata"`
+
+	Items []WorkloadDefaults `json:"items"`
+}
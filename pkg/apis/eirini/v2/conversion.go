@@ -0,0 +1,451 @@
+package v2
+
+import (
+	"encoding/json"
+	"sort"
+
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// V1FieldsAnnotation records how the deprecated v1 fields of objects
+// created through v1 map to their v2 replacements, so that they survive
+// being read and written back through v2 unchanged. It is removed again when
+// converting back to v1.
+//
+// The annotation holds layout hints only, never values: clients can write
+// it, so a hint is applied only when the v2 spec already carries the same
+// data. The deprecated PrivateRegistry credentials have no v2 replacement and
+// are not converted at all; LRPs using them can only be updated through v1.
+const V1FieldsAnnotation = "eirini.cloudfoundry.org/v1-fields"
+
+const mebibyte = 1024 * 1024
+
+// v1Fields holds the hints to restore the deprecated v1 fields dropped by v2.
+type v1Fields struct {
+	// EnvNames are the names of the env map entries, which lead the v2
+	// environment
+	EnvNames []string                   `json:"envNames,omitempty"`
+	Timeouts map[string]v1HealthTimeout `json:"timeouts,omitempty"`
+}
+
+// v1HealthTimeout records a health check using the deprecated TimeoutMs,
+// keyed by the path of the health check in the spec.
+type v1HealthTimeout struct {
+	TimeoutMs        uint `json:"timeoutMs"`
+	StartupTimeoutMs uint `json:"startupTimeoutMs,omitempty"`
+}
+
+func (t v1HealthTimeout) effectiveStartupTimeoutMs() int64 {
+	if t.StartupTimeoutMs != 0 {
+		return int64(t.StartupTimeoutMs)
+	}
+
+	return int64(t.TimeoutMs)
+}
+
+func (f v1Fields) isEmpty() bool {
+	return len(f.EnvNames) == 0 && len(f.Timeouts) == 0
+}
+
+func (f *v1Fields) recordTimeout(path string, health eiriniv1.Healthcheck) {
+	if health.TimeoutMs == 0 {
+		return
+	}
+
+	if f.Timeouts == nil {
+		f.Timeouts = map[string]v1HealthTimeout{}
+	}
+
+	f.Timeouts[path] = v1HealthTimeout{TimeoutMs: health.TimeoutMs, StartupTimeoutMs: health.StartupTimeoutMs}
+}
+
+var (
+	_ conversion.Convertible = &LRP{}
+	_ conversion.Convertible = &Task{}
+)
+
+// ConvertTo converts this LRP to the v1 hub version.
+func (src *LRP) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*eiriniv1.LRP)
+	if !ok {
+		return errors.Errorf("unexpected hub type %T", dstRaw)
+	}
+
+	fields := v1FieldsOf(src.Annotations)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Annotations = withoutV1FieldsAnnotation(dst.Annotations)
+
+	spec := src.Spec.DeepCopy()
+	dst.Spec = eiriniv1.LRPSpec{
+		GUID:                   spec.GUID,
+		Version:                spec.Version,
+		ProcessType:            spec.ProcessType,
+		AppName:                spec.AppName,
+		AppGUID:                spec.AppGUID,
+		OrgName:                spec.OrgName,
+		OrgGUID:                spec.OrgGUID,
+		SpaceName:              spec.SpaceName,
+		SpaceGUID:              spec.SpaceGUID,
+		Image:                  spec.Image,
+		Command:                spec.Command,
+		ImagePullSecrets:       spec.ImagePullSecrets,
+		Health:                 healthToV1(spec.Health, fields.Timeouts["health"]),
+		Ports:                  spec.Ports,
+		Instances:              spec.Instances,
+		MemoryMB:               toMebibytes(spec.Memory),
+		DiskMB:                 toMebibytes(spec.Disk),
+		CPUWeight:              spec.CPUWeight,
+		UserDefinedAnnotations: spec.UserDefinedAnnotations,
+		DisruptionBudget:       (*eiriniv1.DisruptionBudget)(spec.DisruptionBudget),
+		TopologySpreadPolicy:   spec.TopologySpreadPolicy,
+		PlacementTags:          spec.PlacementTags,
+		ServiceAccountName:     spec.ServiceAccountName,
+	}
+	dst.Spec.Env, dst.Spec.Environment = envToV1(spec.Environment, fields.EnvNames)
+
+	if spec.ReadinessHealth != nil {
+		readinessHealth := healthToV1(*spec.ReadinessHealth, fields.Timeouts["readinessHealth"])
+		dst.Spec.ReadinessHealth = &readinessHealth
+	}
+
+	for _, s := range spec.Sidecars {
+		sidecar := eiriniv1.Sidecar{
+			Name:     s.Name,
+			Command:  s.Command,
+			MemoryMB: toMebibytes(s.Memory),
+			Env:      s.Env,
+		}
+
+		if s.Health != nil {
+			health := healthToV1(*s.Health, fields.Timeouts[sidecarHealthPath(s.Name)])
+			sidecar.Health = &health
+		}
+
+		dst.Spec.Sidecars = append(dst.Spec.Sidecars, sidecar)
+	}
+
+	for _, vm := range spec.VolumeMounts {
+		dst.Spec.VolumeMounts = append(dst.Spec.VolumeMounts, eiriniv1.VolumeMount{MountPath: vm.MountPath, ClaimName: vm.ClaimName})
+	}
+
+	dst.Status = eiriniv1.LRPStatus{Replicas: src.Status.Replicas}
+	for _, instance := range src.Status.Instances {
+		dst.Status.Instances = append(dst.Status.Instances, eiriniv1.LRPInstanceStatus(instance))
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the v1 hub version to this LRP.
+func (dst *LRP) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*eiriniv1.LRP)
+	if !ok {
+		return errors.Errorf("unexpected hub type %T", srcRaw)
+	}
+
+	spec := src.Spec.DeepCopy()
+	fields := v1Fields{EnvNames: sortedNames(spec.Env)}
+
+	dst.Spec = LRPSpec{
+		GUID:                   spec.GUID,
+		Version:                spec.Version,
+		ProcessType:            spec.ProcessType,
+		AppName:                spec.AppName,
+		AppGUID:                spec.AppGUID,
+		OrgName:                spec.OrgName,
+		OrgGUID:                spec.OrgGUID,
+		SpaceName:              spec.SpaceName,
+		SpaceGUID:              spec.SpaceGUID,
+		Image:                  spec.Image,
+		Command:                spec.Command,
+		ImagePullSecrets:       spec.ImagePullSecrets,
+		Environment:            envFromV1(spec.Env, spec.Environment),
+		Health:                 healthFromV1(spec.Health),
+		Ports:                  spec.Ports,
+		Instances:              spec.Instances,
+		Memory:                 fromMebibytes(spec.MemoryMB),
+		Disk:                   fromMebibytes(spec.DiskMB),
+		CPUWeight:              spec.CPUWeight,
+		UserDefinedAnnotations: spec.UserDefinedAnnotations,
+		DisruptionBudget:       (*DisruptionBudget)(spec.DisruptionBudget),
+		TopologySpreadPolicy:   spec.TopologySpreadPolicy,
+		PlacementTags:          spec.PlacementTags,
+		ServiceAccountName:     spec.ServiceAccountName,
+	}
+	fields.recordTimeout("health", spec.Health)
+
+	if spec.ReadinessHealth != nil {
+		readinessHealth := healthFromV1(*spec.ReadinessHealth)
+		dst.Spec.ReadinessHealth = &readinessHealth
+		fields.recordTimeout("readinessHealth", *spec.ReadinessHealth)
+	}
+
+	for _, s := range spec.Sidecars {
+		sidecar := Sidecar{
+			Name:    s.Name,
+			Command: s.Command,
+			Memory:  fromMebibytes(s.MemoryMB),
+			Env:     s.Env,
+		}
+
+		if s.Health != nil {
+			health := healthFromV1(*s.Health)
+			sidecar.Health = &health
+			fields.recordTimeout(sidecarHealthPath(s.Name), *s.Health)
+		}
+
+		dst.Spec.Sidecars = append(dst.Spec.Sidecars, sidecar)
+	}
+
+	for _, vm := range spec.VolumeMounts {
+		dst.Spec.VolumeMounts = append(dst.Spec.VolumeMounts, VolumeMount{MountPath: vm.MountPath, ClaimName: vm.ClaimName})
+	}
+
+	dst.Status = LRPStatus{Replicas: src.Status.Replicas}
+	for _, instance := range src.Status.Instances {
+		dst.Status.Instances = append(dst.Status.Instances, LRPInstanceStatus(instance))
+	}
+
+	var err error
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Annotations, err = withV1FieldsAnnotation(dst.Annotations, fields)
+
+	return err
+}
+
+// ConvertTo converts this Task to the v1 hub version.
+func (src *Task) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*eiriniv1.Task)
+	if !ok {
+		return errors.Errorf("unexpected hub type %T", dstRaw)
+	}
+
+	fields := v1FieldsOf(src.Annotations)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Annotations = withoutV1FieldsAnnotation(dst.Annotations)
+
+	spec := src.Spec.DeepCopy()
+	dst.Spec = eiriniv1.TaskSpec{
+		GUID:               spec.GUID,
+		Name:               spec.Name,
+		Image:              spec.Image,
+		ImagePullSecrets:   spec.ImagePullSecrets,
+		Command:            spec.Command,
+		AppName:            spec.AppName,
+		AppGUID:            spec.AppGUID,
+		OrgName:            spec.OrgName,
+		OrgGUID:            spec.OrgGUID,
+		SpaceName:          spec.SpaceName,
+		SpaceGUID:          spec.SpaceGUID,
+		MemoryMB:           spec.Memory.ScaledValue(resource.Mega),
+		DiskMB:             spec.Disk.ScaledValue(resource.Mega),
+		CPUMillis:          spec.CPUMillis,
+		PlacementTags:      spec.PlacementTags,
+		ServiceAccountName: spec.ServiceAccountName,
+	}
+	dst.Spec.Env, dst.Spec.Environment = envToV1(spec.Environment, fields.EnvNames)
+	dst.Status = eiriniv1.TaskStatus(*src.Status.DeepCopy())
+
+	return nil
+}
+
+// ConvertFrom converts from the v1 hub version to this Task.
+func (dst *Task) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*eiriniv1.Task)
+	if !ok {
+		return errors.Errorf("unexpected hub type %T", srcRaw)
+	}
+
+	spec := src.Spec.DeepCopy()
+	dst.Spec = TaskSpec{
+		GUID:               spec.GUID,
+		Name:               spec.Name,
+		Image:              spec.Image,
+		ImagePullSecrets:   spec.ImagePullSecrets,
+		Environment:        envFromV1(spec.Env, spec.Environment),
+		Command:            spec.Command,
+		AppName:            spec.AppName,
+		AppGUID:            spec.AppGUID,
+		OrgName:            spec.OrgName,
+		OrgGUID:            spec.OrgGUID,
+		SpaceName:          spec.SpaceName,
+		SpaceGUID:          spec.SpaceGUID,
+		Memory:             *resource.NewScaledQuantity(spec.MemoryMB, resource.Mega),
+		Disk:               *resource.NewScaledQuantity(spec.DiskMB, resource.Mega),
+		CPUMillis:          spec.CPUMillis,
+		PlacementTags:      spec.PlacementTags,
+		ServiceAccountName: spec.ServiceAccountName,
+	}
+	dst.Status = TaskStatus(*src.Status.DeepCopy())
+
+	var err error
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Annotations, err = withV1FieldsAnnotation(dst.Annotations, v1Fields{EnvNames: sortedNames(spec.Env)})
+
+	return err
+}
+
+// v1FieldsOf reads the hints of the V1FieldsAnnotation. As the annotation
+// is only an optimisation for v1 readers, a malformed one is ignored.
+func v1FieldsOf(annotations map[string]string) v1Fields {
+	fields := v1Fields{}
+
+	data, ok := annotations[V1FieldsAnnotation]
+	if !ok {
+		return fields
+	}
+
+	if err := json.Unmarshal([]byte(data), &fields); err != nil {
+		return v1Fields{}
+	}
+
+	return fields
+}
+
+func withV1FieldsAnnotation(annotations map[string]string, fields v1Fields) (map[string]string, error) {
+	if fields.isEmpty() {
+		return annotations, nil
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal %s annotation", V1FieldsAnnotation)
+	}
+
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	annotations[V1FieldsAnnotation] = string(data)
+
+	return annotations, nil
+}
+
+func withoutV1FieldsAnnotation(annotations map[string]string) map[string]string {
+	delete(annotations, V1FieldsAnnotation)
+
+	if len(annotations) == 0 {
+		return nil
+	}
+
+	return annotations
+}
+
+// envFromV1 merges the deprecated env map into the environment, keeping the
+// precedence the controllers give them: later entries win, so the map goes
+// first.
+func envFromV1(env map[string]string, environment []corev1.EnvVar) []corev1.EnvVar {
+	if len(env) == 0 {
+		return environment
+	}
+
+	return append(mapToEnvVars(env), environment...)
+}
+
+// envToV1 splits the env map back out of the environment, provided the
+// entries envFromV1 added are still leading it, in the order it put them.
+// Otherwise the whole environment is kept as is.
+func envToV1(environment []corev1.EnvVar, envNames []string) (map[string]string, []corev1.EnvVar) {
+	if len(envNames) == 0 || len(environment) < len(envNames) || !sort.StringsAreSorted(envNames) {
+		return nil, environment
+	}
+
+	env := map[string]string{}
+
+	for i, name := range envNames {
+		if environment[i].Name != name || environment[i].ValueFrom != nil {
+			return nil, environment
+		}
+
+		if _, duplicate := env[name]; duplicate {
+			return nil, environment
+		}
+
+		env[name] = environment[i].Value
+	}
+
+	rest := environment[len(envNames):]
+	if len(rest) == 0 {
+		rest = nil
+	}
+
+	return env, rest
+}
+
+func mapToEnvVars(env map[string]string) []corev1.EnvVar {
+	envVars := make([]corev1.EnvVar, 0, len(env))
+	for _, name := range sortedNames(env) {
+		envVars = append(envVars, corev1.EnvVar{Name: name, Value: env[name]})
+	}
+
+	return envVars
+}
+
+func sortedNames(env map[string]string) []string {
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// healthFromV1 folds the deprecated TimeoutMs into StartupTimeoutMs, which
+// is what the controllers fall back to when the latter is not set.
+func healthFromV1(health eiriniv1.Healthcheck) Healthcheck {
+	startupTimeoutMs := health.StartupTimeoutMs
+	if startupTimeoutMs == 0 {
+		startupTimeoutMs = health.TimeoutMs
+	}
+
+	return Healthcheck{
+		Type:                health.Type,
+		Port:                health.Port,
+		Endpoint:            health.Endpoint,
+		Command:             health.Command,
+		StartupTimeoutMs:    int64(startupTimeoutMs),
+		IntervalMs:          int64(health.IntervalMs),
+		InvocationTimeoutMs: int64(health.InvocationTimeoutMs),
+	}
+}
+
+func healthToV1(health Healthcheck, timeout v1HealthTimeout) eiriniv1.Healthcheck {
+	v1Health := eiriniv1.Healthcheck{
+		Type:                health.Type,
+		Port:                health.Port,
+		Endpoint:            health.Endpoint,
+		Command:             health.Command,
+		StartupTimeoutMs:    uint(health.StartupTimeoutMs),
+		IntervalMs:          uint(health.IntervalMs),
+		InvocationTimeoutMs: uint(health.InvocationTimeoutMs),
+	}
+
+	if timeout.TimeoutMs != 0 && timeout.effectiveStartupTimeoutMs() == health.StartupTimeoutMs {
+		v1Health.TimeoutMs = timeout.TimeoutMs
+		v1Health.StartupTimeoutMs = timeout.StartupTimeoutMs
+	}
+
+	return v1Health
+}
+
+func sidecarHealthPath(name string) string {
+	return "sidecars/" + name + "/health"
+}
+
+func toMebibytes(q resource.Quantity) int64 {
+	return (q.Value() + mebibyte - 1) / mebibyte
+}
+
+func fromMebibytes(miB int64) resource.Quantity {
+	return *resource.NewQuantity(miB*mebibyte, resource.BinarySI)
+}
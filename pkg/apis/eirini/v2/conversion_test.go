@@ -0,0 +1,328 @@
+package v2_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	eiriniv2 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apix "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+)
+
+var _ = Describe("Conversion", func() {
+	Describe("LRP", func() {
+		var v1LRP *eiriniv1.LRP
+
+		BeforeEach(func() {
+			maxUnavailable := intstr.FromInt(1)
+			v1LRP = &eiriniv1.LRP{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "my-lrp",
+					Namespace:   "my-namespace",
+					Annotations: map[string]string{"foo": "bar"},
+				},
+				Spec: eiriniv1.LRPSpec{
+					GUID:             "guid",
+					Version:          "version",
+					AppName:          "app",
+					Image:            "eirini/dorini",
+					Command:          []string{"run"},
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "creds"}},
+					Environment:      []corev1.EnvVar{{Name: "FOO", Value: "foo"}},
+					Health: eiriniv1.Healthcheck{
+						Type:       "port",
+						Port:       8080,
+						IntervalMs: 5000,
+					},
+					Ports:     []int32{8080},
+					Instances: 2,
+					MemoryMB:  1024,
+					DiskMB:    512,
+					CPUWeight: 10,
+					Sidecars: []eiriniv1.Sidecar{{
+						Name:     "the-sidecar",
+						Command:  []string{"walk"},
+						MemoryMB: 64,
+					}},
+					VolumeMounts:         []eiriniv1.VolumeMount{{MountPath: "/data", ClaimName: "claim"}},
+					DisruptionBudget:     &eiriniv1.DisruptionBudget{MaxUnavailable: &maxUnavailable},
+					TopologySpreadPolicy: eiriniv1.TopologySpreadPolicyHard,
+					PlacementTags:        []string{"segment-a"},
+					ServiceAccountName:   "my-app-identity",
+				},
+				Status: eiriniv1.LRPStatus{
+					Replicas:  1,
+					Instances: []eiriniv1.LRPInstanceStatus{{Index: 0, PodName: "my-lrp-0", State: eiriniv1.InstanceStateRunning, Node: "node-1", Zone: "zone-a"}},
+				},
+			}
+		})
+
+		convertFromV1 := func() *eiriniv2.LRP {
+			v2LRP := &eiriniv2.LRP{}
+			Expect(v2LRP.ConvertFrom(v1LRP.DeepCopy())).To(Succeed())
+
+			return v2LRP
+		}
+
+		It("converts to v2", func() {
+			v2LRP := convertFromV1()
+
+			Expect(v2LRP.Name).To(Equal("my-lrp"))
+			Expect(v2LRP.Annotations).To(Equal(map[string]string{"foo": "bar"}))
+			Expect(v2LRP.Spec.Memory.String()).To(Equal("1Gi"))
+			Expect(v2LRP.Spec.Disk.String()).To(Equal("512Mi"))
+			Expect(v2LRP.Spec.Sidecars[0].Memory.String()).To(Equal("64Mi"))
+			Expect(v2LRP.Spec.Health.IntervalMs).To(BeNumerically("==", 5000))
+			Expect(v2LRP.Status.Instances).To(ConsistOf(eiriniv2.LRPInstanceStatus{Index: 0, PodName: "my-lrp-0", State: "running", Node: "node-1", Zone: "zone-a"}))
+		})
+
+		It("round trips through v2", func() {
+			v2LRP := convertFromV1()
+
+			roundTripped := &eiriniv1.LRP{}
+			Expect(v2LRP.ConvertTo(roundTripped)).To(Succeed())
+			Expect(roundTripped).To(Equal(v1LRP))
+		})
+
+		When("the LRP uses deprecated v1 fields", func() {
+			BeforeEach(func() {
+				v1LRP.Spec.Env = map[string]string{"BAZ": "baz", "BAR": "bar"}
+				v1LRP.Spec.Health.TimeoutMs = 60000
+				v1LRP.Spec.ReadinessHealth = &eiriniv1.Healthcheck{Type: "port", Port: 8080, TimeoutMs: 1000, StartupTimeoutMs: 2000}
+				v1LRP.Spec.Sidecars[0].Health = &eiriniv1.Healthcheck{Type: "exec", Command: []string{"true"}, TimeoutMs: 3000}
+			})
+
+			It("maps them to their v2 replacements", func() {
+				v2LRP := convertFromV1()
+
+				Expect(v2LRP.Spec.Environment).To(Equal([]corev1.EnvVar{
+					{Name: "BAR", Value: "bar"},
+					{Name: "BAZ", Value: "baz"},
+					{Name: "FOO", Value: "foo"},
+				}))
+				Expect(v2LRP.Spec.Health.StartupTimeoutMs).To(BeNumerically("==", 60000))
+				Expect(v2LRP.Spec.ReadinessHealth.StartupTimeoutMs).To(BeNumerically("==", 2000))
+				Expect(v2LRP.Spec.Sidecars[0].Health.StartupTimeoutMs).To(BeNumerically("==", 3000))
+				Expect(v2LRP.Annotations).To(HaveKey(eiriniv2.V1FieldsAnnotation))
+				Expect(v2LRP.Annotations[eiriniv2.V1FieldsAnnotation]).NotTo(ContainSubstring("baz"))
+			})
+
+			It("round trips through v2", func() {
+				v2LRP := convertFromV1()
+
+				roundTripped := &eiriniv1.LRP{}
+				Expect(v2LRP.ConvertTo(roundTripped)).To(Succeed())
+				Expect(roundTripped).To(Equal(v1LRP))
+			})
+
+			When("the v2 client changes the merged environment", func() {
+				It("keeps the changed values", func() {
+					v2LRP := convertFromV1()
+					v2LRP.Spec.Environment[0].Value = "changed"
+
+					v1Converted := &eiriniv1.LRP{}
+					Expect(v2LRP.ConvertTo(v1Converted)).To(Succeed())
+					Expect(v1Converted.Spec.Env).To(Equal(map[string]string{"BAR": "changed", "BAZ": "baz"}))
+				})
+
+				It("keeps the whole environment when the env map entries are moved", func() {
+					v2LRP := convertFromV1()
+					v2LRP.Spec.Environment = append(v2LRP.Spec.Environment[1:], v2LRP.Spec.Environment[0])
+
+					v1Converted := &eiriniv1.LRP{}
+					Expect(v2LRP.ConvertTo(v1Converted)).To(Succeed())
+					Expect(v1Converted.Spec.Env).To(BeEmpty())
+					Expect(v1Converted.Spec.Environment).To(Equal(v2LRP.Spec.Environment))
+				})
+			})
+
+			When("the v2 client forges the annotation", func() {
+				It("does not inject values that are not in the v2 spec", func() {
+					v2LRP := convertFromV1()
+					v2LRP.Annotations[eiriniv2.V1FieldsAnnotation] = `{"envNames":["AAA","BAR"],"timeouts":{"health":{"timeoutMs":1}}}`
+
+					v1Converted := &eiriniv1.LRP{}
+					Expect(v2LRP.ConvertTo(v1Converted)).To(Succeed())
+					Expect(v1Converted.Spec.Env).To(BeEmpty())
+					Expect(v1Converted.Spec.Environment).To(Equal(v2LRP.Spec.Environment))
+					Expect(v1Converted.Spec.Health.TimeoutMs).To(BeZero())
+					Expect(v1Converted.Spec.Health.StartupTimeoutMs).To(BeNumerically("==", 60000))
+				})
+
+				It("ignores a malformed annotation", func() {
+					v2LRP := convertFromV1()
+					v2LRP.Annotations[eiriniv2.V1FieldsAnnotation] = "{"
+
+					v1Converted := &eiriniv1.LRP{}
+					Expect(v2LRP.ConvertTo(v1Converted)).To(Succeed())
+					Expect(v1Converted.Annotations).NotTo(HaveKey(eiriniv2.V1FieldsAnnotation))
+					Expect(v1Converted.Spec.Environment).To(Equal(v2LRP.Spec.Environment))
+				})
+			})
+
+			When("the v2 client changes the startup timeout", func() {
+				It("does not restore the deprecated timeout", func() {
+					v2LRP := convertFromV1()
+					v2LRP.Spec.Health.StartupTimeoutMs = 30000
+
+					v1Converted := &eiriniv1.LRP{}
+					Expect(v2LRP.ConvertTo(v1Converted)).To(Succeed())
+					Expect(v1Converted.Spec.Health.TimeoutMs).To(BeZero())
+					Expect(v1Converted.Spec.Health.StartupTimeoutMs).To(BeNumerically("==", 30000))
+				})
+			})
+		})
+
+		When("the LRP uses a private registry", func() {
+			BeforeEach(func() {
+				v1LRP.Spec.PrivateRegistry = &eiriniv1.PrivateRegistry{Username: "user", Password: "pass"}
+			})
+
+			It("does not expose the credentials through v2", func() {
+				v2LRP := convertFromV1()
+
+				for _, value := range v2LRP.Annotations {
+					Expect(value).NotTo(ContainSubstring("pass"))
+				}
+
+				v1Converted := &eiriniv1.LRP{}
+				Expect(v2LRP.ConvertTo(v1Converted)).To(Succeed())
+				Expect(v1Converted.Spec.PrivateRegistry).To(BeNil())
+			})
+		})
+
+		Describe("from v2", func() {
+			var v2LRP *eiriniv2.LRP
+
+			BeforeEach(func() {
+				v2LRP = convertFromV1()
+				v2LRP.Spec.Memory = resource.MustParse("2Gi")
+			})
+
+			It("round trips through v1", func() {
+				v1Converted := &eiriniv1.LRP{}
+				Expect(v2LRP.ConvertTo(v1Converted)).To(Succeed())
+				Expect(v1Converted.Spec.MemoryMB).To(BeNumerically("==", 2048))
+
+				roundTripped := &eiriniv2.LRP{}
+				Expect(roundTripped.ConvertFrom(v1Converted)).To(Succeed())
+				Expect(roundTripped.Spec.Memory.Cmp(v2LRP.Spec.Memory)).To(BeZero())
+				Expect(roundTripped.Spec.Environment).To(Equal(v2LRP.Spec.Environment))
+				Expect(roundTripped.Spec.Health).To(Equal(v2LRP.Spec.Health))
+			})
+
+			It("rounds quantities up to whole mebibytes", func() {
+				v2LRP.Spec.Memory = resource.MustParse("1G")
+
+				v1Converted := &eiriniv1.LRP{}
+				Expect(v2LRP.ConvertTo(v1Converted)).To(Succeed())
+				Expect(v1Converted.Spec.MemoryMB).To(BeNumerically("==", 954))
+			})
+		})
+	})
+
+	Describe("Task", func() {
+		var v1Task *eiriniv1.Task
+
+		BeforeEach(func() {
+			v1Task = &eiriniv1.Task{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-task", Namespace: "my-namespace"},
+				Spec: eiriniv1.TaskSpec{
+					GUID:               "guid",
+					Image:              "eirini/busybox",
+					Command:            []string{"sh", "-c", "true"},
+					Env:                map[string]string{"FOO": "foo"},
+					Environment:        []corev1.EnvVar{{Name: "BAR", Value: "bar"}},
+					MemoryMB:           256,
+					DiskMB:             1024,
+					CPUMillis:          100,
+					PlacementTags:      []string{"segment-a"},
+					ServiceAccountName: "my-task-identity",
+				},
+				Status: eiriniv1.TaskStatus{
+					Conditions: []metav1.Condition{{Type: eiriniv1.TaskStartedConditionType, Status: metav1.ConditionTrue}},
+				},
+			}
+		})
+
+		It("round trips through v2", func() {
+			v2Task := &eiriniv2.Task{}
+			Expect(v2Task.ConvertFrom(v1Task.DeepCopy())).To(Succeed())
+			Expect(v2Task.Spec.Memory.String()).To(Equal("256M"))
+			Expect(v2Task.Spec.Environment).To(Equal([]corev1.EnvVar{{Name: "FOO", Value: "foo"}, {Name: "BAR", Value: "bar"}}))
+
+			roundTripped := &eiriniv1.Task{}
+			Expect(v2Task.ConvertTo(roundTripped)).To(Succeed())
+			Expect(roundTripped).To(Equal(v1Task))
+		})
+
+		It("rounds quantities up to whole mebibytes", func() {
+			v2Task := &eiriniv2.Task{Spec: eiriniv2.TaskSpec{Memory: resource.MustParse("1Gi")}}
+
+			v1Converted := &eiriniv1.Task{}
+			Expect(v2Task.ConvertTo(v1Converted)).To(Succeed())
+			Expect(v1Converted.Spec.MemoryMB).To(BeNumerically("==", 1074))
+		})
+	})
+
+	Describe("webhook", func() {
+		var (
+			webhook  *conversion.Webhook
+			response *httptest.ResponseRecorder
+		)
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Expect(eiriniv1.AddToScheme(scheme)).To(Succeed())
+			Expect(eiriniv2.AddToScheme(scheme)).To(Succeed())
+
+			webhook = &conversion.Webhook{}
+			Expect(webhook.InjectScheme(scheme)).To(Succeed())
+
+			v1LRP := &eiriniv1.LRP{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "eirini.cloudfoundry.org/v1", Kind: "LRP"},
+				ObjectMeta: metav1.ObjectMeta{Name: "my-lrp"},
+				Spec:       eiriniv1.LRPSpec{GUID: "guid", Image: "eirini/dorini", MemoryMB: 256, DiskMB: 512},
+			}
+			raw, err := json.Marshal(v1LRP)
+			Expect(err).NotTo(HaveOccurred())
+
+			review, err := json.Marshal(apix.ConversionReview{
+				TypeMeta: metav1.TypeMeta{APIVersion: "apiextensions.k8s.io/v1", Kind: "ConversionReview"},
+				Request: &apix.ConversionRequest{
+					UID:               "uid",
+					DesiredAPIVersion: "eirini.cloudfoundry.org/v2",
+					Objects:           []runtime.RawExtension{{Raw: raw}},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			response = httptest.NewRecorder()
+			webhook.ServeHTTP(response, httptest.NewRequest(http.MethodPost, "/convert", bytes.NewReader(review)))
+		})
+
+		It("converts v1 objects to v2", func() {
+			Expect(response.Code).To(Equal(http.StatusOK))
+
+			review := apix.ConversionReview{}
+			Expect(json.Unmarshal(response.Body.Bytes(), &review)).To(Succeed())
+			Expect(review.Response.Result.Status).To(Equal(metav1.StatusSuccess))
+			Expect(review.Response.ConvertedObjects).To(HaveLen(1))
+
+			v2LRP := &eiriniv2.LRP{}
+			Expect(json.Unmarshal(review.Response.ConvertedObjects[0].Raw, v2LRP)).To(Succeed())
+			Expect(v2LRP.APIVersion).To(Equal("eirini.cloudfoundry.org/v2"))
+			Expect(v2LRP.Spec.Memory.String()).To(Equal("256Mi"))
+		})
+	})
+})
@@ -0,0 +1,4 @@
+// +k8s:deepcopy-gen=package
+// +groupName=eirini.cloudfoundry.org
+
+package v2
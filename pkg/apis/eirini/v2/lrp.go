@@ -0,0 +1,152 @@
+// +kubebuilder:validation:Optional
+package v2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// LRP describes an Long Running Process
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName=lrp
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:JSONPath=.spec.instances,type=integer,name=Replicas
+// +kubebuilder:printcolumn:JSONPath=.status.replicas,type=integer,name=Ready
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type LRP struct {
+	meta_v1.TypeMeta   `json:",inline"`
+	meta_v1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LRPSpec   `json:"spec"`
+	Status LRPStatus `json:"status"`
+}
+
+type LRPSpec struct {
+	// +kubebuilder:validation:Required
+	GUID        string `json:"GUID"`
+	Version     string `json:"version"`
+	ProcessType string `json:"processType"`
+	AppName     string `json:"appName"`
+	AppGUID     string `json:"appGUID"`
+	OrgName     string `json:"orgName"`
+	OrgGUID     string `json:"orgGUID"`
+	SpaceName   string `json:"spaceName"`
+	SpaceGUID   string `json:"spaceGUID"`
+	// +kubebuilder:validation:Required
+	Image            string                        `json:"image"`
+	Command          []string                      `json:"command,omitempty"`
+	Sidecars         []Sidecar                     `json:"sidecars,omitempty"`
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	Environment      []corev1.EnvVar               `json:"environment,omitempty"`
+	Health           Healthcheck                   `json:"health"`
+	// ReadinessHealth is the check deciding whether an instance should
+	// receive traffic. When not set, Health is used instead
+	ReadinessHealth *Healthcheck `json:"readinessHealth,omitempty"`
+	Ports           []int32      `json:"ports,omitempty"`
+	// +kubebuilder:default:=1
+	Instances int `json:"instances"`
+	// Memory is rounded up to whole mebibytes
+	Memory resource.Quantity `json:"memory"`
+	// Disk is rounded up to whole mebibytes
+	// +kubebuilder:validation:Required
+	Disk resource.Quantity `json:"disk"`
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=255
+	CPUWeight              uint8             `json:"cpuWeight"`
+	VolumeMounts           []VolumeMount     `json:"volumeMounts,omitempty"`
+	UserDefinedAnnotations map[string]string `json:"userDefinedAnnotations,omitempty"`
+	// DisruptionBudget overrides the disruption budget the controller
+	// configures by default for LRPs with more than one instance
+	DisruptionBudget *DisruptionBudget `json:"disruptionBudget,omitempty"`
+	// TopologySpreadPolicy overrides how strictly the controller spreads the
+	// instances across zones and nodes. When not set, the controller default
+	// is used. The hard policy requires the nodes to have the
+	// topology.kubernetes.io/zone label, or the instances stay pending
+	// +kubebuilder:validation:Enum=soft;hard
+	TopologySpreadPolicy string `json:"topologySpreadPolicy,omitempty"`
+	// PlacementTags restrict the nodes the instances run on, e.g. to the
+	// ones of a CF isolation segment. Each tag must be configured in the
+	// controller
+	PlacementTags []string `json:"placementTags,omitempty"`
+	// ServiceAccountName is the service account the instances run as,
+	// giving the app its own workload identity. When empty, the controller
+	// runs them as its per-app or shared application service account. It
+	// must be one of the service accounts allowed by the controller, or the
+	// per-app one of the app
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+}
+
+type LRPStatus struct {
+	Replicas  int32               `json:"replicas"`
+	Instances []LRPInstanceStatus `json:"instances,omitempty"`
+}
+
+type LRPInstanceStatus struct {
+	Index   int    `json:"index"`
+	PodName string `json:"podName"`
+	// +kubebuilder:validation:Enum=starting;running;unready;crashed
+	State string `json:"state"`
+	// Node is the name of the node the instance is scheduled on
+	Node string `json:"node,omitempty"`
+	// Zone is the topology.kubernetes.io/zone label of that node
+	Zone string `json:"zone,omitempty"`
+}
+
+type Sidecar struct {
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// +kubebuilder:validation:Required
+	Command []string `json:"command"`
+	// Memory is rounded up to whole mebibytes
+	Memory resource.Quantity `json:"memory"`
+	Env    map[string]string `json:"env,omitempty"`
+	Health *Healthcheck      `json:"health,omitempty"`
+}
+
+// DisruptionBudget sets either the minimum number of available instances or
+// the maximum number of unavailable ones during voluntary disruptions, as a
+// number or a percentage of the instances
+type DisruptionBudget struct {
+	MinAvailable   *intstr.IntOrString `json:"minAvailable,omitempty"`
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+type VolumeMount struct {
+	MountPath string `json:"mountPath"`
+	ClaimName string `json:"claimName"`
+}
+
+type Healthcheck struct {
+	// Type is one of http, port, process or exec. An empty type is the
+	// same as process: no probe is run against the container
+	Type     string `json:"type"`
+	Port     int32  `json:"port"`
+	Endpoint string `json:"endpoint"`
+	// Command is the command run inside the container by exec health checks
+	Command []string `json:"command,omitempty"`
+	// StartupTimeoutMs is the time the app has to pass its first health
+	// check before it is considered crashed
+	// +kubebuilder:validation:Minimum:=0
+	StartupTimeoutMs int64 `json:"startupTimeoutMs,omitempty"`
+	// IntervalMs is the time between two consecutive health checks once
+	// the app has started
+	// +kubebuilder:validation:Minimum:=0
+	IntervalMs int64 `json:"intervalMs,omitempty"`
+	// InvocationTimeoutMs is the time a single health check has to
+	// respond before it is considered failed
+	// +kubebuilder:validation:Minimum:=0
+	InvocationTimeoutMs int64 `json:"invocationTimeoutMs,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type LRPList struct {
+	meta_v1.TypeMeta `json:",inline"`
+	meta_v1.ListMeta `json:"metadata"`
+
+	Items []LRP `json:"items"`
+}
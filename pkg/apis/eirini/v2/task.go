@@ -0,0 +1,68 @@
+package v2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName=task
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:JSONPath=.status.execution_status,type=string,name=State
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Task describes a short-lived job running alongside an LRP
+type Task struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TaskSpec   `json:"spec"`
+	Status TaskStatus `json:"status"`
+}
+
+type TaskSpec struct {
+	// +kubebuilder:validation:Required
+	GUID string `json:"GUID"`
+	Name string `json:"name"`
+	// +kubebuilder:validation:Required
+	Image            string                        `json:"image"`
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	Environment      []corev1.EnvVar               `json:"environment,omitempty"`
+	// +kubebuilder:validation:Required
+	Command   []string `json:"command,omitempty"`
+	AppName   string   `json:"appName"`
+	AppGUID   string   `json:"appGUID"`
+	OrgName   string   `json:"orgName"`
+	OrgGUID   string   `json:"orgGUID"`
+	SpaceName string   `json:"spaceName"`
+	SpaceGUID string   `json:"spaceGUID"`
+	// Memory is rounded up to whole mebibytes
+	Memory resource.Quantity `json:"memory"`
+	// Disk is rounded up to whole mebibytes
+	Disk      resource.Quantity `json:"disk"`
+	CPUMillis int64             `json:"cpuMillis"`
+	// PlacementTags restrict the nodes the task runs on, e.g. to the ones of
+	// a CF isolation segment. Each tag must be configured in the controller
+	PlacementTags []string `json:"placementTags,omitempty"`
+	// ServiceAccountName is the service account the task runs as, giving it
+	// its own workload identity. When empty, the controller runs it as its
+	// per-app or shared application service account. It must be one of the
+	// service accounts allowed by the controller, or the per-app one of the
+	// app
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type TaskList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []Task `json:"items"`
+}
+
+type TaskStatus struct {
+	Conditions []metav1.Condition `json:"conditions"`
+}
@@ -0,0 +1,327 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// LRPSpecApplyConfiguration represents an declarative configuration of the LRPSpec type for use
+// with apply.
+type LRPSpecApplyConfiguration struct {
+	GUID                   *string                             `json:"GUID,omitempty"`
+	Version                *string                             `json:"version,omitempty"`
+	ProcessType            *string                             `json:"processType,omitempty"`
+	AppName                *string                             `json:"appName,omitempty"`
+	AppGUID                *string                             `json:"appGUID,omitempty"`
+	OrgName                *string                             `json:"orgName,omitempty"`
+	OrgGUID                *string                             `json:"orgGUID,omitempty"`
+	SpaceName              *string                             `json:"spaceName,omitempty"`
+	SpaceGUID              *string                             `json:"spaceGUID,omitempty"`
+	Image                  *string                             `json:"image,omitempty"`
+	Command                []string                            `json:"command,omitempty"`
+	Sidecars               []SidecarApplyConfiguration         `json:"sidecars,omitempty"`
+	PrivateRegistry        *PrivateRegistryApplyConfiguration  `json:"privateRegistry,omitempty"`
+	ImagePullSecrets       []corev1.LocalObjectReference       `json:"imagePullSecrets,omitempty"`
+	Env                    map[string]string                   `json:"env,omitempty"`
+	Environment            []corev1.EnvVar                     `json:"environment,omitempty"`
+	Health                 *HealthcheckApplyConfiguration      `json:"health,omitempty"`
+	ReadinessHealth        *HealthcheckApplyConfiguration      `json:"readinessHealth,omitempty"`
+	Ports                  []int32                             `json:"ports,omitempty"`
+	Instances              *int                                `json:"instances,omitempty"`
+	MemoryMB               *int64                              `json:"memoryMB,omitempty"`
+	DiskMB                 *int64                              `json:"diskMB,omitempty"`
+	CPUWeight              *byte                               `json:"cpuWeight,omitempty"`
+	VolumeMounts           []VolumeMountApplyConfiguration     `json:"volumeMounts,omitempty"`
+	UserDefinedAnnotations map[string]string                   `json:"userDefinedAnnotations,omitempty"`
+	DisruptionBudget       *DisruptionBudgetApplyConfiguration `json:"disruptionBudget,omitempty"`
+	TopologySpreadPolicy   *string                             `json:"topologySpreadPolicy,omitempty"`
+	PlacementTags          []string                            `json:"placementTags,omitempty"`
+	ServiceAccountName     *string                             `json:"serviceAccountName,omitempty"`
+}
+
+// LRPSpecApplyConfiguration constructs an declarative configuration of the LRPSpec type for use with
+// apply.
+func LRPSpec() *LRPSpecApplyConfiguration {
+	return &LRPSpecApplyConfiguration{}
+}
+
+// WithGUID sets the GUID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GUID field is set to the value of the last call.
+func (b *LRPSpecApplyConfiguration) WithGUID(value string) *LRPSpecApplyConfiguration {
+	b.GUID = &value
+	return b
+}
+
+// WithVersion sets the Version field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Version field is set to the value of the last call.
+func (b *LRPSpecApplyConfiguration) WithVersion(value string) *LRPSpecApplyConfiguration {
+	b.Version = &value
+	return b
+}
+
+// WithProcessType sets the ProcessType field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ProcessType field is set to the value of the last call.
+func (b *LRPSpecApplyConfiguration) WithProcessType(value string) *LRPSpecApplyConfiguration {
+	b.ProcessType = &value
+	return b
+}
+
+// WithAppName sets the AppName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AppName field is set to the value of the last call.
+func (b *LRPSpecApplyConfiguration) WithAppName(value string) *LRPSpecApplyConfiguration {
+	b.AppName = &value
+	return b
+}
+
+// WithAppGUID sets the AppGUID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AppGUID field is set to the value of the last call.
+func (b *LRPSpecApplyConfiguration) WithAppGUID(value string) *LRPSpecApplyConfiguration {
+	b.AppGUID = &value
+	return b
+}
+
+// WithOrgName sets the OrgName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OrgName field is set to the value of the last call.
+func (b *LRPSpecApplyConfiguration) WithOrgName(value string) *LRPSpecApplyConfiguration {
+	b.OrgName = &value
+	return b
+}
+
+// WithOrgGUID sets the OrgGUID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OrgGUID field is set to the value of the last call.
+func (b *LRPSpecApplyConfiguration) WithOrgGUID(value string) *LRPSpecApplyConfiguration {
+	b.OrgGUID = &value
+	return b
+}
+
+// WithSpaceName sets the SpaceName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SpaceName field is set to the value of the last call.
+func (b *LRPSpecApplyConfiguration) WithSpaceName(value string) *LRPSpecApplyConfiguration {
+	b.SpaceName = &value
+	return b
+}
+
+// WithSpaceGUID sets the SpaceGUID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SpaceGUID field is set to the value of the last call.
+func (b *LRPSpecApplyConfiguration) WithSpaceGUID(value string) *LRPSpecApplyConfiguration {
+	b.SpaceGUID = &value
+	return b
+}
+
+// WithImage sets the Image field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Image field is set to the value of the last call.
+func (b *LRPSpecApplyConfiguration) WithImage(value string) *LRPSpecApplyConfiguration {
+	b.Image = &value
+	return b
+}
+
+// WithCommand adds the given value to the Command field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Command field.
+func (b *LRPSpecApplyConfiguration) WithCommand(values ...string) *LRPSpecApplyConfiguration {
+	for i := range values {
+		b.Command = append(b.Command, values[i])
+	}
+	return b
+}
+
+// WithSidecars adds the given value to the Sidecars field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Sidecars field.
+func (b *LRPSpecApplyConfiguration) WithSidecars(values ...*SidecarApplyConfiguration) *LRPSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithSidecars")
+		}
+		b.Sidecars = append(b.Sidecars, *values[i])
+	}
+	return b
+}
+
+// WithPrivateRegistry sets the PrivateRegistry field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PrivateRegistry field is set to the value of the last call.
+func (b *LRPSpecApplyConfiguration) WithPrivateRegistry(value *PrivateRegistryApplyConfiguration) *LRPSpecApplyConfiguration {
+	b.PrivateRegistry = value
+	return b
+}
+
+// WithImagePullSecrets adds the given value to the ImagePullSecrets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ImagePullSecrets field.
+func (b *LRPSpecApplyConfiguration) WithImagePullSecrets(values ...corev1.LocalObjectReference) *LRPSpecApplyConfiguration {
+	for i := range values {
+		b.ImagePullSecrets = append(b.ImagePullSecrets, values[i])
+	}
+	return b
+}
+
+// WithEnv puts the entries into the Env field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Env field,
+// overwriting an existing map entries in Env field with the same key.
+func (b *LRPSpecApplyConfiguration) WithEnv(entries map[string]string) *LRPSpecApplyConfiguration {
+	if b.Env == nil && len(entries) > 0 {
+		b.Env = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Env[k] = v
+	}
+	return b
+}
+
+// WithEnvironment adds the given value to the Environment field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Environment field.
+func (b *LRPSpecApplyConfiguration) WithEnvironment(values ...corev1.EnvVar) *LRPSpecApplyConfiguration {
+	for i := range values {
+		b.Environment = append(b.Environment, values[i])
+	}
+	return b
+}
+
+// WithHealth sets the Health field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Health field is set to the value of the last call.
+func (b *LRPSpecApplyConfiguration) WithHealth(value *HealthcheckApplyConfiguration) *LRPSpecApplyConfiguration {
+	b.Health = value
+	return b
+}
+
+// WithReadinessHealth sets the ReadinessHealth field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReadinessHealth field is set to the value of the last call.
+func (b *LRPSpecApplyConfiguration) WithReadinessHealth(value *HealthcheckApplyConfiguration) *LRPSpecApplyConfiguration {
+	b.ReadinessHealth = value
+	return b
+}
+
+// WithPorts adds the given value to the Ports field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Ports field.
+func (b *LRPSpecApplyConfiguration) WithPorts(values ...int32) *LRPSpecApplyConfiguration {
+	for i := range values {
+		b.Ports = append(b.Ports, values[i])
+	}
+	return b
+}
+
+// WithInstances sets the Instances field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Instances field is set to the value of the last call.
+func (b *LRPSpecApplyConfiguration) WithInstances(value int) *LRPSpecApplyConfiguration {
+	b.Instances = &value
+	return b
+}
+
+// WithMemoryMB sets the MemoryMB field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MemoryMB field is set to the value of the last call.
+func (b *LRPSpecApplyConfiguration) WithMemoryMB(value int64) *LRPSpecApplyConfiguration {
+	b.MemoryMB = &value
+	return b
+}
+
+// WithDiskMB sets the DiskMB field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DiskMB field is set to the value of the last call.
+func (b *LRPSpecApplyConfiguration) WithDiskMB(value int64) *LRPSpecApplyConfiguration {
+	b.DiskMB = &value
+	return b
+}
+
+// WithCPUWeight sets the CPUWeight field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CPUWeight field is set to the value of the last call.
+func (b *LRPSpecApplyConfiguration) WithCPUWeight(value byte) *LRPSpecApplyConfiguration {
+	b.CPUWeight = &value
+	return b
+}
+
+// WithVolumeMounts adds the given value to the VolumeMounts field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the VolumeMounts field.
+func (b *LRPSpecApplyConfiguration) WithVolumeMounts(values ...*VolumeMountApplyConfiguration) *LRPSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithVolumeMounts")
+		}
+		b.VolumeMounts = append(b.VolumeMounts, *values[i])
+	}
+	return b
+}
+
+// WithUserDefinedAnnotations puts the entries into the UserDefinedAnnotations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the UserDefinedAnnotations field,
+// overwriting an existing map entries in UserDefinedAnnotations field with the same key.
+func (b *LRPSpecApplyConfiguration) WithUserDefinedAnnotations(entries map[string]string) *LRPSpecApplyConfiguration {
+	if b.UserDefinedAnnotations == nil && len(entries) > 0 {
+		b.UserDefinedAnnotations = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.UserDefinedAnnotations[k] = v
+	}
+	return b
+}
+
+// WithDisruptionBudget sets the DisruptionBudget field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DisruptionBudget field is set to the value of the last call.
+func (b *LRPSpecApplyConfiguration) WithDisruptionBudget(value *DisruptionBudgetApplyConfiguration) *LRPSpecApplyConfiguration {
+	b.DisruptionBudget = value
+	return b
+}
+
+// WithTopologySpreadPolicy sets the TopologySpreadPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TopologySpreadPolicy field is set to the value of the last call.
+func (b *LRPSpecApplyConfiguration) WithTopologySpreadPolicy(value string) *LRPSpecApplyConfiguration {
+	b.TopologySpreadPolicy = &value
+	return b
+}
+
+// WithPlacementTags adds the given value to the PlacementTags field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PlacementTags field.
+func (b *LRPSpecApplyConfiguration) WithPlacementTags(values ...string) *LRPSpecApplyConfiguration {
+	for i := range values {
+		b.PlacementTags = append(b.PlacementTags, values[i])
+	}
+	return b
+}
+
+// WithServiceAccountName sets the ServiceAccountName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServiceAccountName field is set to the value of the last call.
+func (b *LRPSpecApplyConfiguration) WithServiceAccountName(value string) *LRPSpecApplyConfiguration {
+	b.ServiceAccountName = &value
+	return b
+}
@@ -0,0 +1,53 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// LRPStatusApplyConfiguration represents an declarative configuration of the LRPStatus type for use
+// with apply.
+type LRPStatusApplyConfiguration struct {
+	Replicas  *int32                                `json:"replicas,omitempty"`
+	Instances []LRPInstanceStatusApplyConfiguration `json:"instances,omitempty"`
+}
+
+// LRPStatusApplyConfiguration constructs an declarative configuration of the LRPStatus type for use with
+// apply.
+func LRPStatus() *LRPStatusApplyConfiguration {
+	return &LRPStatusApplyConfiguration{}
+}
+
+// WithReplicas sets the Replicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Replicas field is set to the value of the last call.
+func (b *LRPStatusApplyConfiguration) WithReplicas(value int32) *LRPStatusApplyConfiguration {
+	b.Replicas = &value
+	return b
+}
+
+// WithInstances adds the given value to the Instances field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Instances field.
+func (b *LRPStatusApplyConfiguration) WithInstances(values ...*LRPInstanceStatusApplyConfiguration) *LRPStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithInstances")
+		}
+		b.Instances = append(b.Instances, *values[i])
+	}
+	return b
+}
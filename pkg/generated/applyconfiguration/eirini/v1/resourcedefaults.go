@@ -0,0 +1,57 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// ResourceDefaultsApplyConfiguration represents an declarative configuration of the ResourceDefaults type for use
+// with apply.
+type ResourceDefaultsApplyConfiguration struct {
+	MemoryMB  *int64 `json:"memoryMB,omitempty"`
+	DiskMB    *int64 `json:"diskMB,omitempty"`
+	CPUWeight *byte  `json:"cpuWeight,omitempty"`
+}
+
+// ResourceDefaultsApplyConfiguration constructs an declarative configuration of the ResourceDefaults type for use with
+// apply.
+func ResourceDefaults() *ResourceDefaultsApplyConfiguration {
+	return &ResourceDefaultsApplyConfiguration{}
+}
+
+// WithMemoryMB sets the MemoryMB field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MemoryMB field is set to the value of the last call.
+func (b *ResourceDefaultsApplyConfiguration) WithMemoryMB(value int64) *ResourceDefaultsApplyConfiguration {
+	b.MemoryMB = &value
+	return b
+}
+
+// WithDiskMB sets the DiskMB field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DiskMB field is set to the value of the last call.
+func (b *ResourceDefaultsApplyConfiguration) WithDiskMB(value int64) *ResourceDefaultsApplyConfiguration {
+	b.DiskMB = &value
+	return b
+}
+
+// WithCPUWeight sets the CPUWeight field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CPUWeight field is set to the value of the last call.
+func (b *ResourceDefaultsApplyConfiguration) WithCPUWeight(value byte) *ResourceDefaultsApplyConfiguration {
+	b.CPUWeight = &value
+	return b
+}
@@ -0,0 +1,84 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// SecurityProfileApplyConfiguration represents an declarative configuration of the SecurityProfile type for use
+// with apply.
+type SecurityProfileApplyConfiguration struct {
+	RunAsUser              *int64  `json:"runAsUser,omitempty"`
+	RunAsGroup             *int64  `json:"runAsGroup,omitempty"`
+	FSGroup                *int64  `json:"fsGroup,omitempty"`
+	ReadOnlyRootFilesystem *bool   `json:"readOnlyRootFilesystem,omitempty"`
+	AppArmorProfile        *string `json:"appArmorProfile,omitempty"`
+	SeccompProfile         *string `json:"seccompProfile,omitempty"`
+}
+
+// SecurityProfileApplyConfiguration constructs an declarative configuration of the SecurityProfile type for use with
+// apply.
+func SecurityProfile() *SecurityProfileApplyConfiguration {
+	return &SecurityProfileApplyConfiguration{}
+}
+
+// WithRunAsUser sets the RunAsUser field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RunAsUser field is set to the value of the last call.
+func (b *SecurityProfileApplyConfiguration) WithRunAsUser(value int64) *SecurityProfileApplyConfiguration {
+	b.RunAsUser = &value
+	return b
+}
+
+// WithRunAsGroup sets the RunAsGroup field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RunAsGroup field is set to the value of the last call.
+func (b *SecurityProfileApplyConfiguration) WithRunAsGroup(value int64) *SecurityProfileApplyConfiguration {
+	b.RunAsGroup = &value
+	return b
+}
+
+// WithFSGroup sets the FSGroup field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FSGroup field is set to the value of the last call.
+func (b *SecurityProfileApplyConfiguration) WithFSGroup(value int64) *SecurityProfileApplyConfiguration {
+	b.FSGroup = &value
+	return b
+}
+
+// WithReadOnlyRootFilesystem sets the ReadOnlyRootFilesystem field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReadOnlyRootFilesystem field is set to the value of the last call.
+func (b *SecurityProfileApplyConfiguration) WithReadOnlyRootFilesystem(value bool) *SecurityProfileApplyConfiguration {
+	b.ReadOnlyRootFilesystem = &value
+	return b
+}
+
+// WithAppArmorProfile sets the AppArmorProfile field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AppArmorProfile field is set to the value of the last call.
+func (b *SecurityProfileApplyConfiguration) WithAppArmorProfile(value string) *SecurityProfileApplyConfiguration {
+	b.AppArmorProfile = &value
+	return b
+}
+
+// WithSeccompProfile sets the SeccompProfile field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SeccompProfile field is set to the value of the last call.
+func (b *SecurityProfileApplyConfiguration) WithSeccompProfile(value string) *SecurityProfileApplyConfiguration {
+	b.SeccompProfile = &value
+	return b
+}
@@ -0,0 +1,147 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// WorkloadDefaultsSpecApplyConfiguration represents an declarative configuration of the WorkloadDefaultsSpec type for use
+// with apply.
+type WorkloadDefaultsSpecApplyConfiguration struct {
+	ApplicationServiceAccount         *string                             `json:"applicationServiceAccount,omitempty"`
+	RegistrySecretName                *string                             `json:"registrySecretName,omitempty"`
+	AllowAutomountServiceAccountToken *bool                               `json:"allowAutomountServiceAccountToken,omitempty"`
+	DefaultMinAvailableInstances      *intstr.IntOrString                 `json:"defaultMinAvailableInstances,omitempty"`
+	TaskTTLSeconds                    *int32                              `json:"taskTTLSeconds,omitempty"`
+	TopologySpreadPolicy              *string                             `json:"topologySpreadPolicy,omitempty"`
+	PlacementTags                     []string                            `json:"placementTags,omitempty"`
+	SecurityProfile                   *SecurityProfileApplyConfiguration  `json:"securityProfile,omitempty"`
+	Resources                         *ResourceDefaultsApplyConfiguration `json:"resources,omitempty"`
+	Labels                            map[string]string                   `json:"labels,omitempty"`
+	Annotations                       map[string]string                   `json:"annotations,omitempty"`
+}
+
+// WorkloadDefaultsSpecApplyConfiguration constructs an declarative configuration of the WorkloadDefaultsSpec type for use with
+// apply.
+func WorkloadDefaultsSpec() *WorkloadDefaultsSpecApplyConfiguration {
+	return &WorkloadDefaultsSpecApplyConfiguration{}
+}
+
+// WithApplicationServiceAccount sets the ApplicationServiceAccount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ApplicationServiceAccount field is set to the value of the last call.
+func (b *WorkloadDefaultsSpecApplyConfiguration) WithApplicationServiceAccount(value string) *WorkloadDefaultsSpecApplyConfiguration {
+	b.ApplicationServiceAccount = &value
+	return b
+}
+
+// WithRegistrySecretName sets the RegistrySecretName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RegistrySecretName field is set to the value of the last call.
+func (b *WorkloadDefaultsSpecApplyConfiguration) WithRegistrySecretName(value string) *WorkloadDefaultsSpecApplyConfiguration {
+	b.RegistrySecretName = &value
+	return b
+}
+
+// WithAllowAutomountServiceAccountToken sets the AllowAutomountServiceAccountToken field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AllowAutomountServiceAccountToken field is set to the value of the last call.
+func (b *WorkloadDefaultsSpecApplyConfiguration) WithAllowAutomountServiceAccountToken(value bool) *WorkloadDefaultsSpecApplyConfiguration {
+	b.AllowAutomountServiceAccountToken = &value
+	return b
+}
+
+// WithDefaultMinAvailableInstances sets the DefaultMinAvailableInstances field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DefaultMinAvailableInstances field is set to the value of the last call.
+func (b *WorkloadDefaultsSpecApplyConfiguration) WithDefaultMinAvailableInstances(value intstr.IntOrString) *WorkloadDefaultsSpecApplyConfiguration {
+	b.DefaultMinAvailableInstances = &value
+	return b
+}
+
+// WithTaskTTLSeconds sets the TaskTTLSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TaskTTLSeconds field is set to the value of the last call.
+func (b *WorkloadDefaultsSpecApplyConfiguration) WithTaskTTLSeconds(value int32) *WorkloadDefaultsSpecApplyConfiguration {
+	b.TaskTTLSeconds = &value
+	return b
+}
+
+// WithTopologySpreadPolicy sets the TopologySpreadPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TopologySpreadPolicy field is set to the value of the last call.
+func (b *WorkloadDefaultsSpecApplyConfiguration) WithTopologySpreadPolicy(value string) *WorkloadDefaultsSpecApplyConfiguration {
+	b.TopologySpreadPolicy = &value
+	return b
+}
+
+// WithPlacementTags adds the given value to the PlacementTags field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PlacementTags field.
+func (b *WorkloadDefaultsSpecApplyConfiguration) WithPlacementTags(values ...string) *WorkloadDefaultsSpecApplyConfiguration {
+	for i := range values {
+		b.PlacementTags = append(b.PlacementTags, values[i])
+	}
+	return b
+}
+
+// WithSecurityProfile sets the SecurityProfile field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SecurityProfile field is set to the value of the last call.
+func (b *WorkloadDefaultsSpecApplyConfiguration) WithSecurityProfile(value *SecurityProfileApplyConfiguration) *WorkloadDefaultsSpecApplyConfiguration {
+	b.SecurityProfile = value
+	return b
+}
+
+// WithResources sets the Resources field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Resources field is set to the value of the last call.
+func (b *WorkloadDefaultsSpecApplyConfiguration) WithResources(value *ResourceDefaultsApplyConfiguration) *WorkloadDefaultsSpecApplyConfiguration {
+	b.Resources = value
+	return b
+}
+
+// WithLabels puts the entries into the Labels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Labels field,
+// overwriting an existing map entries in Labels field with the same key.
+func (b *WorkloadDefaultsSpecApplyConfiguration) WithLabels(entries map[string]string) *WorkloadDefaultsSpecApplyConfiguration {
+	if b.Labels == nil && len(entries) > 0 {
+		b.Labels = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Labels[k] = v
+	}
+	return b
+}
+
+// WithAnnotations puts the entries into the Annotations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Annotations field,
+// overwriting an existing map entries in Annotations field with the same key.
+func (b *WorkloadDefaultsSpecApplyConfiguration) WithAnnotations(entries map[string]string) *WorkloadDefaultsSpecApplyConfiguration {
+	if b.Annotations == nil && len(entries) > 0 {
+		b.Annotations = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Annotations[k] = v
+	}
+	return b
+}
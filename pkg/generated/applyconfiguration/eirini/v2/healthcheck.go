@@ -0,0 +1,95 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2
+
+// HealthcheckApplyConfiguration represents an declarative configuration of the Healthcheck type for use
+// with apply.
+type HealthcheckApplyConfiguration struct {
+	Type                *string  `json:"type,omitempty"`
+	Port                *int32   `json:"port,omitempty"`
+	Endpoint            *string  `json:"endpoint,omitempty"`
+	Command             []string `json:"command,omitempty"`
+	StartupTimeoutMs    *int64   `json:"startupTimeoutMs,omitempty"`
+	IntervalMs          *int64   `json:"intervalMs,omitempty"`
+	InvocationTimeoutMs *int64   `json:"invocationTimeoutMs,omitempty"`
+}
+
+// HealthcheckApplyConfiguration constructs an declarative configuration of the Healthcheck type for use with
+// apply.
+func Healthcheck() *HealthcheckApplyConfiguration {
+	return &HealthcheckApplyConfiguration{}
+}
+
+// WithType sets the Type field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Type field is set to the value of the last call.
+func (b *HealthcheckApplyConfiguration) WithType(value string) *HealthcheckApplyConfiguration {
+	b.Type = &value
+	return b
+}
+
+// WithPort sets the Port field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Port field is set to the value of the last call.
+func (b *HealthcheckApplyConfiguration) WithPort(value int32) *HealthcheckApplyConfiguration {
+	b.Port = &value
+	return b
+}
+
+// WithEndpoint sets the Endpoint field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Endpoint field is set to the value of the last call.
+func (b *HealthcheckApplyConfiguration) WithEndpoint(value string) *HealthcheckApplyConfiguration {
+	b.Endpoint = &value
+	return b
+}
+
+// WithCommand adds the given value to the Command field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Command field.
+func (b *HealthcheckApplyConfiguration) WithCommand(values ...string) *HealthcheckApplyConfiguration {
+	for i := range values {
+		b.Command = append(b.Command, values[i])
+	}
+	return b
+}
+
+// WithStartupTimeoutMs sets the StartupTimeoutMs field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StartupTimeoutMs field is set to the value of the last call.
+func (b *HealthcheckApplyConfiguration) WithStartupTimeoutMs(value int64) *HealthcheckApplyConfiguration {
+	b.StartupTimeoutMs = &value
+	return b
+}
+
+// WithIntervalMs sets the IntervalMs field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IntervalMs field is set to the value of the last call.
+func (b *HealthcheckApplyConfiguration) WithIntervalMs(value int64) *HealthcheckApplyConfiguration {
+	b.IntervalMs = &value
+	return b
+}
+
+// WithInvocationTimeoutMs sets the InvocationTimeoutMs field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the InvocationTimeoutMs field is set to the value of the last call.
+func (b *HealthcheckApplyConfiguration) WithInvocationTimeoutMs(value int64) *HealthcheckApplyConfiguration {
+	b.InvocationTimeoutMs = &value
+	return b
+}
@@ -0,0 +1,75 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2
+
+// LRPInstanceStatusApplyConfiguration represents an declarative configuration of the LRPInstanceStatus type for use
+// with apply.
+type LRPInstanceStatusApplyConfiguration struct {
+	Index   *int    `json:"index,omitempty"`
+	PodName *string `json:"podName,omitempty"`
+	State   *string `json:"state,omitempty"`
+	Node    *string `json:"node,omitempty"`
+	Zone    *string `json:"zone,omitempty"`
+}
+
+// LRPInstanceStatusApplyConfiguration constructs an declarative configuration of the LRPInstanceStatus type for use with
+// apply.
+func LRPInstanceStatus() *LRPInstanceStatusApplyConfiguration {
+	return &LRPInstanceStatusApplyConfiguration{}
+}
+
+// WithIndex sets the Index field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Index field is set to the value of the last call.
+func (b *LRPInstanceStatusApplyConfiguration) WithIndex(value int) *LRPInstanceStatusApplyConfiguration {
+	b.Index = &value
+	return b
+}
+
+// WithPodName sets the PodName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PodName field is set to the value of the last call.
+func (b *LRPInstanceStatusApplyConfiguration) WithPodName(value string) *LRPInstanceStatusApplyConfiguration {
+	b.PodName = &value
+	return b
+}
+
+// WithState sets the State field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the State field is set to the value of the last call.
+func (b *LRPInstanceStatusApplyConfiguration) WithState(value string) *LRPInstanceStatusApplyConfiguration {
+	b.State = &value
+	return b
+}
+
+// WithNode sets the Node field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Node field is set to the value of the last call.
+func (b *LRPInstanceStatusApplyConfiguration) WithNode(value string) *LRPInstanceStatusApplyConfiguration {
+	b.Node = &value
+	return b
+}
+
+// WithZone sets the Zone field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Zone field is set to the value of the last call.
+func (b *LRPInstanceStatusApplyConfiguration) WithZone(value string) *LRPInstanceStatusApplyConfiguration {
+	b.Zone = &value
+	return b
+}
@@ -0,0 +1,87 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2
+
+import (
+	resource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+// SidecarApplyConfiguration represents an declarative configuration of the Sidecar type for use
+// with apply.
+type SidecarApplyConfiguration struct {
+	Name    *string                        `json:"name,omitempty"`
+	Command []string                       `json:"command,omitempty"`
+	Memory  *resource.Quantity             `json:"memory,omitempty"`
+	Env     map[string]string              `json:"env,omitempty"`
+	Health  *HealthcheckApplyConfiguration `json:"health,omitempty"`
+}
+
+// SidecarApplyConfiguration constructs an declarative configuration of the Sidecar type for use with
+// apply.
+func Sidecar() *SidecarApplyConfiguration {
+	return &SidecarApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *SidecarApplyConfiguration) WithName(value string) *SidecarApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithCommand adds the given value to the Command field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Command field.
+func (b *SidecarApplyConfiguration) WithCommand(values ...string) *SidecarApplyConfiguration {
+	for i := range values {
+		b.Command = append(b.Command, values[i])
+	}
+	return b
+}
+
+// WithMemory sets the Memory field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Memory field is set to the value of the last call.
+func (b *SidecarApplyConfiguration) WithMemory(value resource.Quantity) *SidecarApplyConfiguration {
+	b.Memory = &value
+	return b
+}
+
+// WithEnv puts the entries into the Env field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Env field,
+// overwriting an existing map entries in Env field with the same key.
+func (b *SidecarApplyConfiguration) WithEnv(entries map[string]string) *SidecarApplyConfiguration {
+	if b.Env == nil && len(entries) > 0 {
+		b.Env = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Env[k] = v
+	}
+	return b
+}
+
+// WithHealth sets the Health field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Health field is set to the value of the last call.
+func (b *SidecarApplyConfiguration) WithHealth(value *HealthcheckApplyConfiguration) *SidecarApplyConfiguration {
+	b.Health = value
+	return b
+}
@@ -0,0 +1,196 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2
+
+import (
+	v1 "k8s.io/api/core/v1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+// TaskSpecApplyConfiguration represents an declarative configuration of the TaskSpec type for use
+// with apply.
+type TaskSpecApplyConfiguration struct {
+	GUID               *string                   `json:"GUID,omitempty"`
+	Name               *string                   `json:"name,omitempty"`
+	Image              *string                   `json:"image,omitempty"`
+	ImagePullSecrets   []v1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	Environment        []v1.EnvVar               `json:"environment,omitempty"`
+	Command            []string                  `json:"command,omitempty"`
+	AppName            *string                   `json:"appName,omitempty"`
+	AppGUID            *string                   `json:"appGUID,omitempty"`
+	OrgName            *string                   `json:"orgName,omitempty"`
+	OrgGUID            *string                   `json:"orgGUID,omitempty"`
+	SpaceName          *string                   `json:"spaceName,omitempty"`
+	SpaceGUID          *string                   `json:"spaceGUID,omitempty"`
+	Memory             *resource.Quantity        `json:"memory,omitempty"`
+	Disk               *resource.Quantity        `json:"disk,omitempty"`
+	CPUMillis          *int64                    `json:"cpuMillis,omitempty"`
+	PlacementTags      []string                  `json:"placementTags,omitempty"`
+	ServiceAccountName *string                   `json:"serviceAccountName,omitempty"`
+}
+
+// TaskSpecApplyConfiguration constructs an declarative configuration of the TaskSpec type for use with
+// apply.
+func TaskSpec() *TaskSpecApplyConfiguration {
+	return &TaskSpecApplyConfiguration{}
+}
+
+// WithGUID sets the GUID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GUID field is set to the value of the last call.
+func (b *TaskSpecApplyConfiguration) WithGUID(value string) *TaskSpecApplyConfiguration {
+	b.GUID = &value
+	return b
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *TaskSpecApplyConfiguration) WithName(value string) *TaskSpecApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithImage sets the Image field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Image field is set to the value of the last call.
+func (b *TaskSpecApplyConfiguration) WithImage(value string) *TaskSpecApplyConfiguration {
+	b.Image = &value
+	return b
+}
+
+// WithImagePullSecrets adds the given value to the ImagePullSecrets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ImagePullSecrets field.
+func (b *TaskSpecApplyConfiguration) WithImagePullSecrets(values ...v1.LocalObjectReference) *TaskSpecApplyConfiguration {
+	for i := range values {
+		b.ImagePullSecrets = append(b.ImagePullSecrets, values[i])
+	}
+	return b
+}
+
+// WithEnvironment adds the given value to the Environment field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Environment field.
+func (b *TaskSpecApplyConfiguration) WithEnvironment(values ...v1.EnvVar) *TaskSpecApplyConfiguration {
+	for i := range values {
+		b.Environment = append(b.Environment, values[i])
+	}
+	return b
+}
+
+// WithCommand adds the given value to the Command field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Command field.
+func (b *TaskSpecApplyConfiguration) WithCommand(values ...string) *TaskSpecApplyConfiguration {
+	for i := range values {
+		b.Command = append(b.Command, values[i])
+	}
+	return b
+}
+
+// WithAppName sets the AppName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AppName field is set to the value of the last call.
+func (b *TaskSpecApplyConfiguration) WithAppName(value string) *TaskSpecApplyConfiguration {
+	b.AppName = &value
+	return b
+}
+
+// WithAppGUID sets the AppGUID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AppGUID field is set to the value of the last call.
+func (b *TaskSpecApplyConfiguration) WithAppGUID(value string) *TaskSpecApplyConfiguration {
+	b.AppGUID = &value
+	return b
+}
+
+// WithOrgName sets the OrgName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OrgName field is set to the value of the last call.
+func (b *TaskSpecApplyConfiguration) WithOrgName(value string) *TaskSpecApplyConfiguration {
+	b.OrgName = &value
+	return b
+}
+
+// WithOrgGUID sets the OrgGUID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OrgGUID field is set to the value of the last call.
+func (b *TaskSpecApplyConfiguration) WithOrgGUID(value string) *TaskSpecApplyConfiguration {
+	b.OrgGUID = &value
+	return b
+}
+
+// WithSpaceName sets the SpaceName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SpaceName field is set to the value of the last call.
+func (b *TaskSpecApplyConfiguration) WithSpaceName(value string) *TaskSpecApplyConfiguration {
+	b.SpaceName = &value
+	return b
+}
+
+// WithSpaceGUID sets the SpaceGUID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SpaceGUID field is set to the value of the last call.
+func (b *TaskSpecApplyConfiguration) WithSpaceGUID(value string) *TaskSpecApplyConfiguration {
+	b.SpaceGUID = &value
+	return b
+}
+
+// WithMemory sets the Memory field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Memory field is set to the value of the last call.
+func (b *TaskSpecApplyConfiguration) WithMemory(value resource.Quantity) *TaskSpecApplyConfiguration {
+	b.Memory = &value
+	return b
+}
+
+// WithDisk sets the Disk field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Disk field is set to the value of the last call.
+func (b *TaskSpecApplyConfiguration) WithDisk(value resource.Quantity) *TaskSpecApplyConfiguration {
+	b.Disk = &value
+	return b
+}
+
+// WithCPUMillis sets the CPUMillis field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CPUMillis field is set to the value of the last call.
+func (b *TaskSpecApplyConfiguration) WithCPUMillis(value int64) *TaskSpecApplyConfiguration {
+	b.CPUMillis = &value
+	return b
+}
+
+// WithPlacementTags adds the given value to the PlacementTags field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PlacementTags field.
+func (b *TaskSpecApplyConfiguration) WithPlacementTags(values ...string) *TaskSpecApplyConfiguration {
+	for i := range values {
+		b.PlacementTags = append(b.PlacementTags, values[i])
+	}
+	return b
+}
+
+// WithServiceAccountName sets the ServiceAccountName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServiceAccountName field is set to the value of the last call.
+func (b *TaskSpecApplyConfiguration) WithServiceAccountName(value string) *TaskSpecApplyConfiguration {
+	b.ServiceAccountName = &value
+	return b
+}
@@ -0,0 +1,48 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2
+
+// VolumeMountApplyConfiguration represents an declarative configuration of the VolumeMount type for use
+// with apply.
+type VolumeMountApplyConfiguration struct {
+	MountPath *string `json:"mountPath,omitempty"`
+	ClaimName *string `json:"claimName,omitempty"`
+}
+
+// VolumeMountApplyConfiguration constructs an declarative configuration of the VolumeMount type for use with
+// apply.
+func VolumeMount() *VolumeMountApplyConfiguration {
+	return &VolumeMountApplyConfiguration{}
+}
+
+// WithMountPath sets the MountPath field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MountPath field is set to the value of the last call.
+func (b *VolumeMountApplyConfiguration) WithMountPath(value string) *VolumeMountApplyConfiguration {
+	b.MountPath = &value
+	return b
+}
+
+// WithClaimName sets the ClaimName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ClaimName field is set to the value of the last call.
+func (b *VolumeMountApplyConfiguration) WithClaimName(value string) *VolumeMountApplyConfiguration {
+	b.ClaimName = &value
+	return b
+}
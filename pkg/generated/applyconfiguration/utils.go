@@ -0,0 +1,93 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package applyconfiguration
+
+import (
+	v1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	v2 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v2"
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/generated/applyconfiguration/eirini/v1"
+	eiriniv2 "code.cloudfoundry.org/eirini-controller/pkg/generated/applyconfiguration/eirini/v2"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ForKind returns an apply configuration type for the given GroupVersionKind, or nil if no
+// apply configuration type exists for the given GroupVersionKind.
+func ForKind(kind schema.GroupVersionKind) interface{} {
+	switch kind {
+	// Group=eirini.cloudfoundry.org, Version=v1
+	case v1.SchemeGroupVersion.WithKind("DisruptionBudget"):
+		return &eiriniv1.DisruptionBudgetApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("Healthcheck"):
+		return &eiriniv1.HealthcheckApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("LRP"):
+		return &eiriniv1.LRPApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("LRPInstanceStatus"):
+		return &eiriniv1.LRPInstanceStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("LRPSpec"):
+		return &eiriniv1.LRPSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("LRPStatus"):
+		return &eiriniv1.LRPStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("PrivateRegistry"):
+		return &eiriniv1.PrivateRegistryApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ResourceDefaults"):
+		return &eiriniv1.ResourceDefaultsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("SecurityProfile"):
+		return &eiriniv1.SecurityProfileApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("Sidecar"):
+		return &eiriniv1.SidecarApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("Task"):
+		return &eiriniv1.TaskApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("TaskSpec"):
+		return &eiriniv1.TaskSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("TaskStatus"):
+		return &eiriniv1.TaskStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("VolumeMount"):
+		return &eiriniv1.VolumeMountApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("WorkloadDefaults"):
+		return &eiriniv1.WorkloadDefaultsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("WorkloadDefaultsSpec"):
+		return &eiriniv1.WorkloadDefaultsSpecApplyConfiguration{}
+
+		// Group=eirini.cloudfoundry.org, Version=v2
+	case v2.SchemeGroupVersion.WithKind("DisruptionBudget"):
+		return &eiriniv2.DisruptionBudgetApplyConfiguration{}
+	case v2.SchemeGroupVersion.WithKind("Healthcheck"):
+		return &eiriniv2.HealthcheckApplyConfiguration{}
+	case v2.SchemeGroupVersion.WithKind("LRP"):
+		return &eiriniv2.LRPApplyConfiguration{}
+	case v2.SchemeGroupVersion.WithKind("LRPInstanceStatus"):
+		return &eiriniv2.LRPInstanceStatusApplyConfiguration{}
+	case v2.SchemeGroupVersion.WithKind("LRPSpec"):
+		return &eiriniv2.LRPSpecApplyConfiguration{}
+	case v2.SchemeGroupVersion.WithKind("LRPStatus"):
+		return &eiriniv2.LRPStatusApplyConfiguration{}
+	case v2.SchemeGroupVersion.WithKind("Sidecar"):
+		return &eiriniv2.SidecarApplyConfiguration{}
+	case v2.SchemeGroupVersion.WithKind("Task"):
+		return &eiriniv2.TaskApplyConfiguration{}
+	case v2.SchemeGroupVersion.WithKind("TaskSpec"):
+		return &eiriniv2.TaskSpecApplyConfiguration{}
+	case v2.SchemeGroupVersion.WithKind("TaskStatus"):
+		return &eiriniv2.TaskStatusApplyConfiguration{}
+	case v2.SchemeGroupVersion.WithKind("VolumeMount"):
+		return &eiriniv2.VolumeMountApplyConfiguration{}
+
+	}
+	return nil
+}
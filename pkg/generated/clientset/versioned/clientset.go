@@ -23,6 +23,7 @@ import (
 	"net/http"
 
 	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/generated/clientset/versioned/typed/eirini/v1"
+	eiriniv2 "code.cloudfoundry.org/eirini-controller/pkg/generated/clientset/versioned/typed/eirini/v2"
 	discovery "k8s.io/client-go/discovery"
 	rest "k8s.io/client-go/rest"
 	flowcontrol "k8s.io/client-go/util/flowcontrol"
@@ -31,6 +32,7 @@ import (
 type Interface interface {
 	Discovery() discovery.DiscoveryInterface
 	EiriniV1() eiriniv1.EiriniV1Interface
+	EiriniV2() eiriniv2.EiriniV2Interface
 }
 
 // Clientset contains the clients for groups. Each group has exactly one
@@ -38,6 +40,7 @@ type Interface interface {
 type Clientset struct {
 	*discovery.DiscoveryClient
 	eiriniV1 *eiriniv1.EiriniV1Client
+	eiriniV2 *eiriniv2.EiriniV2Client
 }
 
 // EiriniV1 retrieves the EiriniV1Client
@@ -45,6 +48,11 @@ func (c *Clientset) EiriniV1() eiriniv1.EiriniV1Interface {
 	return c.eiriniV1
 }
 
+// EiriniV2 retrieves the EiriniV2Client
+func (c *Clientset) EiriniV2() eiriniv2.EiriniV2Interface {
+	return c.eiriniV2
+}
+
 // Discovery retrieves the DiscoveryClient
 func (c *Clientset) Discovery() discovery.DiscoveryInterface {
 	if c == nil {
@@ -93,6 +101,10 @@ func NewForConfigAndClient(c *rest.Config, httpClient *http.Client) (*Clientset,
 	if err != nil {
 		return nil, err
 	}
+	cs.eiriniV2, err = eiriniv2.NewForConfigAndClient(&configShallowCopy, httpClient)
+	if err != nil {
+		return nil, err
+	}
 
 	cs.DiscoveryClient, err = discovery.NewDiscoveryClientForConfigAndClient(&configShallowCopy, httpClient)
 	if err != nil {
@@ -115,6 +127,7 @@ func NewForConfigOrDie(c *rest.Config) *Clientset {
 func New(c rest.Interface) *Clientset {
 	var cs Clientset
 	cs.eiriniV1 = eiriniv1.New(c)
+	cs.eiriniV2 = eiriniv2.New(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
 	return &cs
@@ -22,6 +22,8 @@ import (
 	clientset "code.cloudfoundry.org/eirini-controller/pkg/generated/clientset/versioned"
 	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/generated/clientset/versioned/typed/eirini/v1"
 	fakeeiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/generated/clientset/versioned/typed/eirini/v1/fake"
+	eiriniv2 "code.cloudfoundry.org/eirini-controller/pkg/generated/clientset/versioned/typed/eirini/v2"
+	fakeeiriniv2 "code.cloudfoundry.org/eirini-controller/pkg/generated/clientset/versioned/typed/eirini/v2/fake"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/discovery"
@@ -83,3 +85,8 @@ var (
 func (c *Clientset) EiriniV1() eiriniv1.EiriniV1Interface {
 	return &fakeeiriniv1.FakeEiriniV1{Fake: &c.Fake}
 }
+
+// EiriniV2 retrieves the EiriniV2Client
+func (c *Clientset) EiriniV2() eiriniv2.EiriniV2Interface {
+	return &fakeeiriniv2.FakeEiriniV2{Fake: &c.Fake}
+}
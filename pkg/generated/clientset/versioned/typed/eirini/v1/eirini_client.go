@@ -30,6 +30,7 @@ type EiriniV1Interface interface {
 	RESTClient() rest.Interface
 	LRPsGetter
 	TasksGetter
+	WorkloadDefaultsesGetter
 }
 
 // EiriniV1Client is used to interact with features provided by the eirini.cloudfoundry.org group.
@@ -45,6 +46,10 @@ func (c *EiriniV1Client) Tasks(namespace string) TaskInterface {
 	return newTasks(c, namespace)
 }
 
+func (c *EiriniV1Client) WorkloadDefaultses(namespace string) WorkloadDefaultsInterface {
+	return newWorkloadDefaultses(c, namespace)
+}
+
 // NewForConfig creates a new EiriniV1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
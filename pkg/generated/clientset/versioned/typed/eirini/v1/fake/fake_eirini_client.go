@@ -36,6 +36,10 @@ func (c *FakeEiriniV1) Tasks(namespace string) v1.TaskInterface {
 	return &FakeTasks{c, namespace}
 }
 
+func (c *FakeEiriniV1) WorkloadDefaultses(namespace string) v1.WorkloadDefaultsInterface {
+	return &FakeWorkloadDefaultses{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeEiriniV1) RESTClient() rest.Interface {
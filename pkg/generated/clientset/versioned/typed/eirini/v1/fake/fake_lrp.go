@@ -20,8 +20,11 @@ package fake
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 
 	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	applyconfigurationeiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/generated/applyconfiguration/eirini/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
@@ -140,3 +143,48 @@ func (c *FakeLRPs) Patch(ctx context.Context, name string, pt types.PatchType, d
 	}
 	return obj.(*eiriniv1.LRP), err
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied lRP.
+func (c *FakeLRPs) Apply(ctx context.Context, lRP *applyconfigurationeiriniv1.LRPApplyConfiguration, opts v1.ApplyOptions) (result *eiriniv1.LRP, err error) {
+	if lRP == nil {
+		return nil, fmt.Errorf("lRP provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(lRP)
+	if err != nil {
+		return nil, err
+	}
+	name := lRP.Name
+	if name == nil {
+		return nil, fmt.Errorf("lRP.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(lrpsResource, c.ns, *name, types.ApplyPatchType, data), &eiriniv1.LRP{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*eiriniv1.LRP), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeLRPs) ApplyStatus(ctx context.Context, lRP *applyconfigurationeiriniv1.LRPApplyConfiguration, opts v1.ApplyOptions) (result *eiriniv1.LRP, err error) {
+	if lRP == nil {
+		return nil, fmt.Errorf("lRP provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(lRP)
+	if err != nil {
+		return nil, err
+	}
+	name := lRP.Name
+	if name == nil {
+		return nil, fmt.Errorf("lRP.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(lrpsResource, c.ns, *name, types.ApplyPatchType, data, "status"), &eiriniv1.LRP{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*eiriniv1.LRP), err
+}
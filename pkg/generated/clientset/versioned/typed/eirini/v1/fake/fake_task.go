@@ -20,8 +20,11 @@ package fake
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 
 	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	applyconfigurationeiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/generated/applyconfiguration/eirini/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
@@ -140,3 +143,48 @@ func (c *FakeTasks) Patch(ctx context.Context, name string, pt types.PatchType,
 	}
 	return obj.(*eiriniv1.Task), err
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied task.
+func (c *FakeTasks) Apply(ctx context.Context, task *applyconfigurationeiriniv1.TaskApplyConfiguration, opts v1.ApplyOptions) (result *eiriniv1.Task, err error) {
+	if task == nil {
+		return nil, fmt.Errorf("task provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(task)
+	if err != nil {
+		return nil, err
+	}
+	name := task.Name
+	if name == nil {
+		return nil, fmt.Errorf("task.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(tasksResource, c.ns, *name, types.ApplyPatchType, data), &eiriniv1.Task{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*eiriniv1.Task), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeTasks) ApplyStatus(ctx context.Context, task *applyconfigurationeiriniv1.TaskApplyConfiguration, opts v1.ApplyOptions) (result *eiriniv1.Task, err error) {
+	if task == nil {
+		return nil, fmt.Errorf("task provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(task)
+	if err != nil {
+		return nil, err
+	}
+	name := task.Name
+	if name == nil {
+		return nil, fmt.Errorf("task.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(tasksResource, c.ns, *name, types.ApplyPatchType, data, "status"), &eiriniv1.Task{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*eiriniv1.Task), err
+}
@@ -0,0 +1,155 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	applyconfigurationeiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/generated/applyconfiguration/eirini/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeWorkloadDefaultses implements WorkloadDefaultsInterface
+type FakeWorkloadDefaultses struct {
+	Fake *FakeEiriniV1
+	ns   string
+}
+
+var workloaddefaultsesResource = schema.GroupVersionResource{Group: "eirini.cloudfoundry.org", Version: "v1", Resource: "workloaddefaultses"}
+
+var workloaddefaultsesKind = schema.GroupVersionKind{Group: "eirini.cloudfoundry.org", Version: "v1", Kind: "WorkloadDefaults"}
+
+// Get takes name of the workloadDefaults, and returns the corresponding workloadDefaults object, and an error if there is any.
+func (c *FakeWorkloadDefaultses) Get(ctx context.Context, name string, options v1.GetOptions) (result *eiriniv1.WorkloadDefaults, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(workloaddefaultsesResource, c.ns, name), &eiriniv1.WorkloadDefaults{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*eiriniv1.WorkloadDefaults), err
+}
+
+// List takes label and field selectors, and returns the list of WorkloadDefaultses that match those selectors.
+func (c *FakeWorkloadDefaultses) List(ctx context.Context, opts v1.ListOptions) (result *eiriniv1.WorkloadDefaultsList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(workloaddefaultsesResource, workloaddefaultsesKind, c.ns, opts), &eiriniv1.WorkloadDefaultsList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &eiriniv1.WorkloadDefaultsList{ListMeta: obj.(*eiriniv1.WorkloadDefaultsList).ListMeta}
+	for _, item := range obj.(*eiriniv1.WorkloadDefaultsList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested workloadDefaultses.
+func (c *FakeWorkloadDefaultses) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(workloaddefaultsesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a workloadDefaults and creates it.  Returns the server's representation of the workloadDefaults, and an error, if there is any.
+func (c *FakeWorkloadDefaultses) Create(ctx context.Context, workloadDefaults *eiriniv1.WorkloadDefaults, opts v1.CreateOptions) (result *eiriniv1.WorkloadDefaults, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(workloaddefaultsesResource, c.ns, workloadDefaults), &eiriniv1.WorkloadDefaults{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*eiriniv1.WorkloadDefaults), err
+}
+
+// Update takes the representation of a workloadDefaults and updates it. Returns the server's representation of the workloadDefaults, and an error, if there is any.
+func (c *FakeWorkloadDefaultses) Update(ctx context.Context, workloadDefaults *eiriniv1.WorkloadDefaults, opts v1.UpdateOptions) (result *eiriniv1.WorkloadDefaults, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(workloaddefaultsesResource, c.ns, workloadDefaults), &eiriniv1.WorkloadDefaults{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*eiriniv1.WorkloadDefaults), err
+}
+
+// Delete takes name of the workloadDefaults and deletes it. Returns an error if one occurs.
+func (c *FakeWorkloadDefaultses) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(workloaddefaultsesResource, c.ns, name, opts), &eiriniv1.WorkloadDefaults{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeWorkloadDefaultses) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(workloaddefaultsesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &eiriniv1.WorkloadDefaultsList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched workloadDefaults.
+func (c *FakeWorkloadDefaultses) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *eiriniv1.WorkloadDefaults, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(workloaddefaultsesResource, c.ns, name, pt, data, subresources...), &eiriniv1.WorkloadDefaults{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*eiriniv1.WorkloadDefaults), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied workloadDefaults.
+func (c *FakeWorkloadDefaultses) Apply(ctx context.Context, workloadDefaults *applyconfigurationeiriniv1.WorkloadDefaultsApplyConfiguration, opts v1.ApplyOptions) (result *eiriniv1.WorkloadDefaults, err error) {
+	if workloadDefaults == nil {
+		return nil, fmt.Errorf("workloadDefaults provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(workloadDefaults)
+	if err != nil {
+		return nil, err
+	}
+	name := workloadDefaults.Name
+	if name == nil {
+		return nil, fmt.Errorf("workloadDefaults.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(workloaddefaultsesResource, c.ns, *name, types.ApplyPatchType, data), &eiriniv1.WorkloadDefaults{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*eiriniv1.WorkloadDefaults), err
+}
@@ -21,3 +21,5 @@ package v1
 type LRPExpansion interface{}
 
 type TaskExpansion interface{}
+
+type WorkloadDefaultsExpansion interface{}
@@ -20,9 +20,12 @@ package v1
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 	"time"
 
 	v1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/generated/applyconfiguration/eirini/v1"
 	scheme "code.cloudfoundry.org/eirini-controller/pkg/generated/clientset/versioned/scheme"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
@@ -47,6 +50,8 @@ type LRPInterface interface {
 	List(ctx context.Context, opts metav1.ListOptions) (*v1.LRPList, error)
 	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.LRP, err error)
+	Apply(ctx context.Context, lRP *eiriniv1.LRPApplyConfiguration, opts metav1.ApplyOptions) (result *v1.LRP, err error)
+	ApplyStatus(ctx context.Context, lRP *eiriniv1.LRPApplyConfiguration, opts metav1.ApplyOptions) (result *v1.LRP, err error)
 	LRPExpansion
 }
 
@@ -193,3 +198,59 @@ func (c *lRPs) Patch(ctx context.Context, name string, pt types.PatchType, data
 		Into(result)
 	return
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied lRP.
+func (c *lRPs) Apply(ctx context.Context, lRP *eiriniv1.LRPApplyConfiguration, opts metav1.ApplyOptions) (result *v1.LRP, err error) {
+	if lRP == nil {
+		return nil, fmt.Errorf("lRP provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(lRP)
+	if err != nil {
+		return nil, err
+	}
+	name := lRP.Name
+	if name == nil {
+		return nil, fmt.Errorf("lRP.Name must be provided to Apply")
+	}
+	result = &v1.LRP{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("lrps").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *lRPs) ApplyStatus(ctx context.Context, lRP *eiriniv1.LRPApplyConfiguration, opts metav1.ApplyOptions) (result *v1.LRP, err error) {
+	if lRP == nil {
+		return nil, fmt.Errorf("lRP provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(lRP)
+	if err != nil {
+		return nil, err
+	}
+
+	name := lRP.Name
+	if name == nil {
+		return nil, fmt.Errorf("lRP.Name must be provided to Apply")
+	}
+
+	result = &v1.LRP{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("lrps").
+		Name(*name).
+		SubResource("status").
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -20,9 +20,12 @@ package v1
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 	"time"
 
 	v1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/generated/applyconfiguration/eirini/v1"
 	scheme "code.cloudfoundry.org/eirini-controller/pkg/generated/clientset/versioned/scheme"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
@@ -47,6 +50,8 @@ type TaskInterface interface {
 	List(ctx context.Context, opts metav1.ListOptions) (*v1.TaskList, error)
 	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.Task, err error)
+	Apply(ctx context.Context, task *eiriniv1.TaskApplyConfiguration, opts metav1.ApplyOptions) (result *v1.Task, err error)
+	ApplyStatus(ctx context.Context, task *eiriniv1.TaskApplyConfiguration, opts metav1.ApplyOptions) (result *v1.Task, err error)
 	TaskExpansion
 }
 
@@ -193,3 +198,59 @@ func (c *tasks) Patch(ctx context.Context, name string, pt types.PatchType, data
 		Into(result)
 	return
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied task.
+func (c *tasks) Apply(ctx context.Context, task *eiriniv1.TaskApplyConfiguration, opts metav1.ApplyOptions) (result *v1.Task, err error) {
+	if task == nil {
+		return nil, fmt.Errorf("task provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(task)
+	if err != nil {
+		return nil, err
+	}
+	name := task.Name
+	if name == nil {
+		return nil, fmt.Errorf("task.Name must be provided to Apply")
+	}
+	result = &v1.Task{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("tasks").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *tasks) ApplyStatus(ctx context.Context, task *eiriniv1.TaskApplyConfiguration, opts metav1.ApplyOptions) (result *v1.Task, err error) {
+	if task == nil {
+		return nil, fmt.Errorf("task provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(task)
+	if err != nil {
+		return nil, err
+	}
+
+	name := task.Name
+	if name == nil {
+		return nil, fmt.Errorf("task.Name must be provided to Apply")
+	}
+
+	result = &v1.Task{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("tasks").
+		Name(*name).
+		SubResource("status").
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,208 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+	"time"
+
+	v1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/generated/applyconfiguration/eirini/v1"
+	scheme "code.cloudfoundry.org/eirini-controller/pkg/generated/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// WorkloadDefaultsesGetter has a method to return a WorkloadDefaultsInterface.
+// A group's client should implement this interface.
+type WorkloadDefaultsesGetter interface {
+	WorkloadDefaultses(namespace string) WorkloadDefaultsInterface
+}
+
+// WorkloadDefaultsInterface has methods to work with WorkloadDefaults resources.
+type WorkloadDefaultsInterface interface {
+	Create(ctx context.Context, workloadDefaults *v1.WorkloadDefaults, opts metav1.CreateOptions) (*v1.WorkloadDefaults, error)
+	Update(ctx context.Context, workloadDefaults *v1.WorkloadDefaults, opts metav1.UpdateOptions) (*v1.WorkloadDefaults, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.WorkloadDefaults, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.WorkloadDefaultsList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.WorkloadDefaults, err error)
+	Apply(ctx context.Context, workloadDefaults *eiriniv1.WorkloadDefaultsApplyConfiguration, opts metav1.ApplyOptions) (result *v1.WorkloadDefaults, err error)
+	WorkloadDefaultsExpansion
+}
+
+// workloadDefaultses implements WorkloadDefaultsInterface
+type workloadDefaultses struct {
+	client rest.Interface
+	ns     string
+}
+
+// newWorkloadDefaultses returns a WorkloadDefaultses
+func newWorkloadDefaultses(c *EiriniV1Client, namespace string) *workloadDefaultses {
+	return &workloadDefaultses{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the workloadDefaults, and returns the corresponding workloadDefaults object, and an error if there is any.
+func (c *workloadDefaultses) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.WorkloadDefaults, err error) {
+	result = &v1.WorkloadDefaults{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("workloaddefaultses").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of WorkloadDefaultses that match those selectors.
+func (c *workloadDefaultses) List(ctx context.Context, opts metav1.ListOptions) (result *v1.WorkloadDefaultsList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.WorkloadDefaultsList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("workloaddefaultses").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested workloadDefaultses.
+func (c *workloadDefaultses) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("workloaddefaultses").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a workloadDefaults and creates it.  Returns the server's representation of the workloadDefaults, and an error, if there is any.
+func (c *workloadDefaultses) Create(ctx context.Context, workloadDefaults *v1.WorkloadDefaults, opts metav1.CreateOptions) (result *v1.WorkloadDefaults, err error) {
+	result = &v1.WorkloadDefaults{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("workloaddefaultses").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(workloadDefaults).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a workloadDefaults and updates it. Returns the server's representation of the workloadDefaults, and an error, if there is any.
+func (c *workloadDefaultses) Update(ctx context.Context, workloadDefaults *v1.WorkloadDefaults, opts metav1.UpdateOptions) (result *v1.WorkloadDefaults, err error) {
+	result = &v1.WorkloadDefaults{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("workloaddefaultses").
+		Name(workloadDefaults.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(workloadDefaults).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the workloadDefaults and deletes it. Returns an error if one occurs.
+func (c *workloadDefaultses) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("workloaddefaultses").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *workloadDefaultses) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("workloaddefaultses").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched workloadDefaults.
+func (c *workloadDefaultses) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.WorkloadDefaults, err error) {
+	result = &v1.WorkloadDefaults{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("workloaddefaultses").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied workloadDefaults.
+func (c *workloadDefaultses) Apply(ctx context.Context, workloadDefaults *eiriniv1.WorkloadDefaultsApplyConfiguration, opts metav1.ApplyOptions) (result *v1.WorkloadDefaults, err error) {
+	if workloadDefaults == nil {
+		return nil, fmt.Errorf("workloadDefaults provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(workloadDefaults)
+	if err != nil {
+		return nil, err
+	}
+	name := workloadDefaults.Name
+	if name == nil {
+		return nil, fmt.Errorf("workloadDefaults.Name must be provided to Apply")
+	}
+	result = &v1.WorkloadDefaults{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("workloaddefaultses").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,190 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+
+	v2 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v2"
+	eiriniv2 "code.cloudfoundry.org/eirini-controller/pkg/generated/applyconfiguration/eirini/v2"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeLRPs implements LRPInterface
+type FakeLRPs struct {
+	Fake *FakeEiriniV2
+	ns   string
+}
+
+var lrpsResource = schema.GroupVersionResource{Group: "eirini.cloudfoundry.org", Version: "v2", Resource: "lrps"}
+
+var lrpsKind = schema.GroupVersionKind{Group: "eirini.cloudfoundry.org", Version: "v2", Kind: "LRP"}
+
+// Get takes name of the lRP, and returns the corresponding lRP object, and an error if there is any.
+func (c *FakeLRPs) Get(ctx context.Context, name string, options v1.GetOptions) (result *v2.LRP, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(lrpsResource, c.ns, name), &v2.LRP{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2.LRP), err
+}
+
+// List takes label and field selectors, and returns the list of LRPs that match those selectors.
+func (c *FakeLRPs) List(ctx context.Context, opts v1.ListOptions) (result *v2.LRPList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(lrpsResource, lrpsKind, c.ns, opts), &v2.LRPList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v2.LRPList{ListMeta: obj.(*v2.LRPList).ListMeta}
+	for _, item := range obj.(*v2.LRPList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested lRPs.
+func (c *FakeLRPs) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(lrpsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a lRP and creates it.  Returns the server's representation of the lRP, and an error, if there is any.
+func (c *FakeLRPs) Create(ctx context.Context, lRP *v2.LRP, opts v1.CreateOptions) (result *v2.LRP, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(lrpsResource, c.ns, lRP), &v2.LRP{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2.LRP), err
+}
+
+// Update takes the representation of a lRP and updates it. Returns the server's representation of the lRP, and an error, if there is any.
+func (c *FakeLRPs) Update(ctx context.Context, lRP *v2.LRP, opts v1.UpdateOptions) (result *v2.LRP, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(lrpsResource, c.ns, lRP), &v2.LRP{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2.LRP), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeLRPs) UpdateStatus(ctx context.Context, lRP *v2.LRP, opts v1.UpdateOptions) (*v2.LRP, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(lrpsResource, "status", c.ns, lRP), &v2.LRP{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2.LRP), err
+}
+
+// Delete takes name of the lRP and deletes it. Returns an error if one occurs.
+func (c *FakeLRPs) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(lrpsResource, c.ns, name, opts), &v2.LRP{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeLRPs) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(lrpsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v2.LRPList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched lRP.
+func (c *FakeLRPs) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2.LRP, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(lrpsResource, c.ns, name, pt, data, subresources...), &v2.LRP{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2.LRP), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied lRP.
+func (c *FakeLRPs) Apply(ctx context.Context, lRP *eiriniv2.LRPApplyConfiguration, opts v1.ApplyOptions) (result *v2.LRP, err error) {
+	if lRP == nil {
+		return nil, fmt.Errorf("lRP provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(lRP)
+	if err != nil {
+		return nil, err
+	}
+	name := lRP.Name
+	if name == nil {
+		return nil, fmt.Errorf("lRP.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(lrpsResource, c.ns, *name, types.ApplyPatchType, data), &v2.LRP{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2.LRP), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeLRPs) ApplyStatus(ctx context.Context, lRP *eiriniv2.LRPApplyConfiguration, opts v1.ApplyOptions) (result *v2.LRP, err error) {
+	if lRP == nil {
+		return nil, fmt.Errorf("lRP provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(lRP)
+	if err != nil {
+		return nil, err
+	}
+	name := lRP.Name
+	if name == nil {
+		return nil, fmt.Errorf("lRP.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(lrpsResource, c.ns, *name, types.ApplyPatchType, data, "status"), &v2.LRP{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2.LRP), err
+}
@@ -0,0 +1,256 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v2
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+	"time"
+
+	v2 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v2"
+	eiriniv2 "code.cloudfoundry.org/eirini-controller/pkg/generated/applyconfiguration/eirini/v2"
+	scheme "code.cloudfoundry.org/eirini-controller/pkg/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// LRPsGetter has a method to return a LRPInterface.
+// A group's client should implement this interface.
+type LRPsGetter interface {
+	LRPs(namespace string) LRPInterface
+}
+
+// LRPInterface has methods to work with LRP resources.
+type LRPInterface interface {
+	Create(ctx context.Context, lRP *v2.LRP, opts v1.CreateOptions) (*v2.LRP, error)
+	Update(ctx context.Context, lRP *v2.LRP, opts v1.UpdateOptions) (*v2.LRP, error)
+	UpdateStatus(ctx context.Context, lRP *v2.LRP, opts v1.UpdateOptions) (*v2.LRP, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v2.LRP, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v2.LRPList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2.LRP, err error)
+	Apply(ctx context.Context, lRP *eiriniv2.LRPApplyConfiguration, opts v1.ApplyOptions) (result *v2.LRP, err error)
+	ApplyStatus(ctx context.Context, lRP *eiriniv2.LRPApplyConfiguration, opts v1.ApplyOptions) (result *v2.LRP, err error)
+	LRPExpansion
+}
+
+// lRPs implements LRPInterface
+type lRPs struct {
+	client rest.Interface
+	ns     string
+}
+
+// newLRPs returns a LRPs
+func newLRPs(c *EiriniV2Client, namespace string) *lRPs {
+	return &lRPs{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the lRP, and returns the corresponding lRP object, and an error if there is any.
+func (c *lRPs) Get(ctx context.Context, name string, options v1.GetOptions) (result *v2.LRP, err error) {
+	result = &v2.LRP{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("lrps").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of LRPs that match those selectors.
+func (c *lRPs) List(ctx context.Context, opts v1.ListOptions) (result *v2.LRPList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v2.LRPList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("lrps").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested lRPs.
+func (c *lRPs) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("lrps").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a lRP and creates it.  Returns the server's representation of the lRP, and an error, if there is any.
+func (c *lRPs) Create(ctx context.Context, lRP *v2.LRP, opts v1.CreateOptions) (result *v2.LRP, err error) {
+	result = &v2.LRP{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("lrps").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(lRP).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a lRP and updates it. Returns the server's representation of the lRP, and an error, if there is any.
+func (c *lRPs) Update(ctx context.Context, lRP *v2.LRP, opts v1.UpdateOptions) (result *v2.LRP, err error) {
+	result = &v2.LRP{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("lrps").
+		Name(lRP.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(lRP).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *lRPs) UpdateStatus(ctx context.Context, lRP *v2.LRP, opts v1.UpdateOptions) (result *v2.LRP, err error) {
+	result = &v2.LRP{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("lrps").
+		Name(lRP.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(lRP).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the lRP and deletes it. Returns an error if one occurs.
+func (c *lRPs) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("lrps").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *lRPs) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("lrps").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched lRP.
+func (c *lRPs) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2.LRP, err error) {
+	result = &v2.LRP{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("lrps").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied lRP.
+func (c *lRPs) Apply(ctx context.Context, lRP *eiriniv2.LRPApplyConfiguration, opts v1.ApplyOptions) (result *v2.LRP, err error) {
+	if lRP == nil {
+		return nil, fmt.Errorf("lRP provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(lRP)
+	if err != nil {
+		return nil, err
+	}
+	name := lRP.Name
+	if name == nil {
+		return nil, fmt.Errorf("lRP.Name must be provided to Apply")
+	}
+	result = &v2.LRP{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("lrps").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *lRPs) ApplyStatus(ctx context.Context, lRP *eiriniv2.LRPApplyConfiguration, opts v1.ApplyOptions) (result *v2.LRP, err error) {
+	if lRP == nil {
+		return nil, fmt.Errorf("lRP provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(lRP)
+	if err != nil {
+		return nil, err
+	}
+
+	name := lRP.Name
+	if name == nil {
+		return nil, fmt.Errorf("lRP.Name must be provided to Apply")
+	}
+
+	result = &v2.LRP{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("lrps").
+		Name(*name).
+		SubResource("status").
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
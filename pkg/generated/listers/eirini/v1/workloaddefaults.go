@@ -0,0 +1,99 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// WorkloadDefaultsLister helps list WorkloadDefaultses.
+// All objects returned here must be treated as read-only.
+type WorkloadDefaultsLister interface {
+	// List lists all WorkloadDefaultses in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.WorkloadDefaults, err error)
+	// WorkloadDefaultses returns an object that can list and get WorkloadDefaultses.
+	WorkloadDefaultses(namespace string) WorkloadDefaultsNamespaceLister
+	WorkloadDefaultsListerExpansion
+}
+
+// workloadDefaultsLister implements the WorkloadDefaultsLister interface.
+type workloadDefaultsLister struct {
+	indexer cache.Indexer
+}
+
+// NewWorkloadDefaultsLister returns a new WorkloadDefaultsLister.
+func NewWorkloadDefaultsLister(indexer cache.Indexer) WorkloadDefaultsLister {
+	return &workloadDefaultsLister{indexer: indexer}
+}
+
+// List lists all WorkloadDefaultses in the indexer.
+func (s *workloadDefaultsLister) List(selector labels.Selector) (ret []*v1.WorkloadDefaults, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.WorkloadDefaults))
+	})
+	return ret, err
+}
+
+// WorkloadDefaultses returns an object that can list and get WorkloadDefaultses.
+func (s *workloadDefaultsLister) WorkloadDefaultses(namespace string) WorkloadDefaultsNamespaceLister {
+	return workloadDefaultsNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// WorkloadDefaultsNamespaceLister helps list and get WorkloadDefaultses.
+// All objects returned here must be treated as read-only.
+type WorkloadDefaultsNamespaceLister interface {
+	// List lists all WorkloadDefaultses in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.WorkloadDefaults, err error)
+	// Get retrieves the WorkloadDefaults from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.WorkloadDefaults, error)
+	WorkloadDefaultsNamespaceListerExpansion
+}
+
+// workloadDefaultsNamespaceLister implements the WorkloadDefaultsNamespaceLister
+// interface.
+type workloadDefaultsNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all WorkloadDefaultses in the indexer for a given namespace.
+func (s workloadDefaultsNamespaceLister) List(selector labels.Selector) (ret []*v1.WorkloadDefaults, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.WorkloadDefaults))
+	})
+	return ret, err
+}
+
+// Get retrieves the WorkloadDefaults from the indexer for a given namespace and name.
+func (s workloadDefaultsNamespaceLister) Get(name string) (*v1.WorkloadDefaults, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("workloaddefaults"), name)
+	}
+	return obj.(*v1.WorkloadDefaults), nil
+}
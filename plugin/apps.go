@@ -0,0 +1,57 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Apps prints the LRPs of the namespace with their desired and ready
+// instances. An empty namespace lists the LRPs of all namespaces.
+func (i *Inspector) Apps(ctx context.Context, w io.Writer, namespace string) error {
+	lrps, err := i.eiriniClient.EiriniV1().LRPs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list LRPs")
+	}
+
+	if len(lrps.Items) == 0 {
+		fmt.Fprintf(w, "No LRPs found in %s.\n", namespaceDescription(namespace))
+
+		return nil
+	}
+
+	sortByNamespaceAndName(lrps.Items)
+
+	columns := []interface{}{"NAME", "APP", "VERSION", "DESIRED", "READY", "AGE"}
+	if namespace == "" {
+		columns = append([]interface{}{"NAMESPACE"}, columns...)
+	}
+
+	table := newTable(w, columns...)
+
+	for _, lrp := range lrps.Items {
+		row := []interface{}{lrp.Name, lrp.Spec.AppName, lrp.Spec.Version, lrp.Spec.Instances, lrp.Status.Replicas, i.age(lrp.CreationTimestamp)}
+		if namespace == "" {
+			row = append([]interface{}{lrp.Namespace}, row...)
+		}
+
+		printRow(table, row...)
+	}
+
+	return table.Flush()
+}
+
+func sortByNamespaceAndName(lrps []eiriniv1.LRP) {
+	sort.Slice(lrps, func(a, b int) bool {
+		if lrps[a].Namespace != lrps[b].Namespace {
+			return lrps[a].Namespace < lrps[b].Namespace
+		}
+
+		return lrps[a].Name < lrps[b].Name
+	})
+}
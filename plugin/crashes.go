@@ -0,0 +1,61 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"code.cloudfoundry.org/eirini-controller/k8s/reconciler"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+const lrpKind = "LRP"
+
+// Crashes prints the crash events the controller recorded for the instances
+// of the LRP, the most recent first.
+func (i *Inspector) Crashes(ctx context.Context, w io.Writer, namespace, lrpName string) error {
+	eventList, err := i.kubeClient.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.SelectorFromSet(fields.Set{
+			"involvedObject.kind": lrpKind,
+			"involvedObject.name": lrpName,
+		}).String(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list events")
+	}
+
+	events := []corev1.Event{}
+
+	for _, event := range eventList.Items {
+		if event.InvolvedObject.Kind == lrpKind && event.InvolvedObject.Name == lrpName {
+			events = append(events, event)
+		}
+	}
+
+	if len(events) == 0 {
+		fmt.Fprintf(w, "No crashes found for LRP %s in %s.\n", lrpName, namespaceDescription(namespace))
+
+		return nil
+	}
+
+	sort.SliceStable(events, func(a, b int) bool {
+		return events[b].LastTimestamp.Before(&events[a].LastTimestamp)
+	})
+
+	table := newTable(w, "INDEX", "REASON", "MESSAGE", "COUNT", "FIRST", "LAST")
+
+	for _, event := range events {
+		index, ok := event.Labels[reconciler.LabelInstanceIndex]
+		if !ok {
+			index = noValue
+		}
+
+		printRow(table, index, event.Reason, event.Message, event.Count, i.age(event.FirstTimestamp), i.age(event.LastTimestamp))
+	}
+
+	return table.Flush()
+}
@@ -0,0 +1,74 @@
+package plugin
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"code.cloudfoundry.org/eirini-controller/pkg/generated/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/clock"
+)
+
+const noValue = "-"
+
+// Inspector prints the LRPs and tasks of a cluster as kubectl tables.
+type Inspector struct {
+	eiriniClient versioned.Interface
+	kubeClient   kubernetes.Interface
+	clock        clock.PassiveClock
+}
+
+func NewInspector(eiriniClient versioned.Interface, kubeClient kubernetes.Interface, clock clock.PassiveClock) *Inspector {
+	return &Inspector{
+		eiriniClient: eiriniClient,
+		kubeClient:   kubeClient,
+		clock:        clock,
+	}
+}
+
+func (i *Inspector) age(t metav1.Time) string {
+	if t.IsZero() {
+		return noValue
+	}
+
+	return duration.HumanDuration(i.clock.Since(t.Time))
+}
+
+func newTable(w io.Writer, columns ...interface{}) *tabwriter.Writer {
+	table := tabwriter.NewWriter(w, 6, 4, 3, ' ', 0) // nolint:gomnd
+	printRow(table, columns...)
+
+	return table
+}
+
+func printRow(table io.Writer, values ...interface{}) {
+	for i, value := range values {
+		if i > 0 {
+			fmt.Fprint(table, "\t")
+		}
+
+		fmt.Fprint(table, value)
+	}
+
+	fmt.Fprintln(table)
+}
+
+func formatDuration(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+
+	return duration.HumanDuration(d)
+}
+
+func namespaceDescription(namespace string) string {
+	if namespace == "" {
+		return "any namespace"
+	}
+
+	return namespace + " namespace"
+}
@@ -0,0 +1,293 @@
+package plugin_test
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	eirinifake "code.cloudfoundry.org/eirini-controller/pkg/generated/clientset/versioned/fake"
+	"code.cloudfoundry.org/eirini-controller/plugin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clock "k8s.io/utils/clock/testing"
+)
+
+var _ = Describe("Inspector", func() {
+	var (
+		now         time.Time
+		eiriniObjs  []runtime.Object
+		kubeObjs    []runtime.Object
+		inspector   *plugin.Inspector
+		out         *bytes.Buffer
+		ctx         context.Context
+		inspectErr  error
+		minutesAgo  func(int) metav1.Time
+		newLRP      func(namespace, name string) *eiriniv1.LRP
+		newAppPod   func(name string) *corev1.Pod
+		newCrash    func(name, index string, count int32, last metav1.Time) *corev1.Event
+		taskWith    func(name string, conditions ...metav1.Condition) *eiriniv1.Task
+		conditionAt func(conditionType string, at metav1.Time) metav1.Condition
+	)
+
+	BeforeEach(func() {
+		now = time.Date(2022, 7, 1, 12, 0, 0, 0, time.UTC)
+		eiriniObjs = nil
+		kubeObjs = nil
+		out = &bytes.Buffer{}
+		ctx = context.Background()
+
+		minutesAgo = func(minutes int) metav1.Time {
+			return metav1.NewTime(now.Add(-time.Duration(minutes) * time.Minute))
+		}
+
+		newLRP = func(namespace, name string) *eiriniv1.LRP {
+			return &eiriniv1.LRP{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, CreationTimestamp: minutesAgo(90)},
+				Spec: eiriniv1.LRPSpec{
+					GUID:      name + "-guid",
+					Version:   "v1",
+					AppName:   name + "-app",
+					Instances: 2,
+				},
+				Status: eiriniv1.LRPStatus{Replicas: 1},
+			}
+		}
+
+		newAppPod = func(name string) *corev1.Pod {
+			started := true
+
+			return &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "space",
+					Name:      name,
+					Labels: map[string]string{
+						"korifi.cloudfoundry.org/guid":        "dora-guid",
+						"korifi.cloudfoundry.org/version":     "v1",
+						"korifi.cloudfoundry.org/source-type": "APP",
+					},
+				},
+				Spec: corev1.PodSpec{NodeName: "node-1"},
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name:    "opi",
+						Ready:   true,
+						Started: &started,
+						State:   corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+					}},
+				},
+			}
+		}
+
+		newCrash = func(name, index string, count int32, last metav1.Time) *corev1.Event {
+			return &corev1.Event{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "space",
+					Name:      name,
+					Labels:    map[string]string{"korifi.cloudfoundry.org/instance-index": index},
+				},
+				InvolvedObject: corev1.ObjectReference{Kind: "LRP", Name: "dora", Namespace: "space"},
+				Reason:         "Container: Error",
+				Message:        "Container terminated with exit code: 1",
+				Count:          count,
+				FirstTimestamp: minutesAgo(60),
+				LastTimestamp:  last,
+			}
+		}
+
+		taskWith = func(name string, conditions ...metav1.Condition) *eiriniv1.Task {
+			return &eiriniv1.Task{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "space", Name: name},
+				Status:     eiriniv1.TaskStatus{Conditions: conditions},
+			}
+		}
+
+		conditionAt = func(conditionType string, at metav1.Time) metav1.Condition {
+			return metav1.Condition{Type: conditionType, Status: metav1.ConditionTrue, LastTransitionTime: at}
+		}
+	})
+
+	JustBeforeEach(func() {
+		inspector = plugin.NewInspector(
+			eirinifake.NewSimpleClientset(eiriniObjs...),
+			kubefake.NewSimpleClientset(kubeObjs...),
+			clock.NewFakePassiveClock(now),
+		)
+	})
+
+	Describe("Apps", func() {
+		var namespace string
+
+		BeforeEach(func() {
+			namespace = "space"
+			eiriniObjs = []runtime.Object{newLRP("space", "dora"), newLRP("space", "catnip"), newLRP("other", "bob")}
+		})
+
+		JustBeforeEach(func() {
+			inspectErr = inspector.Apps(ctx, out, namespace)
+		})
+
+		It("prints the LRPs of the namespace sorted by name", func() {
+			Expect(inspectErr).NotTo(HaveOccurred())
+			Expect(out.String()).To(Equal(
+				"NAME     APP          VERSION   DESIRED   READY   AGE\n" +
+					"catnip   catnip-app   v1        2         1       90m\n" +
+					"dora     dora-app     v1        2         1       90m\n",
+			))
+		})
+
+		When("listing all namespaces", func() {
+			BeforeEach(func() {
+				namespace = ""
+			})
+
+			It("adds a namespace column", func() {
+				Expect(inspectErr).NotTo(HaveOccurred())
+				Expect(out.String()).To(Equal(
+					"NAMESPACE   NAME     APP          VERSION   DESIRED   READY   AGE\n" +
+						"other       bob      bob-app      v1        2         1       90m\n" +
+						"space       catnip   catnip-app   v1        2         1       90m\n" +
+						"space       dora     dora-app     v1        2         1       90m\n",
+				))
+			})
+		})
+
+		When("there are no LRPs", func() {
+			BeforeEach(func() {
+				namespace = "empty"
+			})
+
+			It("says so", func() {
+				Expect(inspectErr).NotTo(HaveOccurred())
+				Expect(out.String()).To(Equal("No LRPs found in empty namespace.\n"))
+			})
+		})
+	})
+
+	Describe("Instances", func() {
+		var lrpName string
+
+		BeforeEach(func() {
+			lrpName = "dora"
+
+			crashedPod := newAppPod("dora-1a2b3-1")
+			crashedPod.Status.Conditions = nil
+			crashedPod.Status.ContainerStatuses[0] = corev1.ContainerStatus{
+				Name:         "opi",
+				RestartCount: 3,
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+					Reason: "CrashLoopBackOff",
+				}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					Reason:     "Error",
+					ExitCode:   137,
+					FinishedAt: minutesAgo(5),
+				}},
+			}
+
+			otherApp := newAppPod("catnip-4c5d6-0")
+			otherApp.Labels["korifi.cloudfoundry.org/guid"] = "catnip-guid"
+
+			eiriniObjs = []runtime.Object{newLRP("space", "dora")}
+			kubeObjs = []runtime.Object{crashedPod, newAppPod("dora-1a2b3-0"), otherApp}
+		})
+
+		JustBeforeEach(func() {
+			inspectErr = inspector.Instances(ctx, out, "space", lrpName)
+		})
+
+		It("prints the instances of the LRP sorted by index", func() {
+			Expect(inspectErr).NotTo(HaveOccurred())
+			Expect(out.String()).To(Equal(
+				"INDEX   POD            STATE     NODE     RESTARTS   LAST CRASH\n" +
+					"0       dora-1a2b3-0   running   node-1   0          -\n" +
+					"1       dora-1a2b3-1   crashed   node-1   3          Error (exit 137) 5m ago\n",
+			))
+		})
+
+		When("the LRP does not exist", func() {
+			BeforeEach(func() {
+				lrpName = "missing"
+			})
+
+			It("fails", func() {
+				Expect(inspectErr).To(MatchError(ContainSubstring("failed to get LRP")))
+			})
+		})
+	})
+
+	Describe("Crashes", func() {
+		BeforeEach(func() {
+			otherLRPCrash := newCrash("catnip-crash", "0", 1, minutesAgo(1))
+			otherLRPCrash.InvolvedObject.Name = "catnip"
+
+			kubeObjs = []runtime.Object{
+				newCrash("dora-crash-0", "0", 2, minutesAgo(30)),
+				newCrash("dora-crash-1", "1", 5, minutesAgo(2)),
+				otherLRPCrash,
+			}
+		})
+
+		JustBeforeEach(func() {
+			inspectErr = inspector.Crashes(ctx, out, "space", "dora")
+		})
+
+		It("prints the crashes of the LRP, the most recent first", func() {
+			Expect(inspectErr).NotTo(HaveOccurred())
+			Expect(out.String()).To(Equal(
+				"INDEX   REASON             MESSAGE                                  COUNT   FIRST   LAST\n" +
+					"1       Container: Error   Container terminated with exit code: 1   5       60m     2m\n" +
+					"0       Container: Error   Container terminated with exit code: 1   2       60m     30m\n",
+			))
+		})
+
+		When("the LRP has not crashed", func() {
+			BeforeEach(func() {
+				kubeObjs = nil
+			})
+
+			It("says so", func() {
+				Expect(inspectErr).NotTo(HaveOccurred())
+				Expect(out.String()).To(Equal("No crashes found for LRP dora in space namespace.\n"))
+			})
+		})
+	})
+
+	Describe("Tasks", func() {
+		BeforeEach(func() {
+			failed := conditionAt(eiriniv1.TaskFailedConditionType, minutesAgo(10))
+			failed.Reason = "Error"
+			failed.Message = "Failed with exit code: 1"
+
+			eiriniObjs = []runtime.Object{
+				taskWith("pending", conditionAt(eiriniv1.TaskInitializedConditionType, minutesAgo(1))),
+				taskWith("running", conditionAt(eiriniv1.TaskStartedConditionType, minutesAgo(3))),
+				taskWith("succeeded",
+					conditionAt(eiriniv1.TaskStartedConditionType, minutesAgo(20)),
+					conditionAt(eiriniv1.TaskSucceededConditionType, minutesAgo(15)),
+				),
+				taskWith("failed", conditionAt(eiriniv1.TaskStartedConditionType, minutesAgo(12)), failed),
+			}
+		})
+
+		JustBeforeEach(func() {
+			inspectErr = inspector.Tasks(ctx, out, "space")
+		})
+
+		It("prints the state, duration and failure reason of the tasks", func() {
+			Expect(inspectErr).NotTo(HaveOccurred())
+			Expect(out.String()).To(Equal(
+				"NAME        STATE       DURATION   REASON\n" +
+					"failed      failed      2m         Error: Failed with exit code: 1\n" +
+					"pending     pending     -          -\n" +
+					"running     running     3m         -\n" +
+					"succeeded   succeeded   5m         -\n",
+			))
+		})
+	})
+})
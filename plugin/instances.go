@@ -0,0 +1,103 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"code.cloudfoundry.org/eirini-controller/k8s/reconciler"
+	"code.cloudfoundry.org/eirini-controller/k8s/stset"
+	"code.cloudfoundry.org/eirini-controller/util"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type instance struct {
+	index int
+	pod   *corev1.Pod
+}
+
+// Instances prints the instances of the LRP, with their state, node, restart
+// count and last crash.
+func (i *Inspector) Instances(ctx context.Context, w io.Writer, namespace, lrpName string) error {
+	lrp, err := i.eiriniClient.EiriniV1().LRPs(namespace).Get(ctx, lrpName, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to get LRP")
+	}
+
+	pods, err := i.kubeClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(stset.StatefulSetLabelSelector(lrp).MatchLabels).String(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list pods")
+	}
+
+	if len(pods.Items) == 0 {
+		fmt.Fprintf(w, "No instances found for LRP %s in %s.\n", lrpName, namespaceDescription(namespace))
+
+		return nil
+	}
+
+	instances := make([]instance, 0, len(pods.Items))
+
+	for podIndex := range pods.Items {
+		pod := &pods.Items[podIndex]
+
+		index, err := util.ParseAppIndex(pod.Name)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse the instance index")
+		}
+
+		instances = append(instances, instance{index: index, pod: pod})
+	}
+
+	sort.Slice(instances, func(a, b int) bool {
+		return instances[a].index < instances[b].index
+	})
+
+	table := newTable(w, "INDEX", "POD", "STATE", "NODE", "RESTARTS", "LAST CRASH")
+
+	for _, inst := range instances {
+		node := inst.pod.Spec.NodeName
+		if node == "" {
+			node = noValue
+		}
+
+		status := applicationContainerStatus(inst.pod)
+
+		restarts := int32(0)
+		if status != nil {
+			restarts = status.RestartCount
+		}
+
+		printRow(table, inst.index, inst.pod.Name, reconciler.InstanceState(inst.pod), node, restarts, i.lastCrash(status))
+	}
+
+	return table.Flush()
+}
+
+func (i *Inspector) lastCrash(status *corev1.ContainerStatus) string {
+	if status == nil || status.LastTerminationState.Terminated == nil {
+		return noValue
+	}
+
+	terminated := status.LastTerminationState.Terminated
+	if terminated.FinishedAt.IsZero() {
+		return fmt.Sprintf("%s (exit %d)", terminated.Reason, terminated.ExitCode)
+	}
+
+	return fmt.Sprintf("%s (exit %d) %s ago", terminated.Reason, terminated.ExitCode, i.age(terminated.FinishedAt))
+}
+
+func applicationContainerStatus(pod *corev1.Pod) *corev1.ContainerStatus {
+	for idx := range pod.Status.ContainerStatuses {
+		if pod.Status.ContainerStatuses[idx].Name == stset.ApplicationContainerName {
+			return &pod.Status.ContainerStatuses[idx]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,4 @@
+// Package plugin implements the commands of the kubectl-eirini plugin, which
+// show the LRPs and tasks of a cluster together with the state of their
+// instances and crashes.
+package plugin
@@ -0,0 +1,112 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	taskStatePending   = "pending"
+	taskStateRunning   = "running"
+	taskStateSucceeded = "succeeded"
+	taskStateFailed    = "failed"
+)
+
+// Tasks prints the tasks of the namespace with their state, how long they
+// ran and why they failed. An empty namespace lists the tasks of all
+// namespaces.
+func (i *Inspector) Tasks(ctx context.Context, w io.Writer, namespace string) error {
+	tasks, err := i.eiriniClient.EiriniV1().Tasks(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list tasks")
+	}
+
+	if len(tasks.Items) == 0 {
+		fmt.Fprintf(w, "No tasks found in %s.\n", namespaceDescription(namespace))
+
+		return nil
+	}
+
+	sort.Slice(tasks.Items, func(a, b int) bool {
+		if tasks.Items[a].Namespace != tasks.Items[b].Namespace {
+			return tasks.Items[a].Namespace < tasks.Items[b].Namespace
+		}
+
+		return tasks.Items[a].Name < tasks.Items[b].Name
+	})
+
+	columns := []interface{}{"NAME", "STATE", "DURATION", "REASON"}
+	if namespace == "" {
+		columns = append([]interface{}{"NAMESPACE"}, columns...)
+	}
+
+	table := newTable(w, columns...)
+
+	for idx := range tasks.Items {
+		task := &tasks.Items[idx]
+
+		row := []interface{}{task.Name, taskState(task), i.taskDuration(task), taskFailureReason(task)}
+		if namespace == "" {
+			row = append([]interface{}{task.Namespace}, row...)
+		}
+
+		printRow(table, row...)
+	}
+
+	return table.Flush()
+}
+
+// taskState summarises the conditions of the task.
+func taskState(task *eiriniv1.Task) string {
+	conditions := task.Status.Conditions
+
+	switch {
+	case meta.IsStatusConditionTrue(conditions, eiriniv1.TaskFailedConditionType):
+		return taskStateFailed
+	case meta.IsStatusConditionTrue(conditions, eiriniv1.TaskSucceededConditionType):
+		return taskStateSucceeded
+	case meta.IsStatusConditionTrue(conditions, eiriniv1.TaskStartedConditionType):
+		return taskStateRunning
+	default:
+		return taskStatePending
+	}
+}
+
+// taskDuration is the time from the start of the task to its completion, or
+// to now while it runs.
+func (i *Inspector) taskDuration(task *eiriniv1.Task) string {
+	started := meta.FindStatusCondition(task.Status.Conditions, eiriniv1.TaskStartedConditionType)
+	if started == nil || started.Status != metav1.ConditionTrue {
+		return noValue
+	}
+
+	end := i.clock.Now()
+
+	for _, conditionType := range []string{eiriniv1.TaskFailedConditionType, eiriniv1.TaskSucceededConditionType} {
+		if completed := meta.FindStatusCondition(task.Status.Conditions, conditionType); completed != nil && completed.Status == metav1.ConditionTrue {
+			end = completed.LastTransitionTime.Time
+		}
+	}
+
+	return formatDuration(end.Sub(started.LastTransitionTime.Time))
+}
+
+func taskFailureReason(task *eiriniv1.Task) string {
+	failed := meta.FindStatusCondition(task.Status.Conditions, eiriniv1.TaskFailedConditionType)
+	if failed == nil || failed.Status != metav1.ConditionTrue {
+		return noValue
+	}
+
+	if failed.Message == "" {
+		return failed.Reason
+	}
+
+	return fmt.Sprintf("%s: %s", failed.Reason, failed.Message)
+}
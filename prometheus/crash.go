@@ -0,0 +1,60 @@
+package prometheus
+
+import (
+	"context"
+	"strconv"
+
+	"code.cloudfoundry.org/eirini-controller/k8s/reconciler"
+	"code.cloudfoundry.org/eirini-controller/k8s/stset"
+	"code.cloudfoundry.org/lager"
+	prometheusapi "github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	LRPCrashes     = "eirini_lrp_crashes"
+	LRPCrashesHelp = "The total number of lrp instance crashes, by reason and exit code"
+)
+
+//counterfeiter:generate . CrashEventGenerator
+
+type CrashEventGenerator interface {
+	Generate(context.Context, *corev1.Pod, lager.Logger) *reconciler.CrashEvent
+}
+
+// CrashEventGeneratorDecorator counts the crashes not reported yet, as the
+// crash reconciler does not report a crash it already reported on the pod.
+type CrashEventGeneratorDecorator struct {
+	CrashEventGenerator
+	crashes *prometheusapi.CounterVec
+	labels  *workloadLabels
+}
+
+func NewCrashEventGeneratorDecorator(generator CrashEventGenerator, registry prometheusapi.Registerer) (*CrashEventGeneratorDecorator, error) {
+	crashes, err := registerCounterVec(registry, LRPCrashes, LRPCrashesHelp, "namespace", "process_type", "reason", "exit_code")
+	if err != nil {
+		return nil, err
+	}
+
+	return &CrashEventGeneratorDecorator{
+		CrashEventGenerator: generator,
+		crashes:             crashes,
+		labels:              defaultWorkloadLabels,
+	}, nil
+}
+
+func (d *CrashEventGeneratorDecorator) Generate(ctx context.Context, pod *corev1.Pod, logger lager.Logger) *reconciler.CrashEvent {
+	crashEvent := d.CrashEventGenerator.Generate(ctx, pod, logger)
+	if crashEvent == nil {
+		return nil
+	}
+
+	if strconv.FormatInt(crashEvent.CrashTimestamp, 10) == pod.Annotations[stset.AnnotationLastReportedLRPCrash] { // nolint:gomnd
+		return crashEvent
+	}
+
+	namespace, processType := d.labels.of(pod.Namespace, pod.Labels[stset.LabelProcessType])
+	d.crashes.WithLabelValues(namespace, processType, crashEvent.Reason, strconv.Itoa(crashEvent.ExitCode)).Inc()
+
+	return crashEvent
+}
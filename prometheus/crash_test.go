@@ -0,0 +1,84 @@
+package prometheus_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/eirini-controller/k8s/reconciler"
+	"code.cloudfoundry.org/eirini-controller/k8s/stset"
+	"code.cloudfoundry.org/eirini-controller/prometheus"
+	"code.cloudfoundry.org/eirini-controller/prometheus/prometheusfakes"
+	"code.cloudfoundry.org/eirini-controller/tests"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	prometheusapi "github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var _ = Describe("Crash Event Generator Prometheus Decorator", func() {
+	var (
+		generator  *prometheusfakes.FakeCrashEventGenerator
+		decorator  *prometheus.CrashEventGeneratorDecorator
+		pod        *corev1.Pod
+		crashEvent *reconciler.CrashEvent
+		registry   metrics.RegistererGatherer
+	)
+
+	BeforeEach(func() {
+		generator = new(prometheusfakes.FakeCrashEventGenerator)
+		generator.GenerateReturns(&reconciler.CrashEvent{
+			Reason:         "OOMKilled",
+			ExitCode:       137,
+			CrashTimestamp: 1234,
+		})
+		pod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "the-namespace",
+				Name:      "the-pod",
+				Labels:    map[string]string{stset.LabelProcessType: "worker"},
+			},
+		}
+		registry = prometheusapi.NewRegistry()
+
+		var err error
+		decorator, err = prometheus.NewCrashEventGeneratorDecorator(generator, registry)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	JustBeforeEach(func() {
+		crashEvent = decorator.Generate(context.Background(), pod, tests.NewTestLogger("crash-decorator"))
+	})
+
+	It("returns the generated crash event", func() {
+		Expect(generator.GenerateCallCount()).To(Equal(1))
+		Expect(crashEvent.Reason).To(Equal("OOMKilled"))
+	})
+
+	It("counts the crash by reason and exit code", func() {
+		Expect(registry).To(HaveLabelledCounter(prometheus.LRPCrashes, prometheus.LRPCrashesHelp,
+			`exit_code="137",namespace="the-namespace",process_type="worker",reason="OOMKilled"`, 1))
+	})
+
+	When("the crash was already reported", func() {
+		BeforeEach(func() {
+			pod.Annotations = map[string]string{stset.AnnotationLastReportedLRPCrash: "1234"}
+		})
+
+		It("does not count it again", func() {
+			Expect(crashEvent).NotTo(BeNil())
+			Expect(registry).To(HaveMetric(prometheus.LRPCrashes, ""))
+		})
+	})
+
+	When("the pod has not crashed", func() {
+		BeforeEach(func() {
+			generator.GenerateReturns(nil)
+		})
+
+		It("does not count a crash", func() {
+			Expect(crashEvent).To(BeNil())
+			Expect(registry).To(HaveMetric(prometheus.LRPCrashes, ""))
+		})
+	})
+})
@@ -0,0 +1,231 @@
+package prometheus
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/eirini-controller/k8s/stset"
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"code.cloudfoundry.org/lager"
+	prometheusapi "github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	LRPDesiredInstances       = "eirini_lrp_desired_instances"
+	LRPDesiredInstancesHelp   = "The number of desired lrp instances"
+	LRPReadyInstances         = "eirini_lrp_ready_instances"
+	LRPReadyInstancesHelp     = "The number of ready lrp instances"
+	LRPCrashingInstances      = "eirini_lrp_crashing_instances"
+	LRPCrashingInstancesHelp  = "The number of crashed lrp instances"
+	LRPLastCrashTimestamp     = "eirini_lrp_last_crash_timestamp_seconds"
+	LRPLastCrashTimestampHelp = "The time of the last reported lrp instance crash"
+)
+
+const instancesCollectTimeout = 10 * time.Second
+
+// NamespaceMatcher tells whether the LRPs of a namespace are exported,
+// either as the controller serves the namespace, or by this replica.
+type NamespaceMatcher interface {
+	Includes(ctx context.Context, namespace string) (bool, error)
+}
+
+// InstancesCollector exports the desired, ready and crashing instances of
+// every LRP as read from the cache at scrape time, so that under-provisioned
+// apps show up right away. When bySpace is set the instances are summed per
+// space instead, for installations with too many apps to label each. Only
+// the LRPs of the namespaces the controller serves are exported, and of
+// those only the ones of the namespaces matched, so that every replica
+// exports its own LRPs and the series of a process are not duplicated.
+type InstancesCollector struct {
+	logger          lager.Logger
+	reader          client.Reader
+	bySpace         bool
+	namespaceFilter NamespaceMatcher
+	matcher         NamespaceMatcher
+
+	desired   *prometheusapi.Desc
+	ready     *prometheusapi.Desc
+	crashing  *prometheusapi.Desc
+	lastCrash *prometheusapi.Desc
+}
+
+type instances struct {
+	labels    []string
+	desired   int
+	ready     int
+	crashing  int
+	lastCrash int64
+}
+
+func NewInstancesCollector(logger lager.Logger, reader client.Reader, bySpace bool, namespaceFilter, matcher NamespaceMatcher) *InstancesCollector {
+	labels := []string{"app_guid", "process_type", "space_guid"}
+	if bySpace {
+		labels = []string{"space_guid"}
+	}
+
+	return &InstancesCollector{
+		logger:          logger.Session("instances-collector"),
+		reader:          reader,
+		bySpace:         bySpace,
+		namespaceFilter: namespaceFilter,
+		matcher:         matcher,
+		desired:         prometheusapi.NewDesc(LRPDesiredInstances, LRPDesiredInstancesHelp, labels, nil),
+		ready:           prometheusapi.NewDesc(LRPReadyInstances, LRPReadyInstancesHelp, labels, nil),
+		crashing:        prometheusapi.NewDesc(LRPCrashingInstances, LRPCrashingInstancesHelp, labels, nil),
+		lastCrash:       prometheusapi.NewDesc(LRPLastCrashTimestamp, LRPLastCrashTimestampHelp, labels, nil),
+	}
+}
+
+func (c *InstancesCollector) Describe(descs chan<- *prometheusapi.Desc) {
+	descs <- c.desired
+	descs <- c.ready
+	descs <- c.crashing
+	descs <- c.lastCrash
+}
+
+func (c *InstancesCollector) Collect(metrics chan<- prometheusapi.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), instancesCollectTimeout)
+	defer cancel()
+
+	lrps := &eiriniv1.LRPList{}
+	if err := c.reader.List(ctx, lrps); err != nil {
+		c.logger.Error("failed-to-list-lrps", err)
+
+		return
+	}
+
+	matched, err := c.matching(ctx, lrps.Items)
+	if err != nil {
+		c.logger.Error("failed-to-match-namespaces", err)
+
+		return
+	}
+
+	lastCrashes, err := c.lastCrashes(ctx)
+	if err != nil {
+		c.logger.Error("failed-to-list-pods", err)
+
+		return
+	}
+
+	for _, instances := range c.instancesOf(matched, lastCrashes) {
+		metrics <- prometheusapi.MustNewConstMetric(c.desired, prometheusapi.GaugeValue, float64(instances.desired), instances.labels...)
+		metrics <- prometheusapi.MustNewConstMetric(c.ready, prometheusapi.GaugeValue, float64(instances.ready), instances.labels...)
+		metrics <- prometheusapi.MustNewConstMetric(c.crashing, prometheusapi.GaugeValue, float64(instances.crashing), instances.labels...)
+
+		if instances.lastCrash > 0 {
+			metrics <- prometheusapi.MustNewConstMetric(c.lastCrash, prometheusapi.GaugeValue, float64(instances.lastCrash), instances.labels...)
+		}
+	}
+}
+
+// matching returns the LRPs of the namespaces included by both the
+// namespace filter and the matcher. Either matches every namespace when nil.
+func (c *InstancesCollector) matching(ctx context.Context, lrps []eiriniv1.LRP) ([]eiriniv1.LRP, error) {
+	if c.namespaceFilter == nil && c.matcher == nil {
+		return lrps, nil
+	}
+
+	matched := []eiriniv1.LRP{}
+	included := map[string]bool{}
+
+	for _, lrp := range lrps {
+		include, ok := included[lrp.Namespace]
+		if !ok {
+			var err error
+			if include, err = c.includes(ctx, lrp.Namespace); err != nil {
+				return nil, err
+			}
+
+			included[lrp.Namespace] = include
+		}
+
+		if include {
+			matched = append(matched, lrp)
+		}
+	}
+
+	return matched, nil
+}
+
+func (c *InstancesCollector) includes(ctx context.Context, namespace string) (bool, error) {
+	for _, matcher := range []NamespaceMatcher{c.namespaceFilter, c.matcher} {
+		if matcher == nil {
+			continue
+		}
+
+		if include, err := matcher.Includes(ctx, namespace); err != nil || !include {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// instancesOf groups the instances by their labels: per process, or per
+// space. LRPs sharing the labels, such as the same process in several
+// namespaces, are summed, as a series can only be exported once.
+func (c *InstancesCollector) instancesOf(lrps []eiriniv1.LRP, lastCrashes map[string]int64) []*instances {
+	groups := []*instances{}
+	groupsByKey := map[string]*instances{}
+
+	for _, lrp := range lrps {
+		labels := []string{lrp.Spec.AppGUID, lrp.Spec.ProcessType, lrp.Spec.SpaceGUID}
+		if c.bySpace {
+			labels = []string{lrp.Spec.SpaceGUID}
+		}
+
+		key := strings.Join(labels, "\x00")
+
+		group, ok := groupsByKey[key]
+		if !ok {
+			group = &instances{labels: labels}
+			groupsByKey[key] = group
+			groups = append(groups, group)
+		}
+
+		group.desired += lrp.Spec.Instances
+		group.ready += int(lrp.Status.Replicas)
+
+		for _, instance := range lrp.Status.Instances {
+			if instance.State == eiriniv1.InstanceStateCrashed {
+				group.crashing++
+			}
+		}
+
+		if lastCrash := lastCrashes[lrp.Namespace+"/"+lrp.Spec.GUID]; lastCrash > group.lastCrash {
+			group.lastCrash = lastCrash
+		}
+	}
+
+	return groups
+}
+
+// lastCrashes returns the last crash reported on the app pods of each
+// process, by namespace and process GUID.
+func (c *InstancesCollector) lastCrashes(ctx context.Context) (map[string]int64, error) {
+	pods := &corev1.PodList{}
+	if err := c.reader.List(ctx, pods, client.MatchingLabels{stset.LabelSourceType: stset.AppSourceType}); err != nil {
+		return nil, err
+	}
+
+	lastCrashes := map[string]int64{}
+
+	for _, pod := range pods.Items {
+		lastCrash, err := strconv.ParseInt(pod.Annotations[stset.AnnotationLastReportedLRPCrash], 10, 64) // nolint:gomnd
+		if err != nil {
+			continue
+		}
+
+		key := pod.Namespace + "/" + pod.Labels[stset.LabelGUID]
+		if lastCrash > lastCrashes[key] {
+			lastCrashes[key] = lastCrash
+		}
+	}
+
+	return lastCrashes, nil
+}
@@ -0,0 +1,213 @@
+package prometheus_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"code.cloudfoundry.org/eirini-controller/k8s/k8sfakes"
+	"code.cloudfoundry.org/eirini-controller/k8s/stset"
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"code.cloudfoundry.org/eirini-controller/prometheus"
+	"code.cloudfoundry.org/eirini-controller/tests"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("InstancesCollector", func() {
+	var (
+		reader    *k8sfakes.FakeClient
+		lrps      []eiriniv1.LRP
+		pods      []corev1.Pod
+		bySpace   bool
+		filter    prometheus.NamespaceMatcher
+		matcher   prometheus.NamespaceMatcher
+		collector *prometheus.InstancesCollector
+	)
+
+	lrp := func(name, appGUID, processType string, desired int, ready int32, states ...string) eiriniv1.LRP {
+		instances := []eiriniv1.LRPInstanceStatus{}
+		for i, state := range states {
+			instances = append(instances, eiriniv1.LRPInstanceStatus{Index: i, State: state})
+		}
+
+		return eiriniv1.LRP{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "the-namespace", Name: name},
+			Spec: eiriniv1.LRPSpec{
+				GUID:        name + "-guid",
+				AppGUID:     appGUID,
+				ProcessType: processType,
+				SpaceGUID:   "the-space",
+				Instances:   desired,
+			},
+			Status: eiriniv1.LRPStatus{Replicas: ready, Instances: instances},
+		}
+	}
+
+	BeforeEach(func() {
+		reader = new(k8sfakes.FakeClient)
+		bySpace = false
+		filter = nil
+		matcher = nil
+		lrps = []eiriniv1.LRP{
+			lrp("web", "app-1", "web", 3, 1, eiriniv1.InstanceStateRunning, eiriniv1.InstanceStateCrashed, eiriniv1.InstanceStateStarting),
+			lrp("worker", "app-2", "worker", 2, 2, eiriniv1.InstanceStateRunning, eiriniv1.InstanceStateRunning),
+		}
+		pods = []corev1.Pod{
+			{ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "the-namespace",
+				Labels:      map[string]string{stset.LabelGUID: "web-guid"},
+				Annotations: map[string]string{stset.AnnotationLastReportedLRPCrash: "1000"},
+			}},
+			{ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "the-namespace",
+				Labels:      map[string]string{stset.LabelGUID: "web-guid"},
+				Annotations: map[string]string{stset.AnnotationLastReportedLRPCrash: "2000"},
+			}},
+			{ObjectMeta: metav1.ObjectMeta{
+				Namespace: "the-namespace",
+				Labels:    map[string]string{stset.LabelGUID: "worker-guid"},
+			}},
+		}
+
+		reader.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			switch list := list.(type) {
+			case *eiriniv1.LRPList:
+				list.Items = lrps
+			case *corev1.PodList:
+				list.Items = pods
+			}
+
+			return nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		collector = prometheus.NewInstancesCollector(tests.NewTestLogger("instances-collector"), reader, bySpace, filter, matcher)
+	})
+
+	It("lists the app pods", func() {
+		testutil.CollectAndCount(collector)
+
+		Expect(reader.ListCallCount()).To(Equal(2))
+		_, _, opts := reader.ListArgsForCall(1)
+		Expect(opts).To(ConsistOf(client.MatchingLabels{stset.LabelSourceType: stset.AppSourceType}))
+	})
+
+	It("exports the instances of every process", func() {
+		Expect(testutil.CollectAndCompare(collector, strings.NewReader(`
+			# HELP eirini_lrp_desired_instances The number of desired lrp instances
+			# TYPE eirini_lrp_desired_instances gauge
+			eirini_lrp_desired_instances{app_guid="app-1",process_type="web",space_guid="the-space"} 3
+			eirini_lrp_desired_instances{app_guid="app-2",process_type="worker",space_guid="the-space"} 2
+			# HELP eirini_lrp_ready_instances The number of ready lrp instances
+			# TYPE eirini_lrp_ready_instances gauge
+			eirini_lrp_ready_instances{app_guid="app-1",process_type="web",space_guid="the-space"} 1
+			eirini_lrp_ready_instances{app_guid="app-2",process_type="worker",space_guid="the-space"} 2
+			# HELP eirini_lrp_crashing_instances The number of crashed lrp instances
+			# TYPE eirini_lrp_crashing_instances gauge
+			eirini_lrp_crashing_instances{app_guid="app-1",process_type="web",space_guid="the-space"} 1
+			eirini_lrp_crashing_instances{app_guid="app-2",process_type="worker",space_guid="the-space"} 0
+			# HELP eirini_lrp_last_crash_timestamp_seconds The time of the last reported lrp instance crash
+			# TYPE eirini_lrp_last_crash_timestamp_seconds gauge
+			eirini_lrp_last_crash_timestamp_seconds{app_guid="app-1",process_type="web",space_guid="the-space"} 2000
+		`))).To(Succeed())
+	})
+
+	When("the same process has LRPs in several namespaces", func() {
+		BeforeEach(func() {
+			moved := lrp("web", "app-1", "web", 2, 2)
+			moved.Namespace = "other-namespace"
+			lrps = append(lrps, moved)
+		})
+
+		It("exports a single series of the summed instances", func() {
+			Expect(testutil.CollectAndCompare(collector, strings.NewReader(`
+				# HELP eirini_lrp_desired_instances The number of desired lrp instances
+				# TYPE eirini_lrp_desired_instances gauge
+				eirini_lrp_desired_instances{app_guid="app-1",process_type="web",space_guid="the-space"} 5
+				eirini_lrp_desired_instances{app_guid="app-2",process_type="worker",space_guid="the-space"} 2
+			`), prometheus.LRPDesiredInstances)).To(Succeed())
+		})
+	})
+
+	When("only some namespaces are matched", func() {
+		BeforeEach(func() {
+			other := lrp("other", "app-3", "web", 4, 4)
+			other.Namespace = "other-namespace"
+			lrps = append(lrps, other)
+			matcher = namespaceMatcher{"other-namespace": true}
+		})
+
+		It("exports the LRPs of the matched namespaces only", func() {
+			Expect(testutil.CollectAndCompare(collector, strings.NewReader(`
+				# HELP eirini_lrp_desired_instances The number of desired lrp instances
+				# TYPE eirini_lrp_desired_instances gauge
+				eirini_lrp_desired_instances{app_guid="app-3",process_type="web",space_guid="the-space"} 4
+			`), prometheus.LRPDesiredInstances)).To(Succeed())
+		})
+	})
+
+	When("the controller does not serve some namespaces", func() {
+		BeforeEach(func() {
+			other := lrp("other", "app-3", "web", 4, 4)
+			other.Namespace = "other-namespace"
+			lrps = append(lrps, other)
+			filter = namespaceMatcher{"the-namespace": true}
+			matcher = namespaceMatcher{"the-namespace": true, "other-namespace": true}
+		})
+
+		It("does not export their LRPs", func() {
+			Expect(testutil.CollectAndCompare(collector, strings.NewReader(`
+				# HELP eirini_lrp_desired_instances The number of desired lrp instances
+				# TYPE eirini_lrp_desired_instances gauge
+				eirini_lrp_desired_instances{app_guid="app-1",process_type="web",space_guid="the-space"} 3
+				eirini_lrp_desired_instances{app_guid="app-2",process_type="worker",space_guid="the-space"} 2
+			`), prometheus.LRPDesiredInstances)).To(Succeed())
+		})
+	})
+
+	When("aggregating per space", func() {
+		BeforeEach(func() {
+			bySpace = true
+		})
+
+		It("sums the instances of the space", func() {
+			Expect(testutil.CollectAndCompare(collector, strings.NewReader(`
+				# HELP eirini_lrp_desired_instances The number of desired lrp instances
+				# TYPE eirini_lrp_desired_instances gauge
+				eirini_lrp_desired_instances{space_guid="the-space"} 5
+				# HELP eirini_lrp_ready_instances The number of ready lrp instances
+				# TYPE eirini_lrp_ready_instances gauge
+				eirini_lrp_ready_instances{space_guid="the-space"} 3
+				# HELP eirini_lrp_crashing_instances The number of crashed lrp instances
+				# TYPE eirini_lrp_crashing_instances gauge
+				eirini_lrp_crashing_instances{space_guid="the-space"} 1
+				# HELP eirini_lrp_last_crash_timestamp_seconds The time of the last reported lrp instance crash
+				# TYPE eirini_lrp_last_crash_timestamp_seconds gauge
+				eirini_lrp_last_crash_timestamp_seconds{space_guid="the-space"} 2000
+			`))).To(Succeed())
+		})
+	})
+
+	When("listing the LRPs fails", func() {
+		BeforeEach(func() {
+			reader.ListReturns(errors.New("boom"))
+			reader.ListStub = nil
+		})
+
+		It("exports nothing", func() {
+			Expect(testutil.CollectAndCount(collector)).To(BeZero())
+		})
+	})
+})
+
+type namespaceMatcher map[string]bool
+
+func (m namespaceMatcher) Includes(_ context.Context, namespace string) (bool, error) {
+	return m[namespace], nil
+}
@@ -0,0 +1,62 @@
+package prometheus
+
+import "sync"
+
+const (
+	// MaxNamespaceLabels and MaxProcessTypeLabels bound the cardinality of
+	// the namespace and process type labels. Further values are counted
+	// under OtherLabelValue.
+	MaxNamespaceLabels   = 100
+	MaxProcessTypeLabels = 20
+	OtherLabelValue      = "other"
+
+	TaskProcessType = "task"
+)
+
+// boundedLabel keeps the first limit values of a label, and maps any other
+// value to OtherLabelValue.
+type boundedLabel struct {
+	mutex  sync.Mutex
+	limit  int
+	values map[string]bool
+}
+
+func newBoundedLabel(limit int) *boundedLabel {
+	return &boundedLabel{
+		limit:  limit,
+		values: map[string]bool{},
+	}
+}
+
+func (l *boundedLabel) value(value string) string {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.values[value] {
+		return value
+	}
+
+	if len(l.values) >= l.limit {
+		return OtherLabelValue
+	}
+
+	l.values[value] = true
+
+	return value
+}
+
+// workloadLabels bounds the namespace and process type labels shared by all
+// the decorators.
+type workloadLabels struct {
+	namespaces   *boundedLabel
+	processTypes *boundedLabel
+}
+
+var defaultWorkloadLabels = &workloadLabels{
+	namespaces:   newBoundedLabel(MaxNamespaceLabels),
+	processTypes: newBoundedLabel(MaxProcessTypeLabels),
+}
+
+func (l *workloadLabels) of(namespace, processType string) (string, string) {
+	return l.namespaces.value(namespace), l.processTypes.value(processType)
+}
@@ -0,0 +1,80 @@
+package prometheus
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	prometheusapi "github.com/prometheus/client_golang/prometheus"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	LeaderLeaseHeld                   = "eirini_leader_lease_held"
+	LeaderLeaseHeldHelp               = "Whether a controller replica holds the leader lease"
+	LeaderLeaseLastRenewTimestamp     = "eirini_leader_lease_last_renew_timestamp_seconds"
+	LeaderLeaseLastRenewTimestampHelp = "The time the leader lease was last renewed"
+)
+
+const leaderLeaseCollectTimeout = 10 * time.Second
+
+// LeaderLeaseCollector exports whether the leader lease is held and when it
+// was last renewed, as read at scrape time, so that a lapsed lease can be
+// alerted on. It is not a readiness check, as every replica would then stop
+// serving the webhooks while the lease lapses.
+type LeaderLeaseCollector struct {
+	logger lager.Logger
+	reader client.Reader
+	lease  client.ObjectKey
+
+	held      *prometheusapi.Desc
+	lastRenew *prometheusapi.Desc
+}
+
+func NewLeaderLeaseCollector(logger lager.Logger, reader client.Reader, lease client.ObjectKey) *LeaderLeaseCollector {
+	return &LeaderLeaseCollector{
+		logger:    logger.Session("leader-lease-collector", lager.Data{"lease": lease.String()}),
+		reader:    reader,
+		lease:     lease,
+		held:      prometheusapi.NewDesc(LeaderLeaseHeld, LeaderLeaseHeldHelp, nil, nil),
+		lastRenew: prometheusapi.NewDesc(LeaderLeaseLastRenewTimestamp, LeaderLeaseLastRenewTimestampHelp, nil, nil),
+	}
+}
+
+func (c *LeaderLeaseCollector) Describe(descs chan<- *prometheusapi.Desc) {
+	descs <- c.held
+	descs <- c.lastRenew
+}
+
+func (c *LeaderLeaseCollector) Collect(metrics chan<- prometheusapi.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), leaderLeaseCollectTimeout)
+	defer cancel()
+
+	lease := &coordinationv1.Lease{}
+
+	err := c.reader.Get(ctx, c.lease, lease)
+	if k8serrors.IsNotFound(err) {
+		metrics <- prometheusapi.MustNewConstMetric(c.held, prometheusapi.GaugeValue, 0)
+
+		return
+	}
+
+	if err != nil {
+		c.logger.Error("failed-to-get-lease", err)
+
+		return
+	}
+
+	held := 0.0
+	if lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity != "" {
+		held = 1
+	}
+
+	metrics <- prometheusapi.MustNewConstMetric(c.held, prometheusapi.GaugeValue, held)
+
+	if lease.Spec.RenewTime != nil {
+		metrics <- prometheusapi.MustNewConstMetric(c.lastRenew, prometheusapi.GaugeValue, float64(lease.Spec.RenewTime.Unix()))
+	}
+}
@@ -0,0 +1,86 @@
+package prometheus_test
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/eirini-controller/prometheus"
+	"code.cloudfoundry.org/eirini-controller/tests"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("LeaderLeaseCollector", func() {
+	var (
+		renewTime time.Time
+		lease     *coordinationv1.Lease
+		objects   []client.Object
+		collector *prometheus.LeaderLeaseCollector
+	)
+
+	key := client.ObjectKey{Namespace: "eirini-controller", Name: "eirini-controller-leader"}
+
+	BeforeEach(func() {
+		renewTime = time.Unix(1600000000, 0)
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity: pointer.String("replica-1"),
+				RenewTime:      &metav1.MicroTime{Time: renewTime},
+			},
+		}
+		objects = []client.Object{lease}
+	})
+
+	JustBeforeEach(func() {
+		reader := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objects...).Build()
+		collector = prometheus.NewLeaderLeaseCollector(tests.NewTestLogger("leader-lease-collector"), reader, key)
+	})
+
+	It("exports that the lease is held and when it was last renewed", func() {
+		Expect(testutil.CollectAndCompare(collector, strings.NewReader(fmt.Sprintf(`
+			# HELP eirini_leader_lease_held Whether a controller replica holds the leader lease
+			# TYPE eirini_leader_lease_held gauge
+			eirini_leader_lease_held 1
+			# HELP eirini_leader_lease_last_renew_timestamp_seconds The time the leader lease was last renewed
+			# TYPE eirini_leader_lease_last_renew_timestamp_seconds gauge
+			eirini_leader_lease_last_renew_timestamp_seconds %d
+		`, renewTime.Unix())))).To(Succeed())
+	})
+
+	When("the lease is not held", func() {
+		BeforeEach(func() {
+			lease.Spec.HolderIdentity = pointer.String("")
+		})
+
+		It("exports that it is not held", func() {
+			Expect(testutil.CollectAndCompare(collector, strings.NewReader(`
+				# HELP eirini_leader_lease_held Whether a controller replica holds the leader lease
+				# TYPE eirini_leader_lease_held gauge
+				eirini_leader_lease_held 0
+			`), prometheus.LeaderLeaseHeld)).To(Succeed())
+		})
+	})
+
+	When("there is no lease", func() {
+		BeforeEach(func() {
+			objects = nil
+		})
+
+		It("exports that it is not held", func() {
+			Expect(testutil.CollectAndCompare(collector, strings.NewReader(`
+				# HELP eirini_leader_lease_held Whether a controller replica holds the leader lease
+				# TYPE eirini_leader_lease_held gauge
+				eirini_leader_lease_held 0
+			`))).To(Succeed())
+		})
+	})
+})
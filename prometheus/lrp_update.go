@@ -0,0 +1,80 @@
+package prometheus
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/eirini-controller/k8s/stset"
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	prometheusapi "github.com/prometheus/client_golang/prometheus"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+const (
+	LRPUpdates     = "eirini_lrp_updates"
+	LRPUpdatesHelp = "The total number of lrp updates, by changed field"
+
+	LRPFieldInstances = "instances"
+	LRPFieldImage     = "image"
+)
+
+//counterfeiter:generate . LRPUpdater
+
+type LRPUpdater interface {
+	Update(ctx context.Context, lrp *eiriniv1.LRP, stSet *appsv1.StatefulSet) error
+}
+
+type LRPUpdaterDecorator struct {
+	LRPUpdater
+	updates *prometheusapi.CounterVec
+	labels  *workloadLabels
+}
+
+func NewLRPUpdaterDecorator(updater LRPUpdater, registry prometheusapi.Registerer) (*LRPUpdaterDecorator, error) {
+	updates, err := registerCounterVec(registry, LRPUpdates, LRPUpdatesHelp, "namespace", "process_type", "field")
+	if err != nil {
+		return nil, err
+	}
+
+	return &LRPUpdaterDecorator{
+		LRPUpdater: updater,
+		updates:    updates,
+		labels:     defaultWorkloadLabels,
+	}, nil
+}
+
+func (d *LRPUpdaterDecorator) Update(ctx context.Context, lrp *eiriniv1.LRP, stSet *appsv1.StatefulSet) error {
+	fields := changedFields(lrp, stSet)
+
+	err := d.LRPUpdater.Update(ctx, lrp, stSet)
+	if err != nil {
+		return err
+	}
+
+	namespace, processType := d.labels.of(lrp.Namespace, lrp.Spec.ProcessType)
+	for _, field := range fields {
+		d.updates.WithLabelValues(namespace, processType, field).Inc()
+	}
+
+	return nil
+}
+
+// changedFields lists the fields of the LRP the statefulset is updated with.
+func changedFields(lrp *eiriniv1.LRP, stSet *appsv1.StatefulSet) []string {
+	fields := []string{}
+
+	if stSet.Spec.Replicas == nil || *stSet.Spec.Replicas != int32(lrp.Spec.Instances) {
+		fields = append(fields, LRPFieldInstances)
+	}
+
+	if lrp.Spec.Image == "" {
+		return fields
+	}
+
+	for _, container := range stSet.Spec.Template.Spec.Containers {
+		if container.Name == stset.ApplicationContainerName && container.Image != lrp.Spec.Image {
+			fields = append(fields, LRPFieldImage)
+		}
+	}
+
+	return fields
+}
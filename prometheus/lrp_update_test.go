@@ -0,0 +1,107 @@
+package prometheus_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/eirini-controller/k8s/stset"
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"code.cloudfoundry.org/eirini-controller/prometheus"
+	"code.cloudfoundry.org/eirini-controller/prometheus/prometheusfakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	prometheusapi "github.com/prometheus/client_golang/prometheus"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var _ = Describe("LRP Updater Prometheus Decorator", func() {
+	var (
+		updater   *prometheusfakes.FakeLRPUpdater
+		decorator *prometheus.LRPUpdaterDecorator
+		lrp       *eiriniv1.LRP
+		stSet     *appsv1.StatefulSet
+		updateErr error
+		registry  metrics.RegistererGatherer
+	)
+
+	BeforeEach(func() {
+		updater = new(prometheusfakes.FakeLRPUpdater)
+		lrp = &eiriniv1.LRP{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "the-namespace", Name: "the-lrp"},
+			Spec: eiriniv1.LRPSpec{
+				ProcessType: "web",
+				Instances:   2,
+				Image:       "the-image",
+			},
+		}
+
+		replicas := int32(2)
+		stSet = &appsv1.StatefulSet{
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: &replicas,
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: stset.ApplicationContainerName, Image: "the-image"}},
+					},
+				},
+			},
+		}
+		registry = prometheusapi.NewRegistry()
+
+		var err error
+		decorator, err = prometheus.NewLRPUpdaterDecorator(updater, registry)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	JustBeforeEach(func() {
+		updateErr = decorator.Update(context.Background(), lrp, stSet)
+	})
+
+	It("delegates to the LRP updater", func() {
+		Expect(updateErr).NotTo(HaveOccurred())
+		Expect(updater.UpdateCallCount()).To(Equal(1))
+		_, actualLRP, actualStSet := updater.UpdateArgsForCall(0)
+		Expect(actualLRP).To(Equal(lrp))
+		Expect(actualStSet).To(Equal(stSet))
+	})
+
+	It("does not count unchanged LRPs", func() {
+		Expect(registry).To(HaveMetric(prometheus.LRPUpdates, ""))
+	})
+
+	When("the instances change", func() {
+		BeforeEach(func() {
+			lrp.Spec.Instances = 3
+		})
+
+		It("counts the update of the instances", func() {
+			Expect(registry).To(HaveLabelledCounter(prometheus.LRPUpdates, prometheus.LRPUpdatesHelp,
+				`field="instances",namespace="the-namespace",process_type="web"`, 1))
+		})
+
+		When("updating the LRP fails", func() {
+			BeforeEach(func() {
+				updater.UpdateReturns(errors.New("foo"))
+			})
+
+			It("does not count the update", func() {
+				Expect(updateErr).To(MatchError("foo"))
+				Expect(registry).To(HaveMetric(prometheus.LRPUpdates, ""))
+			})
+		})
+	})
+
+	When("the image changes", func() {
+		BeforeEach(func() {
+			lrp.Spec.Image = "the-new-image"
+		})
+
+		It("counts the update of the image", func() {
+			Expect(registry).To(HaveLabelledCounter(prometheus.LRPUpdates, prometheus.LRPUpdatesHelp,
+				`field="image",namespace="the-namespace",process_type="web"`, 1))
+		})
+	})
+})
@@ -0,0 +1,43 @@
+package prometheus
+
+import (
+	"context"
+
+	prometheusapi "github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	ReconcileErrors     = "eirini_reconcile_errors"
+	ReconcileErrorsHelp = "The total number of failed reconciles, by controller"
+)
+
+type ReconcilerDecorator struct {
+	reconcile.Reconciler
+	controller string
+	errors     *prometheusapi.CounterVec
+	labels     *workloadLabels
+}
+
+func NewReconcilerDecorator(reconciler reconcile.Reconciler, controller string, registry prometheusapi.Registerer) (*ReconcilerDecorator, error) {
+	errors, err := registerCounterVec(registry, ReconcileErrors, ReconcileErrorsHelp, "controller", "namespace")
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReconcilerDecorator{
+		Reconciler: reconciler,
+		controller: controller,
+		errors:     errors,
+		labels:     defaultWorkloadLabels,
+	}, nil
+}
+
+func (d *ReconcilerDecorator) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	result, err := d.Reconciler.Reconcile(ctx, request)
+	if err != nil {
+		d.errors.WithLabelValues(d.controller, d.labels.namespaces.value(request.Namespace)).Inc()
+	}
+
+	return result, err
+}
@@ -0,0 +1,75 @@
+package prometheus_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"code.cloudfoundry.org/eirini-controller/prometheus"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	prometheusapi "github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Reconciler Prometheus Decorator", func() {
+	var (
+		reconcileErr error
+		decorator    *prometheus.ReconcilerDecorator
+		registry     metrics.RegistererGatherer
+	)
+
+	reconcileIn := func(namespace string) error {
+		_, err := decorator.Reconcile(context.Background(), reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: namespace, Name: "the-name"},
+		})
+
+		return err
+	}
+
+	BeforeEach(func() {
+		reconcileErr = nil
+		registry = prometheusapi.NewRegistry()
+
+		inner := reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+			return reconcile.Result{}, reconcileErr
+		})
+
+		var err error
+		decorator, err = prometheus.NewReconcilerDecorator(inner, "lrp", registry)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("does not count successful reconciles", func() {
+		Expect(reconcileIn("the-namespace")).To(Succeed())
+		Expect(registry).To(HaveMetric(prometheus.ReconcileErrors, ""))
+	})
+
+	When("the reconcile fails", func() {
+		BeforeEach(func() {
+			reconcileErr = errors.New("foo")
+		})
+
+		It("counts the error by controller and namespace", func() {
+			Expect(reconcileIn("the-namespace")).To(MatchError("foo"))
+			Expect(registry).To(HaveLabelledCounter(prometheus.ReconcileErrors, prometheus.ReconcileErrorsHelp,
+				`controller="lrp",namespace="the-namespace"`, 1))
+		})
+
+		It("bounds the number of namespace labels", func() {
+			for i := 0; i < 2*prometheus.MaxNamespaceLabels; i++ {
+				Expect(reconcileIn(fmt.Sprintf("namespace-%d", i))).To(MatchError("foo"))
+			}
+
+			families, err := registry.Gather()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(families).To(HaveLen(1))
+			Expect(len(families[0].GetMetric())).To(BeNumerically("<=", prometheus.MaxNamespaceLabels+1))
+			Expect(families[0].GetMetric()).To(ContainElement(WithTransform(func(metric fmt.Stringer) string {
+				return metric.String()
+			}, ContainSubstring(`value:"other"`))))
+		})
+	})
+})
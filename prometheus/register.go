@@ -0,0 +1,46 @@
+package prometheus
+
+import (
+	"errors"
+
+	prometheusapi "github.com/prometheus/client_golang/prometheus"
+)
+
+func registerCounterVec(registry prometheusapi.Registerer, name, help string, labels ...string) (*prometheusapi.CounterVec, error) {
+	c := prometheusapi.NewCounterVec(prometheusapi.CounterOpts{
+		Name: name,
+		Help: help,
+	}, labels)
+
+	err := registry.Register(c)
+	if err == nil {
+		return c, nil
+	}
+
+	var are prometheusapi.AlreadyRegisteredError
+	if errors.As(err, &are) {
+		return are.ExistingCollector.(*prometheusapi.CounterVec), nil //nolint:forcetypeassert
+	}
+
+	return nil, err
+}
+
+func registerHistogramVec(registry prometheusapi.Registerer, name, help string, buckets []float64, labels ...string) (*prometheusapi.HistogramVec, error) {
+	h := prometheusapi.NewHistogramVec(prometheusapi.HistogramOpts{
+		Name:    name,
+		Help:    help,
+		Buckets: buckets,
+	}, labels)
+
+	err := registry.Register(h)
+	if err == nil {
+		return h, nil
+	}
+
+	var are prometheusapi.AlreadyRegisteredError
+	if errors.As(err, &are) {
+		return are.ExistingCollector.(*prometheusapi.HistogramVec), nil //nolint:forcetypeassert
+	}
+
+	return nil, err
+}
@@ -0,0 +1,176 @@
+package prometheus
+
+import (
+	"context"
+	"sync"
+
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	prometheusapi "github.com/prometheus/client_golang/prometheus"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	TaskCreations        = "eirini_task_creations"
+	TaskCreationsHelp    = "The total number of created tasks"
+	TaskCompletions      = "eirini_task_completions"
+	TaskCompletionsHelp  = "The total number of completed tasks, by outcome"
+	TaskRunDurations     = "eirini_task_run_durations"
+	TaskRunDurationsHelp = "The duration of task runs in seconds"
+
+	TaskOutcomeSucceeded = "succeeded"
+	TaskOutcomeFailed    = "failed"
+	TaskOutcomeTimedOut  = "timed_out"
+
+	jobDeadlineExceededReason = "DeadlineExceeded"
+	completedJobsMemory       = 1024
+)
+
+//counterfeiter:generate . TaskDesirer
+//counterfeiter:generate . TaskStatusGetter
+
+type TaskDesirer interface {
+	Desire(ctx context.Context, task *eiriniv1.Task) (*batchv1.Job, error)
+}
+
+type TaskStatusGetter interface {
+	GetStatusConditions(ctx context.Context, job *batchv1.Job) ([]metav1.Condition, error)
+}
+
+type TaskDesirerDecorator struct {
+	TaskDesirer
+	creations *prometheusapi.CounterVec
+	labels    *workloadLabels
+}
+
+func NewTaskDesirerDecorator(desirer TaskDesirer, registry prometheusapi.Registerer) (*TaskDesirerDecorator, error) {
+	creations, err := registerCounterVec(registry, TaskCreations, TaskCreationsHelp, "namespace", "process_type")
+	if err != nil {
+		return nil, err
+	}
+
+	return &TaskDesirerDecorator{
+		TaskDesirer: desirer,
+		creations:   creations,
+		labels:      defaultWorkloadLabels,
+	}, nil
+}
+
+func (d *TaskDesirerDecorator) Desire(ctx context.Context, task *eiriniv1.Task) (*batchv1.Job, error) {
+	job, err := d.TaskDesirer.Desire(ctx, task)
+	if err == nil {
+		d.creations.WithLabelValues(d.labels.of(task.Namespace, TaskProcessType)).Inc()
+	}
+
+	return job, err
+}
+
+// TaskStatusGetterDecorator counts the tasks completing and observes how
+// long they ran. The status of a job is got until the task status is
+// patched, so completions are only counted for the first time.
+type TaskStatusGetterDecorator struct {
+	TaskStatusGetter
+	completions  *prometheusapi.CounterVec
+	runDurations *prometheusapi.HistogramVec
+	labels       *workloadLabels
+	completed    *recentUIDs
+}
+
+func NewTaskStatusGetterDecorator(statusGetter TaskStatusGetter, registry prometheusapi.Registerer) (*TaskStatusGetterDecorator, error) {
+	completions, err := registerCounterVec(registry, TaskCompletions, TaskCompletionsHelp, "namespace", "process_type", "outcome")
+	if err != nil {
+		return nil, err
+	}
+
+	runDurations, err := registerHistogramVec(registry, TaskRunDurations, TaskRunDurationsHelp,
+		prometheusapi.ExponentialBuckets(1, 2, 15), "namespace", "process_type", "outcome") // nolint:gomnd
+	if err != nil {
+		return nil, err
+	}
+
+	return &TaskStatusGetterDecorator{
+		TaskStatusGetter: statusGetter,
+		completions:      completions,
+		runDurations:     runDurations,
+		labels:           defaultWorkloadLabels,
+		completed:        newRecentUIDs(completedJobsMemory),
+	}, nil
+}
+
+func (d *TaskStatusGetterDecorator) GetStatusConditions(ctx context.Context, job *batchv1.Job) ([]metav1.Condition, error) {
+	conditions, err := d.TaskStatusGetter.GetStatusConditions(ctx, job)
+	if err != nil {
+		return conditions, err
+	}
+
+	outcome, completion := taskOutcome(job, conditions)
+	if completion == nil || !d.completed.add(job.UID) {
+		return conditions, nil
+	}
+
+	namespace, processType := d.labels.of(job.Namespace, TaskProcessType)
+	d.completions.WithLabelValues(namespace, processType, outcome).Inc()
+
+	if job.Status.StartTime != nil {
+		runDuration := completion.LastTransitionTime.Sub(job.Status.StartTime.Time)
+		d.runDurations.WithLabelValues(namespace, processType, outcome).Observe(runDuration.Seconds())
+	}
+
+	return conditions, nil
+}
+
+func taskOutcome(job *batchv1.Job, conditions []metav1.Condition) (string, *metav1.Condition) {
+	if condition := meta.FindStatusCondition(conditions, eiriniv1.TaskSucceededConditionType); condition != nil {
+		return TaskOutcomeSucceeded, condition
+	}
+
+	condition := meta.FindStatusCondition(conditions, eiriniv1.TaskFailedConditionType)
+	if condition == nil {
+		return "", nil
+	}
+
+	for _, jobCondition := range job.Status.Conditions {
+		if jobCondition.Type == batchv1.JobFailed && jobCondition.Reason == jobDeadlineExceededReason {
+			return TaskOutcomeTimedOut, condition
+		}
+	}
+
+	return TaskOutcomeFailed, condition
+}
+
+// recentUIDs remembers the last size UIDs added to it.
+type recentUIDs struct {
+	mutex sync.Mutex
+	size  int
+	uids  map[types.UID]bool
+	order []types.UID
+}
+
+func newRecentUIDs(size int) *recentUIDs {
+	return &recentUIDs{
+		size: size,
+		uids: map[types.UID]bool{},
+	}
+}
+
+// add returns false when the UID is already remembered.
+func (r *recentUIDs) add(uid types.UID) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.uids[uid] {
+		return false
+	}
+
+	if len(r.order) == r.size {
+		delete(r.uids, r.order[0])
+		r.order = r.order[1:]
+	}
+
+	r.uids[uid] = true
+	r.order = append(r.order, uid)
+
+	return true
+}
@@ -0,0 +1,214 @@
+package prometheus_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"code.cloudfoundry.org/eirini-controller/prometheus"
+	"code.cloudfoundry.org/eirini-controller/prometheus/prometheusfakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+	prometheusapi "github.com/prometheus/client_golang/prometheus"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var _ = Describe("Task Desirer Prometheus Decorator", func() {
+	var (
+		desirer   *prometheusfakes.FakeTaskDesirer
+		decorator *prometheus.TaskDesirerDecorator
+		task      *eiriniv1.Task
+		desireErr error
+		registry  metrics.RegistererGatherer
+	)
+
+	BeforeEach(func() {
+		desirer = new(prometheusfakes.FakeTaskDesirer)
+		task = &eiriniv1.Task{ObjectMeta: metav1.ObjectMeta{Namespace: "the-namespace", Name: "the-task"}}
+		registry = prometheusapi.NewRegistry()
+
+		var err error
+		decorator, err = prometheus.NewTaskDesirerDecorator(desirer, registry)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	JustBeforeEach(func() {
+		_, desireErr = decorator.Desire(context.Background(), task)
+	})
+
+	It("delegates to the task desirer", func() {
+		Expect(desireErr).NotTo(HaveOccurred())
+		Expect(desirer.DesireCallCount()).To(Equal(1))
+		_, actualTask := desirer.DesireArgsForCall(0)
+		Expect(actualTask).To(Equal(task))
+	})
+
+	It("increments the task creation counter", func() {
+		Expect(registry).To(HaveLabelledCounter(prometheus.TaskCreations, prometheus.TaskCreationsHelp,
+			`namespace="the-namespace",process_type="task"`, 1))
+	})
+
+	When("desiring the task fails", func() {
+		BeforeEach(func() {
+			desirer.DesireReturns(nil, errors.New("foo"))
+		})
+
+		It("does not increment the task creation counter", func() {
+			Expect(desireErr).To(MatchError("foo"))
+			Expect(registry).To(HaveMetric(prometheus.TaskCreations, ""))
+		})
+	})
+})
+
+var _ = Describe("Task Status Getter Prometheus Decorator", func() {
+	var (
+		statusGetter *prometheusfakes.FakeTaskStatusGetter
+		decorator    *prometheus.TaskStatusGetterDecorator
+		job          *batchv1.Job
+		conditions   []metav1.Condition
+		getErr       error
+		registry     metrics.RegistererGatherer
+		startTime    time.Time
+	)
+
+	BeforeEach(func() {
+		statusGetter = new(prometheusfakes.FakeTaskStatusGetter)
+		startTime = time.Now()
+		job = &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "the-namespace", Name: "the-job", UID: "the-job-uid"},
+			Status:     batchv1.JobStatus{StartTime: &metav1.Time{Time: startTime}},
+		}
+		statusGetter.GetStatusConditionsReturns([]metav1.Condition{
+			{Type: eiriniv1.TaskStartedConditionType, Status: metav1.ConditionTrue},
+			{
+				Type:               eiriniv1.TaskSucceededConditionType,
+				Status:             metav1.ConditionTrue,
+				LastTransitionTime: metav1.NewTime(startTime.Add(10 * time.Second)),
+			},
+		}, nil)
+		registry = prometheusapi.NewRegistry()
+
+		var err error
+		decorator, err = prometheus.NewTaskStatusGetterDecorator(statusGetter, registry)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	JustBeforeEach(func() {
+		conditions, getErr = decorator.GetStatusConditions(context.Background(), job)
+	})
+
+	It("returns the conditions of the status getter", func() {
+		Expect(getErr).NotTo(HaveOccurred())
+		Expect(conditions).To(HaveLen(2))
+		Expect(statusGetter.GetStatusConditionsCallCount()).To(Equal(1))
+	})
+
+	It("counts the succeeded task", func() {
+		Expect(registry).To(HaveLabelledCounter(prometheus.TaskCompletions, prometheus.TaskCompletionsHelp,
+			`namespace="the-namespace",outcome="succeeded",process_type="task"`, 1))
+	})
+
+	It("observes the task run duration", func() {
+		Expect(registry).To(HaveHistogramSamples(prometheus.TaskRunDurations, 10, 1))
+	})
+
+	It("counts the task completion once", func() {
+		_, err := decorator.GetStatusConditions(context.Background(), job)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(registry).To(HaveLabelledCounter(prometheus.TaskCompletions, prometheus.TaskCompletionsHelp,
+			`namespace="the-namespace",outcome="succeeded",process_type="task"`, 1))
+		Expect(registry).To(HaveHistogramSamples(prometheus.TaskRunDurations, 10, 1))
+	})
+
+	When("the task has failed", func() {
+		BeforeEach(func() {
+			statusGetter.GetStatusConditionsReturns([]metav1.Condition{
+				{
+					Type:               eiriniv1.TaskFailedConditionType,
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(startTime.Add(5 * time.Second)),
+				},
+			}, nil)
+		})
+
+		It("counts the failed task", func() {
+			Expect(registry).To(HaveLabelledCounter(prometheus.TaskCompletions, prometheus.TaskCompletionsHelp,
+				`namespace="the-namespace",outcome="failed",process_type="task"`, 1))
+			Expect(registry).To(HaveHistogramSamples(prometheus.TaskRunDurations, 5, 1))
+		})
+
+		When("the job exceeded its deadline", func() {
+			BeforeEach(func() {
+				job.Status.Conditions = []batchv1.JobCondition{
+					{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "DeadlineExceeded"},
+				}
+			})
+
+			It("counts the timed out task", func() {
+				Expect(registry).To(HaveLabelledCounter(prometheus.TaskCompletions, prometheus.TaskCompletionsHelp,
+					`namespace="the-namespace",outcome="timed_out",process_type="task"`, 1))
+			})
+		})
+	})
+
+	When("the task is still running", func() {
+		BeforeEach(func() {
+			statusGetter.GetStatusConditionsReturns([]metav1.Condition{
+				{Type: eiriniv1.TaskStartedConditionType, Status: metav1.ConditionTrue},
+			}, nil)
+		})
+
+		It("does not count it", func() {
+			Expect(registry).To(HaveMetric(prometheus.TaskCompletions, ""))
+		})
+	})
+
+	When("getting the status conditions fails", func() {
+		BeforeEach(func() {
+			statusGetter.GetStatusConditionsReturns(nil, errors.New("foo"))
+		})
+
+		It("returns the error", func() {
+			Expect(getErr).To(MatchError("foo"))
+			Expect(registry).To(HaveMetric(prometheus.TaskCompletions, ""))
+		})
+	})
+})
+
+func HaveLabelledCounter(name, help, labels string, value int) types.GomegaMatcher {
+	return HaveMetric(name, fmt.Sprintf(`
+		# HELP %[1]s %[2]s
+		# TYPE %[1]s counter
+		%[1]s{%[3]s} %[4]d
+		`,
+		name, help, labels, value,
+	))
+}
+
+func HaveHistogramSamples(name string, sum float64, count int) types.GomegaMatcher {
+	return WithTransform(func(registry prometheusapi.Gatherer) ([]float64, error) {
+		families, err := registry.Gather()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, family := range families {
+			if family.GetName() != name {
+				continue
+			}
+
+			histogram := family.GetMetric()[0].GetHistogram()
+
+			return []float64{histogram.GetSampleSum(), float64(histogram.GetSampleCount())}, nil
+		}
+
+		return nil, nil
+	}, Equal([]float64{sum, float64(count)}))
+}
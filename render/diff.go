@@ -0,0 +1,208 @@
+package render
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// serverMetadata are the metadata fields set by the API server.
+var serverMetadata = []string{"uid", "resourceVersion", "generation", "creationTimestamp", "managedFields", "selfLink"}
+
+// Diff writes the differences between the rendered objects and the live
+// objects with the same kind, namespace and name, or generated name, and
+// returns whether there are any. Only the fields set by the controller are
+// compared, so that values defaulted by the API server or added by others
+// are not reported. Rendered objects without a live one are reported as
+// missing.
+func Diff(writer io.Writer, rendered, live []client.Object) (bool, error) {
+	var buffer bytes.Buffer
+
+	matched := map[client.Object]bool{}
+	renames := map[string]string{}
+	differs := false
+
+	for _, object := range rendered {
+		kind := object.GetObjectKind().GroupVersionKind().Kind
+
+		liveObject := findLive(object, live, matched)
+		if liveObject == nil {
+			differs = true
+
+			fmt.Fprintf(&buffer, "%s %s/%s: not found in the live objects\n", kind, object.GetNamespace(), object.GetName())
+
+			continue
+		}
+
+		matched[liveObject] = true
+
+		desired, err := desiredContent(object)
+		if err != nil {
+			return false, err
+		}
+
+		// The in-memory cluster generates other names than the live one,
+		// which are also referenced by the objects created afterwards.
+		if object.GetName() != liveObject.GetName() {
+			renames[object.GetName()] = liveObject.GetName()
+		}
+
+		desired = rename(desired, renames).(map[string]interface{}) //nolint:forcetypeassert
+
+		actual, err := toUnstructured(liveObject)
+		if err != nil {
+			return false, err
+		}
+
+		encodeStringData(actual)
+
+		// Objects are matched by type, whether or not their kind is set.
+		for _, content := range []map[string]interface{}{desired, actual} {
+			delete(content, "apiVersion")
+			delete(content, "kind")
+		}
+
+		if diff := cmp.Diff(prune(actual, desired), desired); diff != "" {
+			differs = true
+
+			fmt.Fprintf(&buffer, "%s %s/%s (-live +rendered):\n%s", kind, liveObject.GetNamespace(), liveObject.GetName(), diff)
+		}
+	}
+
+	_, err := buffer.WriteTo(writer)
+
+	return differs, errors.Wrap(err, "failed to write diff")
+}
+
+func findLive(object client.Object, live []client.Object, matched map[client.Object]bool) client.Object {
+	for _, liveObject := range live {
+		if matched[liveObject] ||
+			reflect.TypeOf(liveObject) != reflect.TypeOf(object) ||
+			liveObject.GetNamespace() != object.GetNamespace() {
+			continue
+		}
+
+		if liveObject.GetName() == object.GetName() {
+			return liveObject
+		}
+
+		generateName := object.GetGenerateName()
+		if generateName != "" && liveObject.GetGenerateName() == generateName && strings.HasPrefix(liveObject.GetName(), generateName) {
+			return liveObject
+		}
+	}
+
+	return nil
+}
+
+// desiredContent returns the content of the rendered object without the
+// fields set by the API server.
+func desiredContent(object client.Object) (map[string]interface{}, error) {
+	content, err := toUnstructured(object)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, _ := content["metadata"].(map[string]interface{})
+	for _, field := range serverMetadata {
+		delete(metadata, field)
+	}
+
+	ownerReferences, _ := metadata["ownerReferences"].([]interface{})
+	for _, ownerReference := range ownerReferences {
+		delete(ownerReference.(map[string]interface{}), "uid") //nolint:forcetypeassert
+	}
+
+	encodeStringData(content)
+
+	return content, nil
+}
+
+// encodeStringData moves the string data of secrets to their data, as the
+// API server stores it.
+func encodeStringData(content map[string]interface{}) {
+	stringData, ok := content["stringData"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	data, _ := content["data"].(map[string]interface{})
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+
+	for key, value := range stringData {
+		data[key] = base64.StdEncoding.EncodeToString([]byte(fmt.Sprint(value)))
+	}
+
+	content["data"] = data
+	delete(content, "stringData")
+}
+
+// prune returns the live value restricted to the fields of the desired one.
+func prune(live, desired interface{}) interface{} {
+	switch desired := desired.(type) {
+	case map[string]interface{}:
+		liveMap, ok := live.(map[string]interface{})
+		if !ok {
+			return live
+		}
+
+		pruned := map[string]interface{}{}
+
+		for key, value := range desired {
+			if liveValue, found := liveMap[key]; found {
+				pruned[key] = prune(liveValue, value)
+			}
+		}
+
+		return pruned
+	case []interface{}:
+		liveSlice, ok := live.([]interface{})
+		if !ok || len(liveSlice) != len(desired) {
+			return live
+		}
+
+		pruned := make([]interface{}, len(liveSlice))
+		for i := range liveSlice {
+			pruned[i] = prune(liveSlice[i], desired[i])
+		}
+
+		return pruned
+	}
+
+	return live
+}
+
+// rename replaces the strings of the value that are renamed.
+func rename(value interface{}, renames map[string]string) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		replaced := map[string]interface{}{}
+		for key, item := range value {
+			replaced[key] = rename(item, renames)
+		}
+
+		return replaced
+	case []interface{}:
+		replaced := make([]interface{}, len(value))
+		for i, item := range value {
+			replaced[i] = rename(item, renames)
+		}
+
+		return replaced
+	case string:
+		if replacement, ok := renames[value]; ok {
+			return replacement
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,134 @@
+package render_test
+
+import (
+	"bytes"
+	"strings"
+
+	"code.cloudfoundry.org/eirini-controller/render"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Diff", func() {
+	var (
+		renderedSecret      *corev1.Secret
+		renderedStatefulSet *appsv1.StatefulSet
+		liveSecret          *corev1.Secret
+		liveStatefulSet     *appsv1.StatefulSet
+		live                []client.Object
+		output              bytes.Buffer
+		differs             bool
+		err                 error
+	)
+
+	BeforeEach(func() {
+		renderedSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "registry-abcde", GenerateName: "registry-"},
+			StringData: map[string]string{"config": "value"},
+		}
+		renderedSecret.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+
+		renderedStatefulSet = &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       "apps",
+				Name:            "my-statefulset",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "LRP", Name: "my-lrp"}},
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: pointer.Int32(2),
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry-abcde"}},
+					},
+				},
+			},
+		}
+		renderedStatefulSet.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("StatefulSet"))
+
+		liveSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "registry-xyz12", GenerateName: "registry-", UID: "secret-uid"},
+			Data:       map[string][]byte{"config": []byte("value")},
+		}
+
+		liveStatefulSet = renderedStatefulSet.DeepCopy()
+		liveStatefulSet.UID = "statefulset-uid"
+		liveStatefulSet.ResourceVersion = "42"
+		liveStatefulSet.Labels = map[string]string{"added-by": "someone-else"}
+		liveStatefulSet.OwnerReferences[0].UID = "lrp-uid"
+		liveStatefulSet.Spec.Template.Spec.ImagePullSecrets[0].Name = "registry-xyz12"
+		liveStatefulSet.Spec.Template.Spec.DNSPolicy = corev1.DNSClusterFirst
+		liveStatefulSet.Status.Replicas = 2
+
+		live = []client.Object{liveStatefulSet, liveSecret}
+	})
+
+	JustBeforeEach(func() {
+		output.Reset()
+		differs, err = render.Diff(&output, []client.Object{renderedSecret, renderedStatefulSet}, live)
+	})
+
+	It("ignores the fields the controller does not set", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(differs).To(BeFalse())
+		Expect(output.String()).To(BeEmpty())
+	})
+
+	When("a field differs", func() {
+		BeforeEach(func() {
+			liveStatefulSet.Spec.Replicas = pointer.Int32(3)
+		})
+
+		It("reports it", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(differs).To(BeTrue())
+			Expect(output.String()).To(HavePrefix("StatefulSet apps/my-statefulset (-live +rendered):\n"))
+			Expect(normalized(output.String())).To(ContainSubstring(`- "replicas": int64(3)`))
+			Expect(normalized(output.String())).To(ContainSubstring(`+ "replicas": int64(2)`))
+		})
+	})
+
+	When("the secret data differs", func() {
+		BeforeEach(func() {
+			liveSecret.Data["config"] = []byte("other-value")
+		})
+
+		It("reports it under the live name", func() {
+			Expect(differs).To(BeTrue())
+			Expect(output.String()).To(HavePrefix("Secret apps/registry-xyz12 (-live +rendered):\n"))
+		})
+	})
+
+	When("a rendered object has no live one", func() {
+		BeforeEach(func() {
+			live = []client.Object{liveStatefulSet}
+		})
+
+		It("reports it as missing", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(differs).To(BeTrue())
+			Expect(output.String()).To(ContainSubstring("Secret apps/registry-abcde: not found in the live objects\n"))
+		})
+	})
+
+	When("the live object is in another namespace", func() {
+		BeforeEach(func() {
+			liveStatefulSet.Namespace = "other"
+		})
+
+		It("does not match it", func() {
+			Expect(differs).To(BeTrue())
+			Expect(output.String()).To(ContainSubstring("StatefulSet apps/my-statefulset: not found in the live objects\n"))
+		})
+	})
+})
+
+// normalized collapses the whitespace of a diff, as go-cmp randomly mixes in
+// non-breaking spaces to keep its output from being relied upon.
+func normalized(diff string) string {
+	return strings.Join(strings.Fields(diff), " ")
+}
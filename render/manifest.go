@@ -0,0 +1,125 @@
+package render
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// ReadObjects decodes the objects of a stream of YAML or JSON documents.
+// Lists, such as the output of kubectl get, are expanded into their items.
+func ReadObjects(reader io.Reader, scheme *runtime.Scheme) ([]client.Object, error) {
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	yamlReader := utilyaml.NewYAMLReader(bufio.NewReader(reader))
+	objects := []client.Object{}
+
+	for {
+		document, err := yamlReader.Read()
+		if errors.Is(err, io.EOF) {
+			return objects, nil
+		}
+
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read document")
+		}
+
+		if isEmpty(document) {
+			continue
+		}
+
+		documentObjects, err := decodeObjects(decoder, document)
+		if err != nil {
+			return nil, err
+		}
+
+		objects = append(objects, documentObjects...)
+	}
+}
+
+func decodeObjects(decoder runtime.Decoder, document []byte) ([]client.Object, error) {
+	decoded, _, err := decoder.Decode(document, nil, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode object")
+	}
+
+	if list, ok := decoded.(*corev1.List); ok {
+		objects := []client.Object{}
+
+		for _, item := range list.Items {
+			itemObjects, err := decodeObjects(decoder, item.Raw)
+			if err != nil {
+				return nil, err
+			}
+
+			objects = append(objects, itemObjects...)
+		}
+
+		return objects, nil
+	}
+
+	object, ok := decoded.(client.Object)
+	if !ok {
+		return nil, errors.Errorf("%T is not a Kubernetes object", decoded)
+	}
+
+	return []client.Object{object}, nil
+}
+
+func isEmpty(document []byte) bool {
+	var content interface{}
+
+	return yaml.Unmarshal(document, &content) == nil && content == nil
+}
+
+// WriteObjects writes the objects as a stream of YAML documents.
+func WriteObjects(writer io.Writer, objects []client.Object) error {
+	var buffer bytes.Buffer
+
+	for i, object := range objects {
+		content, err := toUnstructured(object)
+		if err != nil {
+			return err
+		}
+
+		document, err := yaml.Marshal(content)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal object")
+		}
+
+		if i > 0 {
+			buffer.WriteString("---\n")
+		}
+
+		buffer.Write(document)
+	}
+
+	_, err := buffer.WriteTo(writer)
+
+	return errors.Wrap(err, "failed to write objects")
+}
+
+// toUnstructured returns the content of the object without its status,
+// which is not set on creation, and without the empty creation timestamp of
+// objects that were never persisted.
+func toUnstructured(object client.Object) (map[string]interface{}, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert object")
+	}
+
+	if metadata, ok := content["metadata"].(map[string]interface{}); ok && metadata["creationTimestamp"] == nil {
+		delete(metadata, "creationTimestamp")
+	}
+
+	delete(content, "status")
+
+	return content, nil
+}
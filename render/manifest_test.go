@@ -0,0 +1,117 @@
+package render_test
+
+import (
+	"bytes"
+	"strings"
+
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"code.cloudfoundry.org/eirini-controller/render"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Manifests", func() {
+	Describe("ReadObjects", func() {
+		var (
+			manifest string
+			objects  []client.Object
+			err      error
+		)
+
+		JustBeforeEach(func() {
+			objects, err = render.ReadObjects(strings.NewReader(manifest), newScheme())
+		})
+
+		When("the manifest has several documents", func() {
+			BeforeEach(func() {
+				manifest = `
+# the workload
+apiVersion: eirini.cloudfoundry.org/v1
+kind: LRP
+metadata:
+  name: my-lrp
+spec:
+  GUID: guid
+---
+---
+apiVersion: eirini.cloudfoundry.org/v1
+kind: WorkloadDefaults
+metadata:
+  name: default
+`
+			})
+
+			It("decodes the typed objects, skipping the empty documents", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(objects).To(HaveLen(2))
+
+				lrp, ok := objects[0].(*eiriniv1.LRP)
+				Expect(ok).To(BeTrue())
+				Expect(lrp.Spec.GUID).To(Equal("guid"))
+				Expect(objects[1]).To(BeAssignableToTypeOf(&eiriniv1.WorkloadDefaults{}))
+			})
+		})
+
+		When("the manifest is a list", func() {
+			BeforeEach(func() {
+				manifest = `
+apiVersion: v1
+kind: List
+items:
+- apiVersion: apps/v1
+  kind: StatefulSet
+  metadata:
+    name: my-statefulset
+- apiVersion: v1
+  kind: Secret
+  metadata:
+    name: my-secret
+`
+			})
+
+			It("decodes its items", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(objects).To(HaveLen(2))
+				Expect(objects[0]).To(BeAssignableToTypeOf(&appsv1.StatefulSet{}))
+				Expect(objects[1]).To(BeAssignableToTypeOf(&corev1.Secret{}))
+			})
+		})
+
+		When("the manifest has an unknown kind", func() {
+			BeforeEach(func() {
+				manifest = "apiVersion: example.com/v1\nkind: Unknown\n"
+			})
+
+			It("returns an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("failed to decode object")))
+			})
+		})
+	})
+
+	Describe("WriteObjects", func() {
+		It("writes the objects as YAML documents without status or creation timestamp", func() {
+			statefulSet := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "my-statefulset", Namespace: "apps"}}
+			statefulSet.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("StatefulSet"))
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "my-secret", Namespace: "apps"}}
+			secret.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+
+			var buffer bytes.Buffer
+			Expect(render.WriteObjects(&buffer, []client.Object{statefulSet, secret})).To(Succeed())
+
+			Expect(buffer.String()).To(HavePrefix("apiVersion: apps/v1\nkind: StatefulSet\n"))
+			Expect(buffer.String()).To(ContainSubstring("\n---\napiVersion: v1\nkind: Secret\n"))
+			Expect(buffer.String()).NotTo(ContainSubstring("status"))
+			Expect(buffer.String()).To(ContainSubstring("metadata:\n  name: my-statefulset\n  namespace: apps\nspec:\n"))
+
+			objects, err := render.ReadObjects(&buffer, newScheme())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(objects).To(HaveLen(2))
+			Expect(objects[0].GetName()).To(Equal("my-statefulset"))
+			Expect(objects[1].GetName()).To(Equal("my-secret"))
+		})
+	})
+})
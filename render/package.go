@@ -0,0 +1,4 @@
+// Package render shows the objects the controller creates for an LRP or a
+// task, by running its desirers against an in-memory cluster, and compares
+// them with the live objects of a cluster.
+package render
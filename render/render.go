@@ -0,0 +1,168 @@
+package render
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/eirini-controller/k8s"
+	"code.cloudfoundry.org/eirini-controller/k8s/webhook"
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	eiriniv2 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v2"
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// DefaultNamespace is the namespace of the objects that do not set one.
+const DefaultNamespace = "default"
+
+type LRPDesirer interface {
+	Desire(ctx context.Context, lrp *eiriniv1.LRP) error
+}
+
+type TaskDesirer interface {
+	Desire(ctx context.Context, task *eiriniv1.Task) (*batchv1.Job, error)
+}
+
+// LRPDesirerFactory creates an LRP desirer working with the given client.
+type LRPDesirerFactory func(client.Client) (LRPDesirer, error)
+
+// TaskDesirerFactory creates a task desirer working with the given client.
+type TaskDesirerFactory func(client.Client) (TaskDesirer, error)
+
+type Renderer struct {
+	scheme         *runtime.Scheme
+	newLRPDesirer  LRPDesirerFactory
+	newTaskDesirer TaskDesirerFactory
+}
+
+func NewRenderer(scheme *runtime.Scheme, newLRPDesirer LRPDesirerFactory, newTaskDesirer TaskDesirerFactory) *Renderer {
+	return &Renderer{
+		scheme:         scheme,
+		newLRPDesirer:  newLRPDesirer,
+		newTaskDesirer: newTaskDesirer,
+	}
+}
+
+// Render returns the objects the controller creates for the single LRP or
+// task among the objects, in the order it creates them. The workload is
+// defaulted as by the admission webhook first. The other objects, such as
+// the WorkloadDefaults of the namespace, are put in the in-memory cluster
+// beforehand.
+func (r *Renderer) Render(ctx context.Context, objects []client.Object) ([]client.Object, error) {
+	var (
+		workload client.Object
+		existing []client.Object
+	)
+
+	for _, object := range objects {
+		object = object.DeepCopyObject().(client.Object) //nolint:forcetypeassert
+		if object.GetNamespace() == "" {
+			object.SetNamespace(DefaultNamespace)
+		}
+
+		switch object.(type) {
+		case *eiriniv1.LRP, *eiriniv2.LRP, *eiriniv1.Task, *eiriniv2.Task:
+			if workload != nil {
+				return nil, errors.New("expected a single LRP or Task, found several")
+			}
+
+			workload = object
+		default:
+			existing = append(existing, object)
+		}
+	}
+
+	if workload == nil {
+		return nil, errors.New("expected an LRP or a Task, found none")
+	}
+
+	recorder := &recordingClient{
+		Client: fake.NewClientBuilder().WithScheme(r.scheme).WithObjects(existing...).Build(),
+	}
+
+	if err := r.desire(ctx, recorder, workload); err != nil {
+		return nil, err
+	}
+
+	for _, object := range recorder.created {
+		gvk, err := apiutil.GVKForObject(object, r.scheme)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get the kind of a created object")
+		}
+
+		object.GetObjectKind().SetGroupVersionKind(gvk)
+		object.SetResourceVersion("")
+	}
+
+	return recorder.created, nil
+}
+
+func (r *Renderer) desire(ctx context.Context, controllerClient client.Client, workload client.Object) error {
+	switch workload := workload.(type) {
+	case *eiriniv2.LRP:
+		lrp := &eiriniv1.LRP{}
+		if err := workload.ConvertTo(lrp); err != nil {
+			return errors.Wrap(err, "failed to convert LRP to v1")
+		}
+
+		return r.desire(ctx, controllerClient, lrp)
+	case *eiriniv2.Task:
+		task := &eiriniv1.Task{}
+		if err := workload.ConvertTo(task); err != nil {
+			return errors.Wrap(err, "failed to convert Task to v1")
+		}
+
+		return r.desire(ctx, controllerClient, task)
+	case *eiriniv1.LRP:
+		defaults, err := k8s.GetWorkloadDefaults(ctx, controllerClient, workload.Namespace)
+		if err != nil {
+			return err
+		}
+
+		webhook.DefaultLRP(workload, defaults)
+
+		desirer, err := r.newLRPDesirer(controllerClient)
+		if err != nil {
+			return errors.Wrap(err, "failed to create LRP desirer")
+		}
+
+		return errors.Wrap(desirer.Desire(ctx, workload), "failed to desire LRP")
+	case *eiriniv1.Task:
+		defaults, err := k8s.GetWorkloadDefaults(ctx, controllerClient, workload.Namespace)
+		if err != nil {
+			return err
+		}
+
+		webhook.DefaultTask(workload, defaults)
+
+		desirer, err := r.newTaskDesirer(controllerClient)
+		if err != nil {
+			return errors.Wrap(err, "failed to create Task desirer")
+		}
+
+		_, err = desirer.Desire(ctx, workload)
+
+		return errors.Wrap(err, "failed to desire Task")
+	}
+
+	return errors.Errorf("unsupported workload %T", workload)
+}
+
+// recordingClient records the objects created through it.
+type recordingClient struct {
+	client.Client
+	created []client.Object
+}
+
+func (c *recordingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := c.Client.Create(ctx, obj, opts...); err != nil {
+		return err
+	}
+
+	c.created = append(c.created, obj)
+
+	return nil
+}
@@ -0,0 +1,264 @@
+package render_test
+
+import (
+	"context"
+
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/cmd/wiring"
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	eiriniv2 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v2"
+	eirinischeme "code.cloudfoundry.org/eirini-controller/pkg/generated/clientset/versioned/scheme"
+	"code.cloudfoundry.org/eirini-controller/render"
+	"code.cloudfoundry.org/eirini-controller/tests"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	Expect(kscheme.AddToScheme(scheme)).To(Succeed())
+	Expect(eirinischeme.AddToScheme(scheme)).To(Succeed())
+
+	return scheme
+}
+
+func newLRP() *eiriniv1.LRP {
+	return &eiriniv1.LRP{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-lrp", Namespace: "apps"},
+		Spec: eiriniv1.LRPSpec{
+			GUID:      "guid",
+			Version:   "version",
+			AppName:   "app",
+			AppGUID:   "app-guid",
+			SpaceName: "space",
+			Image:     "registry.example.com/app:latest",
+			Instances: 2,
+			MemoryMB:  256,
+			DiskMB:    512,
+		},
+	}
+}
+
+var _ = Describe("Renderer", func() {
+	var (
+		scheme   *runtime.Scheme
+		cfg      eirinictrl.ControllerConfig
+		objects  []client.Object
+		rendered []client.Object
+		err      error
+	)
+
+	BeforeEach(func() {
+		scheme = newScheme()
+		cfg = eirinictrl.ControllerConfig{}
+		objects = []client.Object{newLRP()}
+	})
+
+	JustBeforeEach(func() {
+		logger := tests.NewTestLogger("render-test")
+		renderer := render.NewRenderer(
+			scheme,
+			func(controllerClient client.Client) (render.LRPDesirer, error) {
+				return wiring.NewLRPDesirer(logger, controllerClient, cfg, scheme)
+			},
+			func(controllerClient client.Client) (render.TaskDesirer, error) {
+				return wiring.NewTaskDesirer(logger, controllerClient, cfg, scheme)
+			},
+		)
+
+		rendered, err = renderer.Render(context.Background(), objects)
+	})
+
+	It("renders the StatefulSet and PDB of the LRP", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rendered).To(HaveLen(2))
+
+		statefulSet, ok := rendered[0].(*appsv1.StatefulSet)
+		Expect(ok).To(BeTrue())
+		Expect(statefulSet.Namespace).To(Equal("apps"))
+		Expect(statefulSet.Kind).To(Equal("StatefulSet"))
+		Expect(statefulSet.APIVersion).To(Equal("apps/v1"))
+		Expect(statefulSet.ResourceVersion).To(BeEmpty())
+		Expect(*statefulSet.Spec.Replicas).To(BeEquivalentTo(2))
+		Expect(statefulSet.OwnerReferences).To(ConsistOf(HaveField("Name", "my-lrp")))
+
+		Expect(rendered[1]).To(BeAssignableToTypeOf(&policyv1.PodDisruptionBudget{}))
+		Expect(rendered[1].GetName()).To(Equal(statefulSet.Name))
+	})
+
+	It("defaults the LRP as the admission webhook does", func() {
+		Expect(err).NotTo(HaveOccurred())
+
+		statefulSet, ok := rendered[0].(*appsv1.StatefulSet)
+		Expect(ok).To(BeTrue())
+		Expect(statefulSet.Spec.Template.Spec.Containers[0].Resources.Requests.Cpu().IsZero()).To(BeFalse())
+	})
+
+	It("does not change the given objects", func() {
+		Expect(objects[0].GetResourceVersion()).To(BeEmpty())
+		Expect(objects[0].GetNamespace()).To(Equal("apps"))
+	})
+
+	When("the LRP uses a private registry", func() {
+		BeforeEach(func() {
+			lrp := newLRP()
+			lrp.Spec.PrivateRegistry = &eiriniv1.PrivateRegistry{Username: "user", Password: "pass"}
+			objects = []client.Object{lrp}
+		})
+
+		It("renders the registry secret first", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rendered).To(HaveLen(3))
+
+			secret, ok := rendered[0].(*corev1.Secret)
+			Expect(ok).To(BeTrue())
+			Expect(secret.Type).To(Equal(corev1.SecretTypeDockerConfigJson))
+			Expect(secret.OwnerReferences).To(ConsistOf(HaveField("Kind", "StatefulSet")))
+
+			statefulSet, ok := rendered[1].(*appsv1.StatefulSet)
+			Expect(ok).To(BeTrue())
+			Expect(statefulSet.Spec.Template.Spec.ImagePullSecrets).To(ContainElement(corev1.LocalObjectReference{Name: secret.Name}))
+		})
+	})
+
+	When("the namespace has workload defaults", func() {
+		BeforeEach(func() {
+			objects = append(objects, &eiriniv1.WorkloadDefaults{
+				ObjectMeta: metav1.ObjectMeta{Name: eiriniv1.WorkloadDefaultsName, Namespace: "apps"},
+				Spec:       eiriniv1.WorkloadDefaultsSpec{RegistrySecretName: "namespace-registry"},
+			})
+		})
+
+		It("applies them", func() {
+			Expect(err).NotTo(HaveOccurred())
+
+			statefulSet, ok := rendered[0].(*appsv1.StatefulSet)
+			Expect(ok).To(BeTrue())
+			Expect(statefulSet.Spec.Template.Spec.ImagePullSecrets).To(ContainElement(corev1.LocalObjectReference{Name: "namespace-registry"}))
+		})
+	})
+
+	When("the config sets the minimum of available instances", func() {
+		BeforeEach(func() {
+			cfg.DefaultMinAvailableInstances = "1"
+		})
+
+		It("renders the PDB with it", func() {
+			Expect(err).NotTo(HaveOccurred())
+
+			podDisruptionBudget, ok := rendered[1].(*policyv1.PodDisruptionBudget)
+			Expect(ok).To(BeTrue())
+			Expect(podDisruptionBudget.Spec.MinAvailable.IntValue()).To(Equal(1))
+		})
+	})
+
+	When("the LRP has no namespace", func() {
+		BeforeEach(func() {
+			lrp := newLRP()
+			lrp.Namespace = ""
+			objects = []client.Object{lrp}
+		})
+
+		It("renders it in the default namespace", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rendered[0].GetNamespace()).To(Equal(render.DefaultNamespace))
+		})
+	})
+
+	When("the LRP is a v2 one", func() {
+		BeforeEach(func() {
+			objects = []client.Object{&eiriniv2.LRP{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-lrp", Namespace: "apps"},
+				Spec: eiriniv2.LRPSpec{
+					GUID:      "guid",
+					Version:   "version",
+					AppName:   "app",
+					SpaceName: "space",
+					Image:     "registry.example.com/app:latest",
+					Instances: 1,
+					Memory:    resource.MustParse("256Mi"),
+					Disk:      resource.MustParse("512Mi"),
+				},
+			}}
+		})
+
+		It("renders it as its v1 version", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rendered).To(HaveLen(1))
+			Expect(rendered[0]).To(BeAssignableToTypeOf(&appsv1.StatefulSet{}))
+		})
+	})
+
+	When("rendering a Task", func() {
+		BeforeEach(func() {
+			objects = []client.Object{&eiriniv1.Task{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-task", Namespace: "apps"},
+				Spec: eiriniv1.TaskSpec{
+					GUID:     "task-guid",
+					Name:     "task",
+					AppName:  "app",
+					Image:    "registry.example.com/task:latest",
+					MemoryMB: 256,
+					DiskMB:   512,
+				},
+			}}
+		})
+
+		It("renders its Job", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rendered).To(HaveLen(1))
+
+			job, ok := rendered[0].(*batchv1.Job)
+			Expect(ok).To(BeTrue())
+			Expect(job.Kind).To(Equal("Job"))
+			Expect(job.OwnerReferences).To(ConsistOf(HaveField("Name", "my-task")))
+		})
+
+		It("defaults its CPU as the admission webhook does", func() {
+			Expect(err).NotTo(HaveOccurred())
+
+			job, ok := rendered[0].(*batchv1.Job)
+			Expect(ok).To(BeTrue())
+			Expect(job.Spec.Template.Spec.Containers[0].Resources.Requests.Cpu().MilliValue()).To(BeEquivalentTo(31))
+		})
+	})
+
+	When("there is no workload", func() {
+		BeforeEach(func() {
+			objects = []client.Object{}
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError("expected an LRP or a Task, found none"))
+		})
+	})
+
+	When("there are several workloads", func() {
+		BeforeEach(func() {
+			objects = []client.Object{newLRP(), newLRP()}
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError("expected a single LRP or Task, found several"))
+		})
+	})
+
+	When("the config is invalid", func() {
+		BeforeEach(func() {
+			cfg.TopologySpreadPolicy = "strict"
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError(ContainSubstring("failed to create LRP desirer")))
+		})
+	})
+})
@@ -0,0 +1,167 @@
+package doctor_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/doctor"
+	"code.cloudfoundry.org/eirini-controller/render"
+	"code.cloudfoundry.org/eirini-controller/tests"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const releaseNamespace = "eirini-controller"
+
+var chartTemplatesDir = filepath.Join("..", "..", "..", "deployment", "helm", "templates", "core")
+
+// renderChartTemplate renders a template of the Helm chart with the values
+// the doctor checks.
+func renderChartTemplate(name, caBundle string) []client.Object {
+	content, err := os.ReadFile(filepath.Join(chartTemplatesDir, name))
+	Expect(err).NotTo(HaveOccurred())
+
+	chartTemplate, err := template.New(name).Parse(string(content))
+	Expect(err).NotTo(HaveOccurred())
+
+	var manifest bytes.Buffer
+	Expect(chartTemplate.Execute(&manifest, map[string]interface{}{
+		"Release": map[string]interface{}{"Namespace": releaseNamespace},
+		"Values":  map[string]interface{}{"webhooks": map[string]interface{}{"ca_bundle": caBundle}},
+	})).To(Succeed())
+
+	objects, err := render.ReadObjects(&manifest, scheme)
+	Expect(err).NotTo(HaveOccurred())
+
+	return objects
+}
+
+func chartCRDs(caBundle string) []*apiextensionsv1.CustomResourceDefinition {
+	crds := []*apiextensionsv1.CustomResourceDefinition{}
+
+	for _, name := range []string{"lrp-crd.yml", "task-crd.yml", "workloaddefaults-crd.yml"} {
+		for _, object := range renderChartTemplate(name, caBundle) {
+			crd, ok := object.(*apiextensionsv1.CustomResourceDefinition)
+			Expect(ok).To(BeTrue())
+
+			crds = append(crds, crd)
+		}
+	}
+
+	return crds
+}
+
+func runDoctor(cfg eirinictrl.ControllerConfig) []doctor.Result {
+	results := doctor.NewDoctor(controllerClient, cfg).Run(context.Background())
+
+	var report bytes.Buffer
+	Expect(doctor.WriteReport(&report, results)).To(Succeed())
+	GinkgoWriter.Print(report.String())
+
+	return results
+}
+
+var _ = Describe("Doctor", Ordered, func() {
+	var cfg eirinictrl.ControllerConfig
+
+	BeforeAll(func() {
+		cfg = eirinictrl.ControllerConfig{
+			ApplicationServiceAccount: tests.DefaultApplicationServiceAccount,
+			RegistrySecretName:        "registry-secret",
+			WorkloadsNamespaces:       []string{"apps"},
+		}
+	})
+
+	When("only the CRDs are installed", func() {
+		It("reports the missing prerequisites", func() {
+			results := runDoctor(cfg)
+
+			Expect(doctor.Failed(results)).To(BeTrue())
+			Expect(results).To(ContainElement(SatisfyAll(
+				HaveField("Check", "CRD workloaddefaults.eirini.cloudfoundry.org"),
+				HaveField("Status", doctor.StatusOK),
+			)))
+			Expect(results).To(ContainElement(SatisfyAll(
+				HaveField("Check", "CRD lrps.eirini.cloudfoundry.org"),
+				HaveField("Message", ContainSubstring("the conversion webhook has no caBundle")),
+			)))
+			Expect(results).To(ContainElement(SatisfyAll(
+				HaveField("Check", "webhook lrp-defaulter.eirini.cloudfoundry.org"),
+				HaveField("Message", "not found"),
+			)))
+			Expect(results).To(ContainElement(SatisfyAll(
+				HaveField("Check", "workloads namespace apps"),
+				HaveField("Status", doctor.StatusFailed),
+			)))
+		})
+	})
+
+	When("the chart and the workloads namespace are installed", func() {
+		BeforeAll(func() {
+			ctx := context.Background()
+
+			_, caPEM := tests.GenerateKeyPairDir("eirini-webhooks", "eirini-webhooks."+releaseNamespace+".svc")
+			caBundle := base64.StdEncoding.EncodeToString(caPEM)
+
+			objects := []client.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: releaseNamespace}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "apps"}},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "registry-secret"},
+					Type:       corev1.SecretTypeDockerConfigJson,
+					StringData: map[string]string{corev1.DockerConfigJsonKey: "{}"},
+				},
+				&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: tests.DefaultApplicationServiceAccount}},
+			}
+
+			for _, name := range []string{
+				"webhooks-service.yml",
+				"resource-defaulter-webhook.yml",
+				"resource-validator-webhook.yml",
+				"instance-index-env-injector-webhook.yml",
+			} {
+				objects = append(objects, renderChartTemplate(name, caBundle)...)
+			}
+
+			for _, object := range objects {
+				Expect(controllerClient.Create(ctx, object)).To(Succeed())
+			}
+
+			for _, crd := range chartCRDs(caBundle) {
+				existing := &apiextensionsv1.CustomResourceDefinition{}
+				Expect(controllerClient.Get(ctx, client.ObjectKeyFromObject(crd), existing)).To(Succeed())
+
+				existing.Spec.Conversion = crd.Spec.Conversion
+				Expect(controllerClient.Update(ctx, existing)).To(Succeed())
+			}
+		})
+
+		It("passes", func() {
+			results := runDoctor(cfg)
+
+			Expect(results).To(HaveEach(HaveField("Status", doctor.StatusOK)))
+		})
+
+		When("the registry secret is missing", func() {
+			It("fails", func() {
+				cfg.RegistrySecretName = "missing-secret"
+				results := runDoctor(cfg)
+
+				Expect(doctor.Failed(results)).To(BeTrue())
+				Expect(results).To(ContainElement(SatisfyAll(
+					HaveField("Check", "registry secret apps/missing-secret"),
+					HaveField("Status", doctor.StatusFailed),
+				)))
+			})
+		})
+	})
+})
@@ -0,0 +1,40 @@
+package generated_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"code.cloudfoundry.org/eirini-controller/tests"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+)
+
+func TestGenerated(t *testing.T) {
+	SetDefaultEventuallyTimeout(30 * time.Second)
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Generated Clients Suite")
+}
+
+var (
+	fixture *tests.Fixture
+	ctx     context.Context
+)
+
+var _ = BeforeSuite(func() {
+	fixture = tests.NewFixture(GinkgoWriter)
+})
+
+var _ = BeforeEach(func() {
+	fixture.SetUp()
+	ctx = context.Background()
+})
+
+var _ = AfterEach(func() {
+	fixture.TearDown()
+})
+
+var _ = AfterSuite(func() {
+	fixture.Destroy()
+})
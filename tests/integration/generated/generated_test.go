@@ -0,0 +1,111 @@
+package generated_test
+
+import (
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	eiriniv1ac "code.cloudfoundry.org/eirini-controller/pkg/generated/applyconfiguration/eirini/v1"
+	"code.cloudfoundry.org/eirini-controller/pkg/generated/informers/externalversions"
+	"code.cloudfoundry.org/eirini-controller/tests"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const fieldManager = "eirini-generated-test"
+
+var _ = Describe("Generated clients", func() {
+	var (
+		lrpName string
+		lrpSpec *eiriniv1ac.LRPSpecApplyConfiguration
+	)
+
+	BeforeEach(func() {
+		lrpName = tests.GenerateGUID()
+		lrpSpec = eiriniv1ac.LRPSpec().
+			WithGUID(tests.GenerateGUID()).
+			WithVersion(tests.GenerateGUID()).
+			WithImage("eirini/dorini").
+			WithAppGUID("the-app-guid").
+			WithAppName("k-2so").
+			WithSpaceName("s").
+			WithOrgName("o").
+			WithMemoryMB(256).
+			WithDiskMB(256).
+			WithCPUWeight(10).
+			WithInstances(1).
+			WithPorts(8080)
+	})
+
+	applyLRP := func(spec *eiriniv1ac.LRPSpecApplyConfiguration) (*eiriniv1.LRP, error) {
+		return fixture.EiriniClientset.EiriniV1().LRPs(fixture.Namespace).Apply(ctx,
+			eiriniv1ac.LRP(lrpName, fixture.Namespace).WithSpec(spec),
+			metav1.ApplyOptions{FieldManager: fieldManager},
+		)
+	}
+
+	Describe("server-side apply", func() {
+		It("creates the LRP", func() {
+			lrp, err := applyLRP(lrpSpec)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lrp.Spec.Instances).To(Equal(1))
+			Expect(lrp.ManagedFields).To(ContainElement(HaveField("Manager", fieldManager)))
+		})
+
+		It("updates the applied fields", func() {
+			_, err := applyLRP(lrpSpec)
+			Expect(err).NotTo(HaveOccurred())
+
+			lrp, err := applyLRP(lrpSpec.WithInstances(3))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lrp.Spec.Instances).To(Equal(3))
+		})
+	})
+
+	Describe("shared informers", func() {
+		var (
+			factory externalversions.SharedInformerFactory
+			stopCh  chan struct{}
+		)
+
+		BeforeEach(func() {
+			factory = externalversions.NewSharedInformerFactoryWithOptions(fixture.EiriniClientset, 0, externalversions.WithNamespace(fixture.Namespace))
+			stopCh = make(chan struct{})
+		})
+
+		AfterEach(func() {
+			close(stopCh)
+		})
+
+		It("lists the LRPs and tasks through the listers", func() {
+			lrpLister := factory.Eirini().V1().LRPs().Lister()
+			taskLister := factory.Eirini().V1().Tasks().Lister()
+
+			factory.Start(stopCh)
+			for informerType, synced := range factory.WaitForCacheSync(stopCh) {
+				Expect(synced).To(BeTrue(), "informer for %v did not sync", informerType)
+			}
+
+			_, err := applyLRP(lrpSpec)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = fixture.EiriniClientset.EiriniV1().Tasks(fixture.Namespace).Apply(ctx,
+				eiriniv1ac.Task("the-task", fixture.Namespace).WithSpec(eiriniv1ac.TaskSpec().
+					WithGUID(tests.GenerateGUID()).
+					WithName("the-task").
+					WithImage("eirini/dorini").
+					WithCommand("/notdora"),
+				),
+				metav1.ApplyOptions{FieldManager: fieldManager},
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(func() (*eiriniv1.LRP, error) {
+				return lrpLister.LRPs(fixture.Namespace).Get(lrpName)
+			}).Should(HaveField("Spec.Image", "eirini/dorini"))
+
+			Eventually(func() ([]*eiriniv1.Task, error) {
+				return taskLister.Tasks(fixture.Namespace).List(labels.Everything())
+			}).Should(ConsistOf(HaveField("Name", "the-task")))
+		})
+	})
+})
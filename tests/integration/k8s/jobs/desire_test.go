@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/k8s"
 	"code.cloudfoundry.org/eirini-controller/k8s/jobs"
 	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
 	eirinischeme "code.cloudfoundry.org/eirini-controller/pkg/generated/clientset/versioned/scheme"
@@ -126,11 +128,19 @@ func createTaskDesirer() *jobs.Desirer {
 	logger := lager.NewLogger("task-desirer")
 	logger.RegisterSink(lager.NewPrettySink(os.Stdout, lager.DEBUG))
 
+	securityPolicy, err := k8s.NewSecurityPolicy(eirinictrl.SecurityProfile{}, nil)
+	Expect(err).NotTo(HaveOccurred())
+
+	workloadIdentity, err := k8s.NewWorkloadIdentity(tests.GetApplicationServiceAccount(), eirinictrl.WorkloadIdentity{})
+	Expect(err).NotTo(HaveOccurred())
+
 	taskToJobConverter := jobs.NewTaskToJobConverter(
-		tests.GetApplicationServiceAccount(),
+		workloadIdentity,
 		"registry-secret",
 		false,
+		nil,
+		securityPolicy,
 	)
 
-	return jobs.NewDesirer(logger, taskToJobConverter, fixture.RuntimeClient, eirinischeme.Scheme)
+	return jobs.NewDesirer(logger, taskToJobConverter, workloadIdentity, fixture.RuntimeClient, eirinischeme.Scheme)
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
 	"code.cloudfoundry.org/eirini-controller/k8s"
 	"code.cloudfoundry.org/eirini-controller/k8s/pdb"
 	"code.cloudfoundry.org/eirini-controller/k8s/stset"
@@ -17,7 +18,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	policyv1beta1 "k8s.io/client-go/kubernetes/typed/policy/v1beta1"
+	policyv1 "k8s.io/client-go/kubernetes/typed/policy/v1"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 )
 
@@ -52,17 +53,27 @@ var _ = AfterSuite(func() {
 func createDesirer(workloadsNamespace string) *stset.Desirer {
 	logger := tests.NewTestLogger("test-" + workloadsNamespace)
 
+	securityPolicy, err := k8s.NewSecurityPolicy(eirinictrl.SecurityProfile{}, nil)
+	Expect(err).NotTo(HaveOccurred())
+
+	workloadIdentity, err := k8s.NewWorkloadIdentity(tests.GetApplicationServiceAccount(), eirinictrl.WorkloadIdentity{})
+	Expect(err).NotTo(HaveOccurred())
+
 	lrpToStatefulSetConverter := stset.NewLRPToStatefulSetConverter(
-		tests.GetApplicationServiceAccount(),
+		workloadIdentity,
 		"registry-secret",
 		false,
+		eiriniv1.TopologySpreadPolicySoft,
+		nil,
+		securityPolicy,
 		k8s.CreateLivenessProbe,
 		k8s.CreateReadinessProbe,
+		k8s.CreateStartupProbe,
 	)
 
-	pdbUpdater := pdb.NewUpdater(fixture.RuntimeClient)
+	pdbUpdater := pdb.NewUpdater(fixture.RuntimeClient, "")
 
-	return stset.NewDesirer(logger, lrpToStatefulSetConverter, pdbUpdater, fixture.RuntimeClient, eirinischeme.Scheme)
+	return stset.NewDesirer(logger, lrpToStatefulSetConverter, pdbUpdater, workloadIdentity, fixture.RuntimeClient, eirinischeme.Scheme)
 }
 
 func labelSelector(lrp *eiriniv1.LRP) string {
@@ -84,8 +95,8 @@ func listPods(lrp *eiriniv1.LRP) []corev1.Pod {
 	return listPodsByLabel(labelSelector(lrp))
 }
 
-func podDisruptionBudgets() policyv1beta1.PodDisruptionBudgetInterface {
-	return fixture.Clientset.PolicyV1beta1().PodDisruptionBudgets(fixture.Namespace)
+func podDisruptionBudgets() policyv1.PodDisruptionBudgetInterface {
+	return fixture.Clientset.PolicyV1().PodDisruptionBudgets(fixture.Namespace)
 }
 
 func podNamesFromPods(pods []corev1.Pod) []string {
@@ -12,6 +12,7 @@ import (
 	. "github.com/onsi/gomega/gstruct"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 var _ = Describe("Update", func() {
@@ -77,6 +78,26 @@ var _ = Describe("Update", func() {
 			})
 		})
 
+		When("the disruption budget of the lrp changes", func() {
+			BeforeEach(func() {
+				instancesBefore = 2
+				instancesAfter = 2
+			})
+
+			JustBeforeEach(func() {
+				maxUnavailable := intstr.FromInt(1)
+				lrp.Spec.DisruptionBudget = &eiriniv1.DisruptionBudget{MaxUnavailable: &maxUnavailable}
+				Expect(updater.Update(ctx, lrp, statefulset)).To(Succeed())
+			})
+
+			It("patches the pod disruption budget", func() {
+				pdb, err := podDisruptionBudgets().Get(context.Background(), statefulset.Name, metav1.GetOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pdb.Spec.MinAvailable).To(BeNil())
+				Expect(pdb.Spec.MaxUnavailable).To(PointTo(Equal(intstr.FromInt(1))))
+			})
+		})
+
 		When("scaling down from 2 to 1 instances", func() {
 			BeforeEach(func() {
 				instancesBefore = 2
@@ -133,7 +154,7 @@ var _ = Describe("Update", func() {
 func createUpdater(workloadsNamespace string) *stset.Updater {
 	logger := tests.NewTestLogger("test-" + workloadsNamespace)
 
-	pdbUpdater := pdb.NewUpdater(fixture.RuntimeClient)
+	pdbUpdater := pdb.NewUpdater(fixture.RuntimeClient, "")
 
 	return stset.NewUpdater(logger, fixture.RuntimeClient, pdbUpdater)
 }
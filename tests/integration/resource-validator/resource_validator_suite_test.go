@@ -70,7 +70,7 @@ var _ = SynchronizedBeforeSuite(func() []byte {
 					AdmissionReviewVersions: []string{"v1beta1"},
 					Rules: []arv1.RuleWithOperations{
 						{
-							Operations: []arv1.OperationType{arv1.Update},
+							Operations: []arv1.OperationType{arv1.Create, arv1.Update},
 							Rule: arv1.Rule{
 								APIGroups:   []string{"eirini.cloudfoundry.org"},
 								APIVersions: []string{"v1"},
@@ -79,6 +79,16 @@ var _ = Describe("ResourceValidator", func() {
 		})
 	})
 
+	When("the health check type is not supported", func() {
+		BeforeEach(func() {
+			lrp.Spec.Health.Type = "telepathy"
+		})
+
+		It("disallows the change", func() {
+			Expect(validationError).To(MatchError(ContainSubstring(`unsupported health check type "telepathy"`)))
+		})
+	})
+
 	When("an immutable field is updated", func() {
 		BeforeEach(func() {
 			lrp.Spec.VolumeMounts[0].MountPath = "foo"
@@ -89,4 +99,33 @@ var _ = Describe("ResourceValidator", func() {
 			Expect(validationError).To(MatchError(ContainSubstring("Changing immutable fields not allowed: VolumeMounts")))
 		})
 	})
+
+	When("creating an LRP with an unsupported health check type", func() {
+		var createErr error
+
+		JustBeforeEach(func() {
+			_, createErr = fixture.EiriniClientset.EiriniV1().LRPs(fixture.Namespace).Create(
+				context.Background(),
+				&eiriniv1.LRP{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: tests.GenerateGUID(),
+					},
+					Spec: eiriniv1.LRPSpec{
+						GUID:    tests.GenerateGUID(),
+						Version: tests.GenerateGUID(),
+						Image:   "eirini/dorini",
+						DiskMB:  256,
+						Health: eiriniv1.Healthcheck{
+							Type: "telepathy",
+						},
+					},
+				},
+				metav1.CreateOptions{},
+			)
+		})
+
+		It("disallows the creation", func() {
+			Expect(createErr).To(MatchError(ContainSubstring(`unsupported health check type "telepathy"`)))
+		})
+	})
 })
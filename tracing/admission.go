@@ -0,0 +1,61 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// AdmissionHandler traces the admission requests handled by a webhook,
+// continuing the trace of the admitted object annotations.
+type AdmissionHandler struct {
+	name    string
+	handler admission.Handler
+}
+
+func NewAdmissionHandler(name string, handler admission.Handler) *AdmissionHandler {
+	return &AdmissionHandler{
+		name:    name,
+		handler: handler,
+	}
+}
+
+func (h *AdmissionHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	obj := &metav1.PartialObjectMetadata{}
+
+	raw := req.Object.Raw
+	if len(raw) == 0 {
+		raw = req.OldObject.Raw
+	}
+
+	if len(raw) > 0 {
+		_ = json.Unmarshal(raw, obj)
+	}
+
+	ctx, span := tracer().Start(Extract(ctx, obj.Annotations), h.name, trace.WithAttributes(
+		attribute.String("admission.operation", string(req.Operation)),
+		AttributeKind.String(req.Kind.Kind),
+		AttributeNamespace.String(req.Namespace),
+		AttributeName.String(req.Name),
+	))
+	defer span.End()
+
+	resp := h.handler.Handle(ctx, req)
+
+	span.SetAttributes(attribute.Bool("admission.allowed", resp.Allowed))
+
+	if !resp.Allowed && resp.Result != nil {
+		reason := resp.Result.Message
+		if reason == "" {
+			reason = string(resp.Result.Reason)
+		}
+
+		span.SetAttributes(attribute.String("admission.reason", reason))
+	}
+
+	return resp
+}
@@ -0,0 +1,101 @@
+package tracing_test
+
+import (
+	"context"
+	"encoding/json"
+
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"code.cloudfoundry.org/eirini-controller/tracing"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var _ = Describe("AdmissionHandler", func() {
+	var (
+		lrp       *eiriniv1.LRP
+		response  admission.Response
+		handled   context.Context
+		resp      admission.Response
+		operation admissionv1.Operation
+	)
+
+	BeforeEach(func() {
+		lrp = &eiriniv1.LRP{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "the-namespace",
+			Name:        "the-lrp",
+			Annotations: map[string]string{tracing.AnnotationTraceParent: traceParent},
+		}}
+		response = admission.Allowed("")
+		operation = admissionv1.Create
+	})
+
+	JustBeforeEach(func() {
+		raw, err := json.Marshal(lrp)
+		Expect(err).NotTo(HaveOccurred())
+
+		req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: operation,
+			Kind:      metav1.GroupVersionKind{Kind: "LRP"},
+			Namespace: "the-namespace",
+			Name:      "the-lrp",
+		}}
+		if operation == admissionv1.Delete {
+			req.OldObject = runtime.RawExtension{Raw: raw}
+		} else {
+			req.Object = runtime.RawExtension{Raw: raw}
+		}
+
+		handler := tracing.NewAdmissionHandler("webhook.Test", admission.HandlerFunc(func(ctx context.Context, _ admission.Request) admission.Response {
+			handled = ctx
+
+			return response
+		}))
+		resp = handler.Handle(context.Background(), req)
+	})
+
+	It("returns the response of the handler", func() {
+		Expect(resp).To(Equal(response))
+	})
+
+	It("traces the request continuing the trace of the object", func() {
+		Expect(spanNames()).To(ConsistOf("webhook.Test"))
+
+		span := recorder.Ended()[0]
+		Expect(span.Parent().TraceID().String()).To(Equal("4bf92f3577b34da6a3ce929d0e0e4736"))
+		Expect(span.Attributes()).To(ContainElements(
+			attribute.String("admission.operation", "CREATE"),
+			tracing.AttributeKind.String("LRP"),
+			attribute.Bool("admission.allowed", true),
+		))
+		Expect(trace.SpanContextFromContext(handled).SpanID()).To(Equal(span.SpanContext().SpanID()))
+	})
+
+	When("the object is deleted", func() {
+		BeforeEach(func() {
+			operation = admissionv1.Delete
+		})
+
+		It("continues the trace of the old object", func() {
+			Expect(recorder.Ended()[0].Parent().TraceID().String()).To(Equal("4bf92f3577b34da6a3ce929d0e0e4736"))
+		})
+	})
+
+	When("the request is denied", func() {
+		BeforeEach(func() {
+			response = admission.Denied("not today")
+		})
+
+		It("records the reason", func() {
+			Expect(recorder.Ended()[0].Attributes()).To(ContainElements(
+				attribute.Bool("admission.allowed", false),
+				attribute.String("admission.reason", "not today"),
+			))
+		})
+	})
+})
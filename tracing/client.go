@@ -0,0 +1,137 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// Client traces the calls to the Kubernetes API, or to the cache for reads,
+// made within a traced operation such as a reconcile. Objects which are not
+// found are not counted as failures, as the reconcilers expect them.
+type Client struct {
+	client.Client
+}
+
+func NewClient(c client.Client) *Client {
+	return &Client{Client: c}
+}
+
+func (c *Client) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	ctx, span := c.start(ctx, "client.Get", obj, AttributeNamespace.String(key.Namespace), AttributeName.String(key.Name))
+	err := c.Client.Get(ctx, key, obj)
+	c.end(span, err)
+
+	return err
+}
+
+func (c *Client) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+
+	ctx, span := c.start(ctx, "client.List", list, AttributeNamespace.String(listOpts.Namespace))
+	err := c.Client.List(ctx, list, opts...)
+	c.end(span, err)
+
+	return err
+}
+
+func (c *Client) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	ctx, span := c.startFor(ctx, "client.Create", obj)
+	err := c.Client.Create(ctx, obj, opts...)
+	c.end(span, err)
+
+	return err
+}
+
+func (c *Client) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	ctx, span := c.startFor(ctx, "client.Delete", obj)
+	err := c.Client.Delete(ctx, obj, opts...)
+	c.end(span, err)
+
+	return err
+}
+
+func (c *Client) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	ctx, span := c.startFor(ctx, "client.Update", obj)
+	err := c.Client.Update(ctx, obj, opts...)
+	c.end(span, err)
+
+	return err
+}
+
+func (c *Client) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	ctx, span := c.startFor(ctx, "client.Patch", obj)
+	err := c.Client.Patch(ctx, obj, patch, opts...)
+	c.end(span, err)
+
+	return err
+}
+
+func (c *Client) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	deleteAllOfOpts := &client.DeleteAllOfOptions{}
+	deleteAllOfOpts.ApplyOptions(opts)
+
+	ctx, span := c.start(ctx, "client.DeleteAllOf", obj, AttributeNamespace.String(deleteAllOfOpts.Namespace))
+	err := c.Client.DeleteAllOf(ctx, obj, opts...)
+	c.end(span, err)
+
+	return err
+}
+
+func (c *Client) Status() client.StatusWriter {
+	return &statusWriter{StatusWriter: c.Client.Status(), client: c}
+}
+
+func (c *Client) startFor(ctx context.Context, name string, obj client.Object) (context.Context, trace.Span) {
+	return c.start(ctx, name, obj, AttributeNamespace.String(obj.GetNamespace()), AttributeName.String(obj.GetName()))
+}
+
+func (c *Client) start(ctx context.Context, name string, obj runtime.Object, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+
+	if scheme := c.Scheme(); scheme != nil {
+		if gvk, err := apiutil.GVKForObject(obj, scheme); err == nil {
+			attributes = append(attributes, AttributeKind.String(gvk.Kind))
+		}
+	}
+
+	return tracer().Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+func (c *Client) end(span trace.Span, err error) {
+	if apierrors.IsNotFound(err) {
+		span.SetAttributes(attribute.Bool("k8s.object.not_found", true))
+		err = nil
+	}
+
+	End(span, err)
+}
+
+type statusWriter struct {
+	client.StatusWriter
+	client *Client
+}
+
+func (w *statusWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	ctx, span := w.client.startFor(ctx, "client.Status.Update", obj)
+	err := w.StatusWriter.Update(ctx, obj, opts...)
+	w.client.end(span, err)
+
+	return err
+}
+
+func (w *statusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	ctx, span := w.client.startFor(ctx, "client.Status.Patch", obj)
+	err := w.StatusWriter.Patch(ctx, obj, patch, opts...)
+	w.client.end(span, err)
+
+	return err
+}
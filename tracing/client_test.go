@@ -0,0 +1,79 @@
+package tracing_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/eirini-controller/k8s/k8sfakes"
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"code.cloudfoundry.org/eirini-controller/tracing"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Client", func() {
+	var (
+		fakeClient    *k8sfakes.FakeClient
+		statusWriter  *k8sfakes.FakeStatusWriter
+		tracingClient *tracing.Client
+		lrp           *eiriniv1.LRP
+		ctx           context.Context
+	)
+
+	BeforeEach(func() {
+		fakeClient = new(k8sfakes.FakeClient)
+		statusWriter = new(k8sfakes.FakeStatusWriter)
+		fakeClient.StatusReturns(statusWriter)
+		tracingClient = tracing.NewClient(fakeClient)
+		lrp = &eiriniv1.LRP{ObjectMeta: metav1.ObjectMeta{Namespace: "the-namespace", Name: "the-lrp"}}
+
+		var span trace.Span
+		ctx, span = tracing.Start(context.Background(), "reconcile", lrp)
+		DeferCleanup(func() { span.End() })
+	})
+
+	It("traces the calls within a traced operation", func() {
+		Expect(tracingClient.Get(ctx, types.NamespacedName{Namespace: "the-namespace", Name: "the-lrp"}, &eiriniv1.LRP{})).To(Succeed())
+		Expect(tracingClient.List(ctx, &eiriniv1.LRPList{}, client.InNamespace("the-namespace"))).To(Succeed())
+		Expect(tracingClient.Create(ctx, lrp)).To(Succeed())
+		Expect(tracingClient.Patch(ctx, lrp, client.MergeFrom(lrp))).To(Succeed())
+		Expect(tracingClient.Status().Patch(ctx, lrp, client.MergeFrom(lrp))).To(Succeed())
+
+		Expect(spanNames()).To(Equal([]string{"client.Get", "client.List", "client.Create", "client.Patch", "client.Status.Patch"}))
+		Expect(recorder.Ended()[0].Attributes()).To(ContainElements(
+			tracing.AttributeNamespace.String("the-namespace"),
+			tracing.AttributeName.String("the-lrp"),
+		))
+		Expect(fakeClient.GetCallCount()).To(Equal(1))
+		Expect(statusWriter.PatchCallCount()).To(Equal(1))
+	})
+
+	It("does not trace calls outside traced operations", func() {
+		Expect(tracingClient.Get(context.Background(), types.NamespacedName{Namespace: "the-namespace", Name: "the-lrp"}, &eiriniv1.LRP{})).To(Succeed())
+
+		Expect(spanNames()).To(BeEmpty())
+		Expect(fakeClient.GetCallCount()).To(Equal(1))
+	})
+
+	It("does not fail the spans of objects not found", func() {
+		fakeClient.GetReturns(apierrors.NewNotFound(eiriniv1.Resource("lrp"), "the-lrp"))
+
+		err := tracingClient.Get(ctx, types.NamespacedName{Namespace: "the-namespace", Name: "the-lrp"}, &eiriniv1.LRP{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		Expect(recorder.Ended()[0].Status().Code).To(Equal(codes.Unset))
+	})
+
+	It("fails the spans of failed calls", func() {
+		fakeClient.CreateReturns(apierrors.NewBadRequest("boom"))
+
+		Expect(tracingClient.Create(ctx, lrp)).To(MatchError("boom"))
+
+		Expect(recorder.Ended()[0].Status().Code).To(Equal(codes.Error))
+	})
+})
@@ -0,0 +1,49 @@
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ConversionHandler traces the conversion requests handled by a webhook,
+// failing the spans of the requests which are not answered with a success.
+type ConversionHandler struct {
+	name    string
+	handler http.Handler
+}
+
+func NewConversionHandler(name string, handler http.Handler) *ConversionHandler {
+	return &ConversionHandler{
+		name:    name,
+		handler: handler,
+	}
+}
+
+func (h *ConversionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer().Start(r.Context(), h.name)
+
+	recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	h.handler.ServeHTTP(recorder, r.WithContext(ctx))
+
+	span.SetAttributes(attribute.Int("http.status_code", recorder.status))
+
+	var err error
+	if recorder.status >= http.StatusBadRequest {
+		err = errors.Errorf("conversion failed with status %d", recorder.status)
+	}
+
+	End(span, err)
+}
+
+// statusRecorder records the status code written to the response.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
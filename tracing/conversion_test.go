@@ -0,0 +1,57 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/eirini-controller/tracing"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ = Describe("ConversionHandler", func() {
+	var (
+		status  int
+		handled context.Context
+		resp    *httptest.ResponseRecorder
+	)
+
+	BeforeEach(func() {
+		status = http.StatusOK
+	})
+
+	JustBeforeEach(func() {
+		handler := tracing.NewConversionHandler("webhook.Conversion", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handled = r.Context()
+			w.WriteHeader(status)
+		}))
+
+		resp = httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/convert", nil))
+	})
+
+	It("traces the request", func() {
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(spanNames()).To(ConsistOf("webhook.Conversion"))
+
+		span := recorder.Ended()[0]
+		Expect(span.Attributes()).To(ContainElement(attribute.Int("http.status_code", http.StatusOK)))
+		Expect(span.Status().Code).To(Equal(codes.Unset))
+		Expect(trace.SpanContextFromContext(handled).SpanID()).To(Equal(span.SpanContext().SpanID()))
+	})
+
+	When("the conversion fails", func() {
+		BeforeEach(func() {
+			status = http.StatusBadRequest
+		})
+
+		It("marks the span as failed", func() {
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
+			Expect(recorder.Ended()[0].Status().Code).To(Equal(codes.Error))
+		})
+	})
+})
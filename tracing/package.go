@@ -0,0 +1,5 @@
+// Package tracing exports the OpenTelemetry spans of the controller. The
+// reconciles of the LRPs and tasks are linked to the trace of their
+// eirini.cloudfoundry.org/traceparent and eirini.cloudfoundry.org/tracestate
+// annotations, so that the requests creating them lead to their reconciles.
+package tracing
@@ -0,0 +1,154 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	TracerName  = "code.cloudfoundry.org/eirini-controller"
+	ServiceName = "eirini-controller"
+
+	annotationPrefix      = "eirini.cloudfoundry.org/"
+	AnnotationTraceParent = annotationPrefix + "traceparent"
+	AnnotationTraceState  = annotationPrefix + "tracestate"
+
+	AttributeNamespace = attribute.Key("k8s.namespace.name")
+	AttributeKind      = attribute.Key("k8s.object.kind")
+	AttributeName      = attribute.Key("k8s.object.name")
+)
+
+// Setup exports the spans to the configured OTLP collector, and returns the
+// function flushing the pending ones on shutdown. Spans are dropped when
+// tracing is disabled.
+func Setup(ctx context.Context, config eirinictrl.Tracing) (func(context.Context) error, error) {
+	if !config.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	options := []otlptracehttp.Option{}
+	if config.Endpoint != "" {
+		options = append(options, otlptracehttp.WithEndpoint(config.Endpoint))
+	}
+
+	if config.Insecure {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+
+	exporter, err := otlptracehttp.New(ctx, options...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the OTLP trace exporter")
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(ServiceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio(config)))),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+// sampleRatio returns the configured ratio of new traces sampled, sampling
+// them all when it is unset.
+func sampleRatio(config eirinictrl.Tracing) float64 {
+	if config.SampleRatio == nil {
+		return 1
+	}
+
+	return *config.SampleRatio
+}
+
+// Start starts a span about the object.
+func Start(ctx context.Context, name string, obj metav1.Object, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return start(ctx, name, obj, attributes)
+}
+
+// StartFromObject starts a span about the object linked to the trace of its
+// annotations, if any. The span is not a child of that trace: an object is
+// reconciled many times over its life, long after the request that set the
+// annotations.
+func StartFromObject(ctx context.Context, name string, obj metav1.Object, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	linked := trace.SpanContextFromContext(Extract(context.Background(), obj.GetAnnotations()))
+	if !linked.IsValid() {
+		return start(ctx, name, obj, attributes)
+	}
+
+	return start(ctx, name, obj, attributes, trace.WithLinks(trace.Link{SpanContext: linked}))
+}
+
+func start(
+	ctx context.Context,
+	name string,
+	obj metav1.Object,
+	attributes []attribute.KeyValue,
+	options ...trace.SpanStartOption,
+) (context.Context, trace.Span) {
+	attributes = append(attributes, AttributeNamespace.String(obj.GetNamespace()), AttributeName.String(obj.GetName()))
+	options = append(options, trace.WithAttributes(attributes...))
+
+	return tracer().Start(ctx, name, options...)
+}
+
+// Extract returns the context continuing the trace of the annotations.
+func Extract(ctx context.Context, annotations map[string]string) context.Context {
+	return propagation.TraceContext{}.Extract(ctx, annotationCarrier(annotations))
+}
+
+// Inject sets the annotations continuing the trace of the context.
+func Inject(ctx context.Context, annotations map[string]string) {
+	propagation.TraceContext{}.Inject(ctx, annotationCarrier(annotations))
+}
+
+// End ends the span, marking it as failed when err is set.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+func tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// annotationCarrier maps the W3C trace context keys to the annotations.
+type annotationCarrier map[string]string
+
+func (c annotationCarrier) Get(key string) string {
+	return c[annotationPrefix+key]
+}
+
+func (c annotationCarrier) Set(key, value string) {
+	c[annotationPrefix+key] = value
+}
+
+func (c annotationCarrier) Keys() []string {
+	keys := []string{}
+
+	for annotation := range c {
+		if key := strings.TrimPrefix(annotation, annotationPrefix); key != annotation {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
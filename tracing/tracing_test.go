@@ -0,0 +1,102 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"code.cloudfoundry.org/eirini-controller/tracing"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+var _ = Describe("Tracing", func() {
+	var lrp *eiriniv1.LRP
+
+	BeforeEach(func() {
+		lrp = &eiriniv1.LRP{ObjectMeta: metav1.ObjectMeta{Namespace: "the-namespace", Name: "the-lrp"}}
+	})
+
+	Describe("Setup", func() {
+		It("does nothing when tracing is disabled", func() {
+			shutdown, err := tracing.Setup(context.Background(), eirinictrl.Tracing{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(shutdown(context.Background())).To(Succeed())
+		})
+	})
+
+	Describe("StartFromObject", func() {
+		It("starts a span about the object", func() {
+			_, span := tracing.StartFromObject(context.Background(), "the-span", lrp)
+			span.End()
+
+			Expect(spanNames()).To(ConsistOf("the-span"))
+			Expect(recorder.Ended()[0].Attributes()).To(ContainElements(
+				tracing.AttributeNamespace.String("the-namespace"),
+				tracing.AttributeName.String("the-lrp"),
+			))
+			Expect(recorder.Ended()[0].Parent().IsValid()).To(BeFalse())
+		})
+
+		It("links the span to the trace of the object annotations", func() {
+			lrp.Annotations = map[string]string{tracing.AnnotationTraceParent: traceParent}
+
+			_, span := tracing.StartFromObject(context.Background(), "the-span", lrp)
+			span.End()
+
+			Expect(recorder.Ended()[0].Parent().IsValid()).To(BeFalse())
+			Expect(recorder.Ended()[0].Links()).To(HaveLen(1))
+			linked := recorder.Ended()[0].Links()[0].SpanContext
+			Expect(linked.IsRemote()).To(BeTrue())
+			Expect(linked.TraceID().String()).To(Equal("4bf92f3577b34da6a3ce929d0e0e4736"))
+			Expect(linked.SpanID().String()).To(Equal("00f067aa0ba902b7"))
+		})
+
+		It("keeps the span in the trace of the context", func() {
+			lrp.Annotations = map[string]string{tracing.AnnotationTraceParent: traceParent}
+			ctx, parent := tracing.Start(context.Background(), "the-parent", lrp)
+
+			_, span := tracing.StartFromObject(ctx, "the-span", lrp)
+			span.End()
+			parent.End()
+
+			Expect(recorder.Ended()[0].Parent().SpanID()).To(Equal(parent.SpanContext().SpanID()))
+		})
+	})
+
+	Describe("Inject", func() {
+		It("sets the trace context annotations", func() {
+			ctx, span := tracing.Start(context.Background(), "the-span", lrp)
+			defer span.End()
+
+			annotations := map[string]string{}
+			tracing.Inject(ctx, annotations)
+
+			Expect(annotations).To(HaveKeyWithValue(tracing.AnnotationTraceParent, ContainSubstring(span.SpanContext().TraceID().String())))
+			Expect(trace.SpanContextFromContext(tracing.Extract(context.Background(), annotations)).TraceID()).To(Equal(span.SpanContext().TraceID()))
+		})
+	})
+
+	Describe("End", func() {
+		It("marks the span as failed on error", func() {
+			_, span := tracing.Start(context.Background(), "the-span", lrp)
+			tracing.End(span, errors.New("boom"))
+
+			Expect(recorder.Ended()[0].Status().Code).To(Equal(codes.Error))
+			Expect(recorder.Ended()[0].Status().Description).To(Equal("boom"))
+		})
+
+		It("leaves successful spans unset", func() {
+			_, span := tracing.Start(context.Background(), "the-span", lrp)
+			tracing.End(span, nil)
+
+			Expect(recorder.Ended()[0].Status().Code).To(Equal(codes.Unset))
+		})
+	})
+})
@@ -5,20 +5,34 @@ import (
 	"github.com/go-logr/logr"
 )
 
-// LagerLogr is a logr (https://github.com/go-logr/logr) implementation over lager.Logger
+// LevelEnabler tells whether a session logs at a level.
+type LevelEnabler interface {
+	Enabled(sessionName string, level lager.LogLevel) bool
+}
+
+// LagerLogr is a logr (https://github.com/go-logr/logr) implementation over lager.Logger.
+// Info logs of verbosity 0 are logged at the info level, the more verbose
+// ones at the debug level.
 type LagerLogr struct {
 	logger lager.Logger
+	levels LevelEnabler
 }
 
-func NewLagerLogr(logger lager.Logger) logr.Logger {
-	return logr.New(LagerLogr{logger: logger})
+func NewLagerLogr(logger lager.Logger, levels LevelEnabler) logr.Logger {
+	return logr.New(LagerLogr{logger: logger, levels: levels})
 }
 
 func (l LagerLogr) Enabled(level int) bool {
-	return true
+	return l.levels.Enabled(l.logger.SessionName(), toLagerLevel(level))
 }
 
 func (l LagerLogr) Info(level int, msg string, kvs ...interface{}) {
+	if toLagerLevel(level) == lager.DEBUG {
+		l.logger.Debug(msg, toLagerData(kvs))
+
+		return
+	}
+
 	l.logger.Info(msg, toLagerData(kvs))
 }
 
@@ -40,6 +54,14 @@ func (l LagerLogr) WithName(name string) logr.LogSink {
 	return l
 }
 
+func toLagerLevel(level int) lager.LogLevel {
+	if level > 0 {
+		return lager.DEBUG
+	}
+
+	return lager.INFO
+}
+
 func toLagerData(kvs []interface{}) lager.Data {
 	data := lager.Data{}
 	for i := 0; i < len(kvs); i += 2 {
@@ -3,6 +3,7 @@ package util_test
 import (
 	"errors"
 
+	"code.cloudfoundry.org/eirini-controller/tests"
 	"code.cloudfoundry.org/eirini-controller/util"
 	"code.cloudfoundry.org/eirini-controller/util/utilfakes"
 	"code.cloudfoundry.org/lager"
@@ -16,16 +17,47 @@ import (
 var _ = Describe("LagerLogr", func() {
 	var (
 		fakeLogger *utilfakes.FakeLogger
+		levelSink  *util.LevelSink
 		lagerLogr  logr.Logger
 	)
 
 	BeforeEach(func() {
 		fakeLogger = new(utilfakes.FakeLogger)
-		lagerLogr = util.NewLagerLogr(fakeLogger)
+		fakeLogger.SessionNameReturns("eirini-controller.controller-runtime")
+
+		var err error
+		levelSink, err = util.NewLevelSink(tests.NewTestSink(), "info", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		lagerLogr = util.NewLagerLogr(fakeLogger, levelSink)
 	})
 
-	It("is always enabled", func() {
-		Expect(lagerLogr.Enabled()).To(BeTrue())
+	Describe("Enabled", func() {
+		It("is enabled at verbosity 0 when the level is info", func() {
+			Expect(lagerLogr.Enabled()).To(BeTrue())
+			Expect(lagerLogr.V(1).Enabled()).To(BeFalse())
+		})
+
+		When("the session logs at the debug level", func() {
+			BeforeEach(func() {
+				Expect(levelSink.SetLevels("error", map[string]string{"controller-runtime": "debug"})).To(Succeed())
+			})
+
+			It("is enabled at all verbosities", func() {
+				Expect(lagerLogr.Enabled()).To(BeTrue())
+				Expect(lagerLogr.V(3).Enabled()).To(BeTrue())
+			})
+		})
+
+		When("the session logs at the error level", func() {
+			BeforeEach(func() {
+				Expect(levelSink.SetLevels("debug", map[string]string{"controller-runtime": "error"})).To(Succeed())
+			})
+
+			It("is disabled", func() {
+				Expect(lagerLogr.Enabled()).To(BeFalse())
+			})
+		})
 	})
 
 	Describe("Info", func() {
@@ -42,6 +74,34 @@ var _ = Describe("LagerLogr", func() {
 		})
 	})
 
+	Describe("verbose Info", func() {
+		BeforeEach(func() {
+			Expect(levelSink.SetLevels("debug", nil)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			lagerLogr.V(1).Info("some-message", "some-key", "some-value")
+		})
+
+		It("delegates to lagger's Debug", func() {
+			Expect(fakeLogger.InfoCallCount()).To(BeZero())
+			Expect(fakeLogger.DebugCallCount()).To(Equal(1))
+			actualMsg, actualLagerData := fakeLogger.DebugArgsForCall(0)
+			Expect(actualMsg).To(Equal("some-message"))
+			Expect(actualLagerData).To(ConsistOf(lager.Data{"some-key": "some-value"}))
+		})
+
+		When("debug logs are disabled", func() {
+			BeforeEach(func() {
+				Expect(levelSink.SetLevels("info", nil)).To(Succeed())
+			})
+
+			It("does not log", func() {
+				Expect(fakeLogger.DebugCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("Error", func() {
 		JustBeforeEach(func() {
 			lagerLogr.Error(errors.New("some-error"), "some-message", "some-key", "some-value")
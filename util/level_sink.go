@@ -0,0 +1,122 @@
+package util
+
+import (
+	"strings"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/pkg/errors"
+)
+
+const DefaultLogLevel = lager.INFO
+
+// LevelSink drops the logs below the minimum level of their component. The
+// levels can be changed at runtime.
+type LevelSink struct {
+	sink lager.Sink
+
+	mutex      sync.RWMutex
+	minLevel   lager.LogLevel
+	components map[string]lager.LogLevel
+}
+
+// NewLevelSink wraps sink, which should accept all levels. An empty level
+// stands for the default one.
+func NewLevelSink(sink lager.Sink, level string, components map[string]string) (*LevelSink, error) {
+	levelSink := &LevelSink{sink: sink}
+
+	if err := levelSink.SetLevels(level, components); err != nil {
+		return nil, err
+	}
+
+	return levelSink, nil
+}
+
+func (s *LevelSink) Log(log lager.LogFormat) {
+	if !s.Enabled(log.Message, log.LogLevel) {
+		return
+	}
+
+	s.sink.Log(log)
+}
+
+// Enabled tells whether a log of the given level would be written by the
+// named session, or with the given message.
+func (s *LevelSink) Enabled(sessionName string, level lager.LogLevel) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return level >= s.levelOf(sessionName)
+}
+
+// SetLevels replaces the minimum level and the component levels. Nothing
+// changes when any of them is invalid.
+func (s *LevelSink) SetLevels(level string, components map[string]string) error {
+	minLevel, err := ParseLogLevel(level)
+	if err != nil {
+		return err
+	}
+
+	componentLevels := map[string]lager.LogLevel{}
+
+	for component, componentLevel := range components {
+		componentLevels[component], err = ParseLogLevel(componentLevel)
+		if err != nil {
+			return errors.Wrapf(err, "invalid level of component %q", component)
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.minLevel = minLevel
+	s.components = componentLevels
+
+	return nil
+}
+
+// Levels returns the minimum level and the component levels.
+func (s *LevelSink) Levels() (string, map[string]string) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	components := map[string]string{}
+	for component, level := range s.components {
+		components[component] = level.String()
+	}
+
+	return s.minLevel.String(), components
+}
+
+// levelOf returns the level of the most specific component the session
+// belongs to. Session names are prefixed with the logger component, e.g.
+// "eirini-controller.lrp-reconciler.reconcile-lrp".
+func (s *LevelSink) levelOf(sessionName string) lager.LogLevel {
+	_, session, _ := strings.Cut(sessionName, ".")
+
+	level, matchLen := s.minLevel, -1
+
+	for component, componentLevel := range s.components {
+		if session != component && !strings.HasPrefix(session, component+".") {
+			continue
+		}
+
+		if len(component) > matchLen {
+			level, matchLen = componentLevel, len(component)
+		}
+	}
+
+	return level
+}
+
+// ParseLogLevel parses a lager level name, case insensitively. An empty
+// name stands for the default level.
+func ParseLogLevel(level string) (lager.LogLevel, error) {
+	if level == "" {
+		return DefaultLogLevel, nil
+	}
+
+	logLevel, err := lager.LogLevelFromString(strings.ToLower(level))
+
+	return logLevel, errors.Wrap(err, "failed to parse log level")
+}
@@ -0,0 +1,114 @@
+package util_test
+
+import (
+	"code.cloudfoundry.org/eirini-controller/tests"
+	"code.cloudfoundry.org/eirini-controller/util"
+	"code.cloudfoundry.org/lager"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LevelSink", func() {
+	var (
+		testSink   *tests.TestSink
+		level      string
+		components map[string]string
+		levelSink  *util.LevelSink
+		err        error
+	)
+
+	BeforeEach(func() {
+		testSink = tests.NewTestSink()
+		level = "info"
+		components = map[string]string{
+			"lrp-reconciler":               "error",
+			"lrp-reconciler.reconcile-lrp": "debug",
+		}
+	})
+
+	JustBeforeEach(func() {
+		levelSink, err = util.NewLevelSink(testSink, level, components)
+	})
+
+	log := func(message string, level lager.LogLevel) {
+		levelSink.Log(lager.LogFormat{Source: "eirini-controller", Message: message, LogLevel: level})
+	}
+
+	It("drops the logs below the minimum level", func() {
+		Expect(err).NotTo(HaveOccurred())
+
+		log("eirini-controller.task-reconciler.debug", lager.DEBUG)
+		log("eirini-controller.task-reconciler.info", lager.INFO)
+
+		Expect(testSink.LogMessages()).To(ConsistOf("eirini-controller.task-reconciler.info"))
+	})
+
+	It("uses the level of the most specific component", func() {
+		log("eirini-controller.lrp-reconciler.info", lager.INFO)
+		log("eirini-controller.lrp-reconciler.error", lager.ERROR)
+		log("eirini-controller.lrp-reconciler.reconcile-lrp.debug", lager.DEBUG)
+		log("eirini-controller.lrp-reconciler-other.info", lager.INFO)
+
+		Expect(testSink.LogMessages()).To(ConsistOf(
+			"eirini-controller.lrp-reconciler.error",
+			"eirini-controller.lrp-reconciler.reconcile-lrp.debug",
+			"eirini-controller.lrp-reconciler-other.info",
+		))
+	})
+
+	It("returns the levels", func() {
+		actualLevel, actualComponents := levelSink.Levels()
+		Expect(actualLevel).To(Equal("info"))
+		Expect(actualComponents).To(Equal(components))
+	})
+
+	When("the level is empty", func() {
+		BeforeEach(func() {
+			level = ""
+		})
+
+		It("defaults to info", func() {
+			actualLevel, _ := levelSink.Levels()
+			Expect(actualLevel).To(Equal("info"))
+		})
+	})
+
+	When("the level is invalid", func() {
+		BeforeEach(func() {
+			level = "verbose"
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError(ContainSubstring("invalid log level: verbose")))
+		})
+	})
+
+	When("a component level is invalid", func() {
+		BeforeEach(func() {
+			components["task-reconciler"] = "verbose"
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError(ContainSubstring(`invalid level of component "task-reconciler"`)))
+		})
+	})
+
+	Describe("SetLevels", func() {
+		It("changes the levels", func() {
+			Expect(levelSink.SetLevels("debug", nil)).To(Succeed())
+
+			log("eirini-controller.lrp-reconciler.debug", lager.DEBUG)
+
+			Expect(testSink.LogMessages()).To(ConsistOf("eirini-controller.lrp-reconciler.debug"))
+			Expect(levelSink.Enabled("eirini-controller.lrp-reconciler", lager.DEBUG)).To(BeTrue())
+		})
+
+		It("keeps the levels when any is invalid", func() {
+			Expect(levelSink.SetLevels("debug", map[string]string{"lrp-reconciler": "verbose"})).NotTo(Succeed())
+
+			actualLevel, actualComponents := levelSink.Levels()
+			Expect(actualLevel).To(Equal("info"))
+			Expect(actualComponents).To(Equal(components))
+		})
+	})
+})
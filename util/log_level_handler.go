@@ -0,0 +1,80 @@
+package util
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// LogLevels is the body of the log level endpoint.
+type LogLevels struct {
+	Level      string            `json:"level"`
+	Components map[string]string `json:"components"`
+}
+
+// LogLevelHandler serves the levels of a LevelSink. GET returns them and PUT
+// replaces them, unless the handler is read-only.
+type LogLevelHandler struct {
+	logger   lager.Logger
+	sink     *LevelSink
+	readOnly bool
+}
+
+func NewLogLevelHandler(logger lager.Logger, sink *LevelSink) *LogLevelHandler {
+	return &LogLevelHandler{
+		logger: logger.Session("log-level-handler"),
+		sink:   sink,
+	}
+}
+
+// NewReadOnlyLogLevelHandler creates a handler which only returns the
+// levels, for the endpoints anyone reaching the pod can call.
+func NewReadOnlyLogLevelHandler(logger lager.Logger, sink *LevelSink) *LogLevelHandler {
+	handler := NewLogLevelHandler(logger, sink)
+	handler.readOnly = true
+
+	return handler
+}
+
+func (h *LogLevelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet:
+	case r.Method == http.MethodPut && !h.readOnly:
+		var levels LogLevels
+		if err := json.NewDecoder(r.Body).Decode(&levels); err != nil {
+			http.Error(w, "invalid body: "+err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		if err := h.sink.SetLevels(levels.Level, levels.Components); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		h.logger.Info("log-levels-changed", lager.Data{"level": levels.Level, "components": levels.Components})
+	default:
+		w.Header().Set("Allow", h.allowedMethods())
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	level, components := h.sink.Levels()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(LogLevels{Level: level, Components: components}); err != nil {
+		h.logger.Error("failed-to-encode-log-levels", err)
+	}
+}
+
+func (h *LogLevelHandler) allowedMethods() string {
+	if h.readOnly {
+		return http.MethodGet
+	}
+
+	return "GET, PUT"
+}
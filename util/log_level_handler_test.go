@@ -0,0 +1,118 @@
+package util_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"code.cloudfoundry.org/eirini-controller/tests"
+	"code.cloudfoundry.org/eirini-controller/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LogLevelHandler", func() {
+	var (
+		levelSink *util.LevelSink
+		handler   *util.LogLevelHandler
+		request   *http.Request
+		recorder  *httptest.ResponseRecorder
+	)
+
+	BeforeEach(func() {
+		var err error
+		levelSink, err = util.NewLevelSink(tests.NewTestSink(), "info", map[string]string{"lrp-reconciler": "debug"})
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = util.NewLogLevelHandler(tests.NewTestLogger("log-level-handler"), levelSink)
+		recorder = httptest.NewRecorder()
+		request = httptest.NewRequest(http.MethodGet, "/log-level", nil)
+	})
+
+	JustBeforeEach(func() {
+		handler.ServeHTTP(recorder, request)
+	})
+
+	levels := func() util.LogLevels {
+		var levels util.LogLevels
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &levels)).To(Succeed())
+
+		return levels
+	}
+
+	It("returns the levels", func() {
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(levels()).To(Equal(util.LogLevels{
+			Level:      "info",
+			Components: map[string]string{"lrp-reconciler": "debug"},
+		}))
+	})
+
+	When("the levels are put", func() {
+		BeforeEach(func() {
+			request = httptest.NewRequest(http.MethodPut, "/log-level", strings.NewReader(`{"level":"error","components":{"task-reconciler":"debug"}}`))
+		})
+
+		It("replaces the levels", func() {
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(levels()).To(Equal(util.LogLevels{
+				Level:      "error",
+				Components: map[string]string{"task-reconciler": "debug"},
+			}))
+
+			level, components := levelSink.Levels()
+			Expect(level).To(Equal("error"))
+			Expect(components).To(Equal(map[string]string{"task-reconciler": "debug"}))
+		})
+
+		When("a level is invalid", func() {
+			BeforeEach(func() {
+				request = httptest.NewRequest(http.MethodPut, "/log-level", strings.NewReader(`{"level":"verbose"}`))
+			})
+
+			It("rejects the request", func() {
+				Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+				Expect(recorder.Body.String()).To(ContainSubstring("invalid log level: verbose"))
+
+				level, _ := levelSink.Levels()
+				Expect(level).To(Equal("info"))
+			})
+		})
+
+		When("the body is not JSON", func() {
+			BeforeEach(func() {
+				request = httptest.NewRequest(http.MethodPut, "/log-level", strings.NewReader(`debug`))
+			})
+
+			It("rejects the request", func() {
+				Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		When("the handler is read-only", func() {
+			BeforeEach(func() {
+				handler = util.NewReadOnlyLogLevelHandler(tests.NewTestLogger("log-level-handler"), levelSink)
+			})
+
+			It("rejects the request", func() {
+				Expect(recorder.Code).To(Equal(http.StatusMethodNotAllowed))
+				Expect(recorder.Header().Get("Allow")).To(Equal(http.MethodGet))
+
+				level, _ := levelSink.Levels()
+				Expect(level).To(Equal("info"))
+			})
+		})
+	})
+
+	When("the method is not supported", func() {
+		BeforeEach(func() {
+			request = httptest.NewRequest(http.MethodDelete, "/log-level", nil)
+		})
+
+		It("rejects the request", func() {
+			Expect(recorder.Code).To(Equal(http.StatusMethodNotAllowed))
+			Expect(recorder.Header().Get("Allow")).To(Equal("GET, PUT"))
+		})
+	})
+})
@@ -0,0 +1,52 @@
+package util
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const logLevelServerReadHeaderTimeout = 10 * time.Second
+
+// LogLevelServer serves the /log-level endpoint of a handler at an address,
+// on every replica rather than the leader only.
+type LogLevelServer struct {
+	addr    string
+	handler http.Handler
+}
+
+func NewLogLevelServer(addr string, handler http.Handler) *LogLevelServer {
+	return &LogLevelServer{
+		addr:    addr,
+		handler: handler,
+	}
+}
+
+// Start serves until the context is done.
+func (s *LogLevelServer) Start(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.Handle("/log-level", s.handler)
+
+	server := &http.Server{
+		Addr:              s.addr,
+		Handler:           mux,
+		ReadHeaderTimeout: logLevelServerReadHeaderTimeout,
+	}
+
+	go func() {
+		<-ctx.Done()
+		_ = server.Shutdown(context.Background())
+	}()
+
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return errors.Wrap(err, "failed to serve the log levels")
+	}
+
+	return nil
+}
+
+func (s *LogLevelServer) NeedLeaderElection() bool {
+	return false
+}
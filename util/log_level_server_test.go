@@ -0,0 +1,65 @@
+package util_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+
+	"code.cloudfoundry.org/eirini-controller/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LogLevelServer", func() {
+	var (
+		addr   string
+		server *util.LogLevelServer
+		cancel context.CancelFunc
+		done   chan error
+	)
+
+	BeforeEach(func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		addr = listener.Addr().String()
+		Expect(listener.Close()).To(Succeed())
+
+		server = util.NewLogLevelServer(addr, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		}))
+
+		var ctx context.Context
+		ctx, cancel = context.WithCancel(context.Background())
+		done = make(chan error, 1)
+
+		go func() {
+			done <- server.Start(ctx)
+		}()
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	It("serves the handler at /log-level", func() {
+		Eventually(func() (int, error) {
+			resp, err := http.Get(fmt.Sprintf("http://%s/log-level", addr)) //nolint:noctx
+			if err != nil {
+				return 0, err
+			}
+			defer resp.Body.Close()
+
+			return resp.StatusCode, nil
+		}).Should(Equal(http.StatusTeapot))
+	})
+
+	It("stops when the context is done", func() {
+		cancel()
+		Eventually(done).Should(Receive(BeNil()))
+	})
+
+	It("runs on every replica", func() {
+		Expect(server.NeedLeaderElection()).To(BeFalse())
+	})
+})
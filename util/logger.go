@@ -0,0 +1,39 @@
+package util
+
+import (
+	"fmt"
+	"io"
+
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/lager"
+)
+
+const (
+	LogFormatPretty = "pretty"
+	LogFormatJSON   = "json"
+)
+
+// NewLogger creates a logger writing to w as configured. The returned sink
+// changes its levels at runtime.
+func NewLogger(component string, config eirinictrl.Logging, w io.Writer) (lager.Logger, *LevelSink, error) {
+	var sink lager.Sink
+
+	switch config.Format {
+	case "", LogFormatPretty:
+		sink = lager.NewPrettySink(w, lager.DEBUG)
+	case LogFormatJSON:
+		sink = lager.NewWriterSink(w, lager.DEBUG)
+	default:
+		return nil, nil, fmt.Errorf("invalid log format %q, expected %q or %q", config.Format, LogFormatPretty, LogFormatJSON)
+	}
+
+	levelSink, err := NewLevelSink(sink, config.Level, config.Components)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logger := lager.NewLogger(component)
+	logger.RegisterSink(levelSink)
+
+	return logger, levelSink, nil
+}
@@ -0,0 +1,81 @@
+package util_test
+
+import (
+	"encoding/json"
+
+	eirinictrl "code.cloudfoundry.org/eirini-controller"
+	"code.cloudfoundry.org/eirini-controller/util"
+	"code.cloudfoundry.org/lager"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("NewLogger", func() {
+	var (
+		config eirinictrl.Logging
+		buffer *gbytes.Buffer
+		logger lager.Logger
+		err    error
+	)
+
+	BeforeEach(func() {
+		config = eirinictrl.Logging{}
+		buffer = gbytes.NewBuffer()
+	})
+
+	JustBeforeEach(func() {
+		logger, _, err = util.NewLogger("eirini-controller", config, buffer)
+	})
+
+	logLine := func() map[string]interface{} {
+		line := map[string]interface{}{}
+		Expect(json.Unmarshal(buffer.Contents(), &line)).To(Succeed())
+
+		return line
+	}
+
+	It("logs pretty info logs by default", func() {
+		Expect(err).NotTo(HaveOccurred())
+
+		logger.Debug("some-debug")
+		Expect(buffer.Contents()).To(BeEmpty())
+
+		logger.Info("some-info")
+		Expect(logLine()).To(HaveKeyWithValue("level", "info"))
+		Expect(logLine()).To(HaveKeyWithValue("message", "eirini-controller.some-info"))
+	})
+
+	When("the format is json", func() {
+		BeforeEach(func() {
+			config.Format = "json"
+			config.Level = "debug"
+		})
+
+		It("logs in lager's compact format", func() {
+			logger.Debug("some-debug")
+			Expect(logLine()).To(HaveKeyWithValue("log_level", BeNumerically("==", lager.DEBUG)))
+			Expect(logLine()).To(HaveKeyWithValue("message", "eirini-controller.some-debug"))
+		})
+	})
+
+	When("the format is invalid", func() {
+		BeforeEach(func() {
+			config.Format = "xml"
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError(ContainSubstring(`invalid log format "xml"`)))
+		})
+	})
+
+	When("the level is invalid", func() {
+		BeforeEach(func() {
+			config.Level = "verbose"
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError(ContainSubstring("invalid log level: verbose")))
+		})
+	})
+})
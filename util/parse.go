@@ -11,7 +11,19 @@ import (
 
 const DockerHubHost = "index.docker.io/v1/"
 
-var dockerRX = regexp.MustCompile(`([a-zA-Z0-9.-]+)(:([0-9]+))?/(\S+/\S+)`)
+var (
+	dockerRX = regexp.MustCompile(`([a-zA-Z0-9.-]+)(:([0-9]+))?/(\S+/\S+)`)
+
+	// imageReferenceRX follows the grammar of github.com/distribution/distribution/reference
+	imageReferenceRX = regexp.MustCompile(
+		`^` +
+			`(?:(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9])(?:\.(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9]))*(?::[0-9]+)?/)?` +
+			`[a-z0-9]+(?:(?:[._]|__|[-]*)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|[-]*)[a-z0-9]+)*)*` +
+			`(?::[\w][\w.-]{0,127})?` +
+			`(?:@[A-Za-z][A-Za-z0-9]*(?:[-_+.][A-Za-z][A-Za-z0-9]*)*:[[:xdigit:]]{32,})?` +
+			`$`,
+	)
+)
 
 func ParseAppIndex(podName string) (int, error) {
 	sl := strings.Split(podName, "-")
@@ -37,3 +49,7 @@ func ParseImageRegistryHost(imageURL string) string {
 
 	return matches[1]
 }
+
+func IsValidImageReference(image string) bool {
+	return imageReferenceRX.MatchString(image)
+}
@@ -1,6 +1,8 @@
 package util_test
 
 import (
+	"strings"
+
 	"code.cloudfoundry.org/eirini-controller/util"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -55,4 +57,20 @@ var _ = Describe("Parse", func() {
 			Expect(util.ParseImageRegistryHost(imageURL)).To(Equal("index.docker.io/v1/"))
 		})
 	})
+
+	DescribeTable("IsValidImageReference",
+		func(image string, valid bool) {
+			Expect(util.IsValidImageReference(image)).To(Equal(valid))
+		},
+		Entry("name only", "busybox", true),
+		Entry("repository and tag", "eirini/dorini:latest", true),
+		Entry("registry with port", "my-registry.io:5000/repo/app:v1.2", true),
+		Entry("digest", "eirini/dorini@sha256:"+strings.Repeat("a", 64), true),
+		Entry("tag and digest", "eirini/dorini:v1@sha256:"+strings.Repeat("a", 64), true),
+		Entry("empty", "", false),
+		Entry("uppercase repository", "eirini/Dorini", false),
+		Entry("whitespace", "eirini/dorini latest", false),
+		Entry("empty tag", "eirini/dorini:", false),
+		Entry("short digest", "eirini/dorini@sha256:abc", false),
+	)
 })